@@ -0,0 +1,48 @@
+package key
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/akfaiz/go-vue-starter-kit/internal/config"
+	"github.com/akfaiz/go-vue-starter-kit/internal/hash/jwtmanager"
+	"github.com/urfave/cli/v3"
+)
+
+var Command = &cli.Command{
+	Name:  "key",
+	Usage: "JWT signing key commands",
+	Commands: []*cli.Command{
+		{
+			Name:  "rotate",
+			Usage: "Generate a new signing key and retire keys whose tokens have expired",
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:  "alg",
+					Usage: "Signing algorithm (RS256, ES256 or EdDSA), defaults to JWT_ALGORITHM",
+				},
+			},
+			Action: func(ctx context.Context, c *cli.Command) error {
+				cfg := config.Load()
+				alg := c.String("alg")
+				if alg == "" {
+					alg = cfg.Auth.JWT.Algorithm
+				}
+				if alg == "" || alg == "HS256" {
+					return fmt.Errorf("HS256 uses JWT_ACCESS_SECRET, set JWT_ALGORITHM or --alg to an asymmetric algorithm")
+				}
+
+				kid, removed, err := jwtmanager.Rotate(cfg.Auth.JWT.KeysDir, alg, cfg.Auth.JWT.AccessExpires+cfg.Auth.JWT.Leeway, time.Now())
+				if err != nil {
+					return err
+				}
+				fmt.Printf("Created %s key %s in %s\n", alg, kid, cfg.Auth.JWT.KeysDir)
+				for _, old := range removed {
+					fmt.Printf("Removed expired key %s\n", old)
+				}
+				return nil
+			},
+		},
+	},
+}
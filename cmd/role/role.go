@@ -0,0 +1,119 @@
+package role
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/akfaiz/go-vue-starter-kit/internal/config"
+	"github.com/akfaiz/go-vue-starter-kit/internal/db"
+	"github.com/akfaiz/go-vue-starter-kit/internal/domain"
+	rolerepo "github.com/akfaiz/go-vue-starter-kit/internal/repository/role"
+	userrepo "github.com/akfaiz/go-vue-starter-kit/internal/repository/user"
+	"github.com/akfaiz/go-vue-starter-kit/internal/service/rbac"
+	"github.com/urfave/cli/v3"
+)
+
+var userFlags = []cli.Flag{
+	&cli.StringFlag{
+		Name:     "email",
+		Aliases:  []string{"e"},
+		Required: true,
+		Usage:    "Email of the user",
+	},
+	&cli.StringFlag{
+		Name:     "role",
+		Aliases:  []string{"r"},
+		Required: true,
+		Usage:    "Name of the role, e.g. " + domain.RoleAdmin,
+	},
+}
+
+var Command = &cli.Command{
+	Name:  "role",
+	Usage: "Role management commands",
+	Commands: []*cli.Command{
+		{
+			Name:  "list",
+			Usage: "List roles and their permissions",
+			Action: func(ctx context.Context, c *cli.Command) error {
+				rbacService, _, err := newServices()
+				if err != nil {
+					return err
+				}
+				roles, err := rbacService.ListRoles(ctx)
+				if err != nil {
+					return err
+				}
+				for _, role := range roles {
+					fmt.Printf("%s\t%s\n", role.Name, strings.Join(role.Permissions, ", "))
+				}
+				return nil
+			},
+		},
+		{
+			Name:  "assign",
+			Usage: "Assign a role to a user",
+			Flags: userFlags,
+			Action: func(ctx context.Context, c *cli.Command) error {
+				rbacService, user, err := findUser(ctx, c.String("email"))
+				if err != nil {
+					return err
+				}
+				if err := rbacService.AssignRole(ctx, user.ID, c.String("role")); err != nil {
+					return roleError(err, c.String("role"))
+				}
+				fmt.Printf("Assigned role %s to %s\n", c.String("role"), user.Email)
+				return nil
+			},
+		},
+		{
+			Name:  "remove",
+			Usage: "Remove a role from a user",
+			Flags: userFlags,
+			Action: func(ctx context.Context, c *cli.Command) error {
+				rbacService, user, err := findUser(ctx, c.String("email"))
+				if err != nil {
+					return err
+				}
+				if err := rbacService.RemoveRole(ctx, user.ID, c.String("role")); err != nil {
+					return roleError(err, c.String("role"))
+				}
+				fmt.Printf("Removed role %s from %s\n", c.String("role"), user.Email)
+				return nil
+			},
+		},
+	},
+}
+
+func newServices() (domain.RBACService, domain.UserRepository, error) {
+	cfg := config.Load()
+	database, err := db.NewDatabase(cfg.Database)
+	if err != nil {
+		return nil, nil, err
+	}
+	return rbac.NewService(cfg.Auth, rolerepo.NewRepository(database)), userrepo.NewRepository(database), nil
+}
+
+func findUser(ctx context.Context, email string) (domain.RBACService, *domain.User, error) {
+	rbacService, userRepo, err := newServices()
+	if err != nil {
+		return nil, nil, err
+	}
+	user, err := userRepo.FindByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, domain.ErrResourceNotFound) {
+			return nil, nil, fmt.Errorf("no user with email %s", email)
+		}
+		return nil, nil, err
+	}
+	return rbacService, user, nil
+}
+
+func roleError(err error, name string) error {
+	if errors.Is(err, domain.ErrResourceNotFound) {
+		return fmt.Errorf("role %s does not exist or is not assigned", name)
+	}
+	return err
+}
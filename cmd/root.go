@@ -4,7 +4,9 @@ import (
 	"context"
 	"log"
 
+	"github.com/akfaiz/go-vue-starter-kit/cmd/key"
 	"github.com/akfaiz/go-vue-starter-kit/cmd/migrate"
+	"github.com/akfaiz/go-vue-starter-kit/cmd/role"
 	"github.com/akfaiz/go-vue-starter-kit/cmd/serve"
 	"github.com/urfave/cli/v3"
 )
@@ -15,6 +17,8 @@ var cmd = &cli.Command{
 	Commands: []*cli.Command{
 		serve.Command,
 		migrate.Command,
+		key.Command,
+		role.Command,
 	},
 }
 
@@ -53,7 +53,7 @@ func appOptions(cfg config.Config) []fx.Option {
 			slogLogger.UseLogLevel(slog.LevelDebug)
 			return slogLogger
 		}),
-		fx.Supply(cfg, cfg.Auth, cfg.Auth.JWT, cfg.Database),
+		fx.Supply(cfg, cfg.App, cfg.Auth, cfg.Auth.JWT, cfg.Auth.PasswordHash, cfg.Database),
 		fx.Provide(
 			db.NewDatabase,
 		),
@@ -0,0 +1,28 @@
+package migrations
+
+import (
+	"github.com/akfaiz/migris"
+	"github.com/akfaiz/migris/schema"
+)
+
+func init() {
+	migris.AddMigrationContext(upCreateSessionsTable, downCreateSessionsTable)
+}
+
+func upCreateSessionsTable(c *schema.Context) error {
+	return schema.Create(c, "sessions", func(table *schema.Blueprint) {
+		table.UUID("id").Primary()
+		table.BigInteger("user_id").Index()
+		table.String("token_hash", 64).Unique()
+		table.Timestamp("expires_at")
+		table.Timestamp("last_used_at").UseCurrent()
+		table.Timestamp("revoked_at").Nullable()
+		table.Timestamp("created_at").UseCurrent()
+
+		table.Foreign("user_id").References("id").On("users").CascadeOnDelete()
+	})
+}
+
+func downCreateSessionsTable(c *schema.Context) error {
+	return schema.DropIfExists(c, "sessions")
+}
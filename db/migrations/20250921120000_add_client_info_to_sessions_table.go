@@ -0,0 +1,23 @@
+package migrations
+
+import (
+	"github.com/akfaiz/migris"
+	"github.com/akfaiz/migris/schema"
+)
+
+func init() {
+	migris.AddMigrationContext(upAddClientInfoToSessionsTable, downAddClientInfoToSessionsTable)
+}
+
+func upAddClientInfoToSessionsTable(c *schema.Context) error {
+	return schema.Table(c, "sessions", func(table *schema.Blueprint) {
+		table.String("user_agent", 512).Nullable()
+		table.String("ip_address", 45).Nullable()
+	})
+}
+
+func downAddClientInfoToSessionsTable(c *schema.Context) error {
+	return schema.Table(c, "sessions", func(table *schema.Blueprint) {
+		table.DropColumn("user_agent", "ip_address")
+	})
+}
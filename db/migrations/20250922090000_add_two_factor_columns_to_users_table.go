@@ -0,0 +1,24 @@
+package migrations
+
+import (
+	"github.com/akfaiz/migris"
+	"github.com/akfaiz/migris/schema"
+)
+
+func init() {
+	migris.AddMigrationContext(upAddTwoFactorColumnsToUsersTable, downAddTwoFactorColumnsToUsersTable)
+}
+
+func upAddTwoFactorColumnsToUsersTable(c *schema.Context) error {
+	return schema.Table(c, "users", func(table *schema.Blueprint) {
+		table.Text("two_factor_secret").Nullable()
+		table.JSONB("two_factor_recovery_codes").Nullable()
+		table.Timestamp("two_factor_confirmed_at").Nullable()
+	})
+}
+
+func downAddTwoFactorColumnsToUsersTable(c *schema.Context) error {
+	return schema.Table(c, "users", func(table *schema.Blueprint) {
+		table.DropColumn("two_factor_secret", "two_factor_recovery_codes", "two_factor_confirmed_at")
+	})
+}
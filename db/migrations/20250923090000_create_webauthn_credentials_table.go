@@ -0,0 +1,34 @@
+package migrations
+
+import (
+	"github.com/akfaiz/migris"
+	"github.com/akfaiz/migris/schema"
+)
+
+func init() {
+	migris.AddMigrationContext(upCreateWebauthnCredentialsTable, downCreateWebauthnCredentialsTable)
+}
+
+func upCreateWebauthnCredentialsTable(c *schema.Context) error {
+	return schema.Create(c, "webauthn_credentials", func(table *schema.Blueprint) {
+		table.ID()
+		table.BigInteger("user_id").Index()
+		table.String("name")
+		table.Binary("credential_id").Unique()
+		table.Binary("public_key")
+		table.String("attestation_type", 32)
+		table.JSONB("transports").Nullable()
+		table.Binary("aaguid").Nullable()
+		table.BigInteger("sign_count").Default(0)
+		table.Boolean("backup_eligible").Default(false)
+		table.Boolean("backup_state").Default(false)
+		table.Timestamp("last_used_at").Nullable()
+		table.Timestamp("created_at").UseCurrent()
+
+		table.Foreign("user_id").References("id").On("users").CascadeOnDelete()
+	})
+}
+
+func downCreateWebauthnCredentialsTable(c *schema.Context) error {
+	return schema.DropIfExists(c, "webauthn_credentials")
+}
@@ -0,0 +1,26 @@
+package migrations
+
+import (
+	"github.com/akfaiz/migris"
+	"github.com/akfaiz/migris/schema"
+)
+
+func init() {
+	migris.AddMigrationContext(upCreateWebauthnSessionsTable, downCreateWebauthnSessionsTable)
+}
+
+func upCreateWebauthnSessionsTable(c *schema.Context) error {
+	return schema.Create(c, "webauthn_sessions", func(table *schema.Blueprint) {
+		table.UUID("id").Primary()
+		table.BigInteger("user_id").Nullable().Index()
+		table.JSONB("data")
+		table.Timestamp("expires_at").Index()
+		table.Timestamp("created_at").UseCurrent()
+
+		table.Foreign("user_id").References("id").On("users").CascadeOnDelete()
+	})
+}
+
+func downCreateWebauthnSessionsTable(c *schema.Context) error {
+	return schema.DropIfExists(c, "webauthn_sessions")
+}
@@ -0,0 +1,29 @@
+package migrations
+
+import (
+	"github.com/akfaiz/migris"
+	"github.com/akfaiz/migris/schema"
+)
+
+func init() {
+	migris.AddMigrationContext(upCreateUserIdentitiesTable, downCreateUserIdentitiesTable)
+}
+
+func upCreateUserIdentitiesTable(c *schema.Context) error {
+	return schema.Create(c, "user_identities", func(table *schema.Blueprint) {
+		table.ID()
+		table.BigInteger("user_id").Index()
+		table.String("provider", 50)
+		table.String("subject")
+		table.String("email").Nullable()
+		table.Timestamp("created_at").UseCurrent()
+
+		table.Foreign("user_id").References("id").On("users").CascadeOnDelete()
+		table.Unique("provider", "subject")
+		table.Unique("user_id", "provider")
+	})
+}
+
+func downCreateUserIdentitiesTable(c *schema.Context) error {
+	return schema.DropIfExists(c, "user_identities")
+}
@@ -0,0 +1,30 @@
+package migrations
+
+import (
+	"github.com/akfaiz/migris"
+	"github.com/akfaiz/migris/schema"
+)
+
+func init() {
+	migris.AddMigrationContext(upAddMagicLinkToUserTokensTable, downAddMagicLinkToUserTokensTable)
+}
+
+// The token_type enum is a CHECK constraint, which Postgres can only replace.
+func upAddMagicLinkToUserTokensTable(c *schema.Context) error {
+	_, err := c.Exec(`ALTER TABLE user_tokens
+		DROP CONSTRAINT IF EXISTS user_tokens_token_type_check,
+		ADD CONSTRAINT user_tokens_token_type_check
+			CHECK (token_type IN ('verification', 'reset_password', 'magic_link'))`)
+	return err
+}
+
+func downAddMagicLinkToUserTokensTable(c *schema.Context) error {
+	if _, err := c.Exec(`DELETE FROM user_tokens WHERE token_type = 'magic_link'`); err != nil {
+		return err
+	}
+	_, err := c.Exec(`ALTER TABLE user_tokens
+		DROP CONSTRAINT IF EXISTS user_tokens_token_type_check,
+		ADD CONSTRAINT user_tokens_token_type_check
+			CHECK (token_type IN ('verification', 'reset_password'))`)
+	return err
+}
@@ -0,0 +1,23 @@
+package migrations
+
+import (
+	"github.com/akfaiz/migris"
+	"github.com/akfaiz/migris/schema"
+)
+
+func init() {
+	migris.AddMigrationContext(upCreateThrottlesTable, downCreateThrottlesTable)
+}
+
+func upCreateThrottlesTable(c *schema.Context) error {
+	return schema.Create(c, "throttles", func(table *schema.Blueprint) {
+		table.String("key").Primary()
+		table.Integer("hits").Default(0)
+		table.Timestamp("blocked_until").Nullable()
+		table.Timestamp("expires_at").Index()
+	})
+}
+
+func downCreateThrottlesTable(c *schema.Context) error {
+	return schema.DropIfExists(c, "throttles")
+}
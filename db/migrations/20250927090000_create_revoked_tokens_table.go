@@ -0,0 +1,22 @@
+package migrations
+
+import (
+	"github.com/akfaiz/migris"
+	"github.com/akfaiz/migris/schema"
+)
+
+func init() {
+	migris.AddMigrationContext(upCreateRevokedTokensTable, downCreateRevokedTokensTable)
+}
+
+func upCreateRevokedTokensTable(c *schema.Context) error {
+	return schema.Create(c, "revoked_tokens", func(table *schema.Blueprint) {
+		table.String("key").Primary()
+		table.Timestamp("expires_at").Index()
+		table.Timestamp("created_at").UseCurrent()
+	})
+}
+
+func downCreateRevokedTokensTable(c *schema.Context) error {
+	return schema.DropIfExists(c, "revoked_tokens")
+}
@@ -0,0 +1,30 @@
+package migrations
+
+import (
+	"github.com/akfaiz/migris"
+	"github.com/akfaiz/migris/schema"
+)
+
+func init() {
+	migris.AddMigrationContext(upCreatePersonalAccessTokensTable, downCreatePersonalAccessTokensTable)
+}
+
+func upCreatePersonalAccessTokensTable(c *schema.Context) error {
+	return schema.Create(c, "personal_access_tokens", func(table *schema.Blueprint) {
+		table.ID()
+		table.BigInteger("user_id").Index()
+		table.String("name")
+		table.String("prefix", 32).Unique()
+		table.String("token_hash", 64)
+		table.JSONB("scopes")
+		table.Timestamp("expires_at").Nullable()
+		table.Timestamp("last_used_at").Nullable()
+		table.Timestamp("created_at").UseCurrent()
+
+		table.Foreign("user_id").References("id").On("users").CascadeOnDelete()
+	})
+}
+
+func downCreatePersonalAccessTokensTable(c *schema.Context) error {
+	return schema.DropIfExists(c, "personal_access_tokens")
+}
@@ -0,0 +1,91 @@
+package migrations
+
+import (
+	"github.com/akfaiz/migris"
+	"github.com/akfaiz/migris/schema"
+)
+
+func init() {
+	migris.AddMigrationContext(upCreateRolesTables, downCreateRolesTables)
+}
+
+func upCreateRolesTables(c *schema.Context) error {
+	err := schema.Create(c, "roles", func(table *schema.Blueprint) {
+		table.ID()
+		table.String("name", 64).Unique()
+		table.String("description").Default("")
+		table.Timestamp("created_at").UseCurrent()
+	})
+	if err != nil {
+		return err
+	}
+	err = schema.Create(c, "permissions", func(table *schema.Blueprint) {
+		table.ID()
+		table.String("name", 64).Unique()
+		table.String("description").Default("")
+		table.Timestamp("created_at").UseCurrent()
+	})
+	if err != nil {
+		return err
+	}
+	err = schema.Create(c, "role_permissions", func(table *schema.Blueprint) {
+		table.BigInteger("role_id")
+		table.BigInteger("permission_id").Index()
+		table.Primary("role_id", "permission_id")
+
+		table.Foreign("role_id").References("id").On("roles").CascadeOnDelete()
+		table.Foreign("permission_id").References("id").On("permissions").CascadeOnDelete()
+	})
+	if err != nil {
+		return err
+	}
+	err = schema.Create(c, "user_roles", func(table *schema.Blueprint) {
+		table.BigInteger("user_id")
+		table.BigInteger("role_id").Index()
+		table.Timestamp("created_at").UseCurrent()
+		table.Primary("user_id", "role_id")
+
+		table.Foreign("user_id").References("id").On("users").CascadeOnDelete()
+		table.Foreign("role_id").References("id").On("roles").CascadeOnDelete()
+	})
+	if err != nil {
+		return err
+	}
+
+	// The admin role is granted every permission; migrations adding permissions
+	// later seed them with seedPermissions too.
+	if _, err := c.Exec(`INSERT INTO roles (name, description) VALUES ('admin', 'Full access to administration')`); err != nil {
+		return err
+	}
+	return seedPermissions(c, [][2]string{
+		{"users.manage", "View and manage user accounts"},
+		{"roles.manage", "View roles and assign them to users"},
+	})
+}
+
+func downCreateRolesTables(c *schema.Context) error {
+	for _, table := range []string{"user_roles", "role_permissions", "permissions", "roles"} {
+		if err := schema.DropIfExists(c, table); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// seedPermissions creates permissions and grants them to the admin role.
+func seedPermissions(c *schema.Context, permissions [][2]string) error {
+	for _, p := range permissions {
+		name, description := p[0], p[1]
+		if _, err := c.Exec(`INSERT INTO permissions (name, description) VALUES ($1, $2) ON CONFLICT (name) DO NOTHING`, name, description); err != nil {
+			return err
+		}
+		_, err := c.Exec(`INSERT INTO role_permissions (role_id, permission_id)
+			SELECT roles.id, permissions.id FROM roles, permissions
+			WHERE roles.name = 'admin' AND permissions.name = $1
+			ON CONFLICT DO NOTHING`, name)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,22 @@
+package migrations
+
+import (
+	"github.com/akfaiz/migris"
+	"github.com/akfaiz/migris/schema"
+)
+
+func init() {
+	migris.AddMigrationContext(upAddDisabledAtToUsersTable, downAddDisabledAtToUsersTable)
+}
+
+func upAddDisabledAtToUsersTable(c *schema.Context) error {
+	return schema.Table(c, "users", func(table *schema.Blueprint) {
+		table.Timestamp("disabled_at").Nullable()
+	})
+}
+
+func downAddDisabledAtToUsersTable(c *schema.Context) error {
+	return schema.Table(c, "users", func(table *schema.Blueprint) {
+		table.DropColumn("disabled_at")
+	})
+}
@@ -0,0 +1,44 @@
+package migrations
+
+import (
+	"github.com/akfaiz/migris"
+	"github.com/akfaiz/migris/schema"
+)
+
+func init() {
+	migris.AddMigrationContext(upAddStatusToUsersTable, downAddStatusToUsersTable)
+}
+
+// upAddStatusToUsersTable replaces disabled_at with an account status, carrying
+// disabled accounts over as suspended.
+func upAddStatusToUsersTable(c *schema.Context) error {
+	err := schema.Table(c, "users", func(table *schema.Blueprint) {
+		table.String("status", 16).Default("active").Index()
+		table.Timestamp("suspended_at").Nullable()
+		table.Text("suspension_reason").Nullable()
+	})
+	if err != nil {
+		return err
+	}
+	if _, err := c.Exec(`UPDATE users SET status = 'suspended', suspended_at = disabled_at WHERE disabled_at IS NOT NULL`); err != nil {
+		return err
+	}
+	return schema.Table(c, "users", func(table *schema.Blueprint) {
+		table.DropColumn("disabled_at")
+	})
+}
+
+func downAddStatusToUsersTable(c *schema.Context) error {
+	err := schema.Table(c, "users", func(table *schema.Blueprint) {
+		table.Timestamp("disabled_at").Nullable()
+	})
+	if err != nil {
+		return err
+	}
+	if _, err := c.Exec(`UPDATE users SET disabled_at = COALESCE(suspended_at, NOW()) WHERE status = 'suspended'`); err != nil {
+		return err
+	}
+	return schema.Table(c, "users", func(table *schema.Blueprint) {
+		table.DropColumn("status", "suspended_at", "suspension_reason")
+	})
+}
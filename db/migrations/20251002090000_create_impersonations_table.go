@@ -0,0 +1,39 @@
+package migrations
+
+import (
+	"github.com/akfaiz/migris"
+	"github.com/akfaiz/migris/schema"
+)
+
+func init() {
+	migris.AddMigrationContext(upCreateImpersonationsTable, downCreateImpersonationsTable)
+}
+
+// upCreateImpersonationsTable has no foreign keys so the audit trail outlives
+// the users involved.
+func upCreateImpersonationsTable(c *schema.Context) error {
+	err := schema.Create(c, "impersonations", func(table *schema.Blueprint) {
+		table.ID()
+		table.BigInteger("impersonator_id").Index()
+		table.BigInteger("user_id").Index()
+		table.String("token_id").Unique()
+		table.String("user_agent", 512).Nullable()
+		table.String("ip_address", 45).Nullable()
+		table.Timestamp("expires_at")
+		table.Timestamp("ended_at").Nullable()
+		table.Timestamp("created_at").UseCurrent()
+	})
+	if err != nil {
+		return err
+	}
+	return seedPermissions(c, [][2]string{
+		{"users.impersonate", "Sign in as another user to see what they see"},
+	})
+}
+
+func downCreateImpersonationsTable(c *schema.Context) error {
+	if _, err := c.Exec(`DELETE FROM permissions WHERE name = 'users.impersonate'`); err != nil {
+		return err
+	}
+	return schema.DropIfExists(c, "impersonations")
+}
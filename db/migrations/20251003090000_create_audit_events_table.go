@@ -0,0 +1,39 @@
+package migrations
+
+import (
+	"github.com/akfaiz/migris"
+	"github.com/akfaiz/migris/schema"
+)
+
+func init() {
+	migris.AddMigrationContext(upCreateAuditEventsTable, downCreateAuditEventsTable)
+}
+
+// upCreateAuditEventsTable has no foreign keys so the events outlive the users
+// involved.
+func upCreateAuditEventsTable(c *schema.Context) error {
+	err := schema.Create(c, "audit_events", func(table *schema.Blueprint) {
+		table.ID()
+		table.String("type", 64).Index()
+		table.BigInteger("actor_id").Nullable().Index()
+		table.BigInteger("user_id").Nullable().Index()
+		table.String("ip_address", 45).Nullable()
+		table.String("user_agent", 512).Nullable()
+		table.String("request_id", 64).Nullable()
+		table.JSONB("metadata").Nullable()
+		table.Timestamp("created_at").UseCurrent().Index()
+	})
+	if err != nil {
+		return err
+	}
+	return seedPermissions(c, [][2]string{
+		{"audit.read", "View the security audit log"},
+	})
+}
+
+func downCreateAuditEventsTable(c *schema.Context) error {
+	if _, err := c.Exec(`DELETE FROM permissions WHERE name = 'audit.read'`); err != nil {
+		return err
+	}
+	return schema.DropIfExists(c, "audit_events")
+}
@@ -0,0 +1,43 @@
+package migrations
+
+import (
+	"github.com/akfaiz/migris"
+	"github.com/akfaiz/migris/schema"
+)
+
+func init() {
+	migris.AddMigrationContext(upAddEmailChangeToUserTokensTable, downAddEmailChangeToUserTokensTable)
+}
+
+// upAddEmailChangeToUserTokensTable adds the email change token types, and the
+// address each one carries: the requested address to confirm, or the previous
+// address to revert to.
+func upAddEmailChangeToUserTokensTable(c *schema.Context) error {
+	err := schema.Table(c, "user_tokens", func(table *schema.Blueprint) {
+		table.String("email").Nullable()
+	})
+	if err != nil {
+		return err
+	}
+	_, err = c.Exec(`ALTER TABLE user_tokens
+		DROP CONSTRAINT IF EXISTS user_tokens_token_type_check,
+		ADD CONSTRAINT user_tokens_token_type_check
+			CHECK (token_type IN ('verification', 'reset_password', 'magic_link', 'email_change', 'email_revert'))`)
+	return err
+}
+
+func downAddEmailChangeToUserTokensTable(c *schema.Context) error {
+	if _, err := c.Exec(`DELETE FROM user_tokens WHERE token_type IN ('email_change', 'email_revert')`); err != nil {
+		return err
+	}
+	_, err := c.Exec(`ALTER TABLE user_tokens
+		DROP CONSTRAINT IF EXISTS user_tokens_token_type_check,
+		ADD CONSTRAINT user_tokens_token_type_check
+			CHECK (token_type IN ('verification', 'reset_password', 'magic_link'))`)
+	if err != nil {
+		return err
+	}
+	return schema.Table(c, "user_tokens", func(table *schema.Blueprint) {
+		table.DropColumn("email")
+	})
+}
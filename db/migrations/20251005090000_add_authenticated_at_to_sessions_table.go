@@ -0,0 +1,29 @@
+package migrations
+
+import (
+	"github.com/akfaiz/migris"
+	"github.com/akfaiz/migris/schema"
+)
+
+func init() {
+	migris.AddMigrationContext(upAddAuthenticatedAtToSessionsTable, downAddAuthenticatedAtToSessionsTable)
+}
+
+// upAddAuthenticatedAtToSessionsTable records when the user last proved their
+// identity in each session; existing sessions count from when they were created.
+func upAddAuthenticatedAtToSessionsTable(c *schema.Context) error {
+	err := schema.Table(c, "sessions", func(table *schema.Blueprint) {
+		table.Timestamp("authenticated_at").UseCurrent()
+	})
+	if err != nil {
+		return err
+	}
+	_, err = c.Exec(`UPDATE sessions SET authenticated_at = created_at`)
+	return err
+}
+
+func downAddAuthenticatedAtToSessionsTable(c *schema.Context) error {
+	return schema.Table(c, "sessions", func(table *schema.Blueprint) {
+		table.DropColumn("authenticated_at")
+	})
+}
@@ -0,0 +1,27 @@
+package migrations
+
+import (
+	"github.com/akfaiz/migris"
+	"github.com/akfaiz/migris/schema"
+)
+
+func init() {
+	migris.AddMigrationContext(upCreatePasswordHistoryTable, downCreatePasswordHistoryTable)
+}
+
+// upCreatePasswordHistoryTable keeps the hashes of the passwords users had
+// before, so they cannot cycle back to them.
+func upCreatePasswordHistoryTable(c *schema.Context) error {
+	return schema.Create(c, "password_history", func(table *schema.Blueprint) {
+		table.ID()
+		table.BigInteger("user_id").Index()
+		table.String("password_hash")
+		table.Timestamp("created_at").UseCurrent()
+
+		table.Foreign("user_id").References("id").On("users").CascadeOnDelete()
+	})
+}
+
+func downCreatePasswordHistoryTable(c *schema.Context) error {
+	return schema.DropIfExists(c, "password_history")
+}
@@ -0,0 +1,25 @@
+package migrations
+
+import (
+	"github.com/akfaiz/migris"
+	"github.com/akfaiz/migris/schema"
+)
+
+func init() {
+	migris.AddMigrationContext(upAddPasswordChangedAtToUsersTable, downAddPasswordChangedAtToUsersTable)
+}
+
+// upAddPasswordChangedAtToUsersTable dates every password for the maximum
+// password age. Existing passwords count from the migration, so turning the
+// limit on does not lock everyone out at once.
+func upAddPasswordChangedAtToUsersTable(c *schema.Context) error {
+	return schema.Table(c, "users", func(table *schema.Blueprint) {
+		table.Timestamp("password_changed_at").UseCurrent()
+	})
+}
+
+func downAddPasswordChangedAtToUsersTable(c *schema.Context) error {
+	return schema.Table(c, "users", func(table *schema.Blueprint) {
+		table.DropColumn("password_changed_at")
+	})
+}
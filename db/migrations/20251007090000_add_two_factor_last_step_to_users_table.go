@@ -0,0 +1,24 @@
+package migrations
+
+import (
+	"github.com/akfaiz/migris"
+	"github.com/akfaiz/migris/schema"
+)
+
+func init() {
+	migris.AddMigrationContext(upAddTwoFactorLastStepToUsersTable, downAddTwoFactorLastStepToUsersTable)
+}
+
+// upAddTwoFactorLastStepToUsersTable remembers the TOTP time step of the last
+// accepted code, so a code cannot be used twice while it is still valid.
+func upAddTwoFactorLastStepToUsersTable(c *schema.Context) error {
+	return schema.Table(c, "users", func(table *schema.Blueprint) {
+		table.BigInteger("two_factor_last_step").Nullable()
+	})
+}
+
+func downAddTwoFactorLastStepToUsersTable(c *schema.Context) error {
+	return schema.Table(c, "users", func(table *schema.Blueprint) {
+		table.DropColumn("two_factor_last_step")
+	})
+}
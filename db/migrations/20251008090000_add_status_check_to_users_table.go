@@ -0,0 +1,25 @@
+package migrations
+
+import (
+	"github.com/akfaiz/migris"
+	"github.com/akfaiz/migris/schema"
+)
+
+func init() {
+	migris.AddMigrationContext(upAddStatusCheckToUsersTable, downAddStatusCheckToUsersTable)
+}
+
+// upAddStatusCheckToUsersTable limits the status to the values in
+// domain.UserStatus, the same way token_type is limited on user_tokens.
+func upAddStatusCheckToUsersTable(c *schema.Context) error {
+	_, err := c.Exec(`ALTER TABLE users
+		DROP CONSTRAINT IF EXISTS users_status_check,
+		ADD CONSTRAINT users_status_check
+			CHECK (status IN ('active', 'suspended', 'pending'))`)
+	return err
+}
+
+func downAddStatusCheckToUsersTable(c *schema.Context) error {
+	_, err := c.Exec(`ALTER TABLE users DROP CONSTRAINT IF EXISTS users_status_check`)
+	return err
+}
@@ -1,33 +1,193 @@
 package config
 
 import (
+	"strings"
 	"time"
 
 	"github.com/akfaiz/go-vue-starter-kit/pkg/env"
 )
 
 type Auth struct {
-	ResetPasswordExpiration time.Duration
-	VerificationExpiration  time.Duration
-	JWT                     JWT
+	ResetPasswordExpiration      time.Duration
+	VerificationExpiration       time.Duration
+	TwoFactorChallengeExpiration time.Duration
+	MagicLinkEnabled             bool
+	MagicLinkExpiration          time.Duration
+	EmailChangeExpiration        time.Duration
+	EmailRevertExpiration        time.Duration
+	JWT                          JWT
+	TokenDenylist                TokenDenylist
+	PermissionCacheTTL           time.Duration
+	SuspensionCacheTTL           time.Duration
+	ImpersonationExpiration      time.Duration
+	PasswordConfirmationTimeout  time.Duration
+	Stealth                      bool // hide which emails have an account
+	Mode                         string
+	Cookie                       Cookie
+	PasswordPolicy               PasswordPolicy
+	PasswordHash                 PasswordHash
+	WebAuthn                     WebAuthn
 }
 
+// Auth modes. In AuthModeBearer the tokens are returned in the response body
+// and sent back in the Authorization header. In AuthModeCookie browsers get them
+// in HttpOnly cookies instead, and state-changing requests authenticated by the
+// cookies must echo the CSRF cookie in a header. The Authorization header is
+// accepted in both modes, so API clients are unaffected.
+const (
+	AuthModeBearer = "bearer"
+	AuthModeCookie = "cookie"
+)
+
+// CookieMode reports whether browsers authenticate with cookies.
+func (a Auth) CookieMode() bool {
+	return a.Mode == AuthModeCookie
+}
+
+// Cookie configures the cookies of AuthModeCookie. They are Secure when
+// API_BASE_URL uses https and scoped to its path.
+type Cookie struct {
+	Domain   string
+	SameSite string // "lax" or "strict"
+}
+
+// JWT configures token signing. With HS256 access tokens use AccessSecret. With
+// RS256, ES256 or EdDSA they are signed by the newest key in KeysDir once it
+// is 30 seconds old, by when every instance has reloaded the directory, or by
+// PrivateKey when set, and can be verified by anyone through the JWKS endpoint.
+// Refresh tokens are only read by this service and always use RefreshSecret.
+// Issuer and Audience are embedded in and required of every token, so tokens
+// minted by another deployment are rejected; Leeway absorbs clock skew.
 type JWT struct {
+	Issuer         string
+	Audience       string
+	Leeway         time.Duration
+	Algorithm      string
+	KeysDir        string
+	PrivateKey     string // PEM encoded
 	AccessSecret   string
 	RefreshSecret  string
 	AccessExpires  time.Duration
 	RefreshExpires time.Duration
 }
 
+// TokenDenylist sizes the in-memory cache in front of the revoked token store.
+// Lookups that found no revocation are cached for CacheTTL, so a token revoked
+// through another instance can keep working here for up to CacheTTL.
+type TokenDenylist struct {
+	CacheSize int
+	CacheTTL  time.Duration
+}
+
+// PasswordPolicy is enforced on every new password. BreachCheck is "off",
+// "api" to look passwords up in a range API compatible with Have I Been Pwned at
+// BreachAPIURL, or "local" to use the bundled list of common passwords instead,
+// for development and tests. HistorySize counts the current password and the
+// ones before it that cannot be reused; MaxAge forces a change at the next
+// password login once exceeded. Zero turns either off.
+type PasswordPolicy struct {
+	MinLength        int
+	MaxLength        int
+	RequireUppercase bool
+	RequireLowercase bool
+	RequireDigit     bool
+	RequireSymbol    bool
+	BreachCheck      string
+	BreachAPIURL     string
+	BreachTimeout    time.Duration
+	HistorySize      int
+	MaxAge           time.Duration
+}
+
+// PasswordHash sets the argon2id parameters of new password hashes. Memory is
+// in KiB. Hashes made with other parameters, or with bcrypt, scrypt or PBKDF2
+// by a system the users were imported from, still verify and are upgraded at
+// the next successful password login.
+type PasswordHash struct {
+	Memory      uint32
+	Iterations  uint32
+	Parallelism uint8
+	SaltLength  uint32
+	KeyLength   uint32
+}
+
+// WebAuthn configures the passkey relying party. When RPID or RPOrigins are empty
+// they are derived from App.FrontendBaseURL.
+type WebAuthn struct {
+	RPID               string
+	RPOrigins          []string
+	CeremonyExpiration time.Duration
+}
+
 func getAuthConfig() Auth {
 	return Auth{
-		ResetPasswordExpiration: 60 * time.Minute,
-		VerificationExpiration:  60 * time.Minute,
+		ResetPasswordExpiration:      60 * time.Minute,
+		VerificationExpiration:       60 * time.Minute,
+		TwoFactorChallengeExpiration: 5 * time.Minute,
+		MagicLinkEnabled:             env.GetBool("AUTH_MAGIC_LINK_ENABLED", false),
+		MagicLinkExpiration:          15 * time.Minute,
+		EmailChangeExpiration:        60 * time.Minute,
+		EmailRevertExpiration:        7 * 24 * time.Hour,
 		JWT: JWT{
-			AccessSecret:   env.MustGetString("JWT_ACCESS_SECRET"),
+			Issuer:         env.GetString("JWT_ISSUER", env.GetString("API_BASE_URL", "http://localhost:8080/api")),
+			Audience:       env.GetString("JWT_AUDIENCE", env.GetString("API_BASE_URL", "http://localhost:8080/api")),
+			Leeway:         env.GetDuration("JWT_LEEWAY", 30*time.Second),
+			Algorithm:      env.GetString("JWT_ALGORITHM", "HS256"),
+			KeysDir:        env.GetString("JWT_KEYS_DIR", "storage/jwt"),
+			PrivateKey:     env.GetString("JWT_PRIVATE_KEY"),
+			AccessSecret:   env.GetString("JWT_ACCESS_SECRET"),
 			RefreshSecret:  env.MustGetString("JWT_REFRESH_SECRET"),
 			AccessExpires:  env.MustGetDuration("JWT_ACCESS_EXPIRES_IN"),
 			RefreshExpires: env.MustGetDuration("JWT_REFRESH_EXPIRES_IN"),
 		},
+		TokenDenylist: TokenDenylist{
+			CacheSize: env.GetInt("TOKEN_DENYLIST_CACHE_SIZE", 10000),
+			CacheTTL:  env.GetDuration("TOKEN_DENYLIST_CACHE_TTL", 5*time.Second),
+		},
+		PermissionCacheTTL:          env.GetDuration("AUTH_PERMISSION_CACHE_TTL", 30*time.Second),
+		SuspensionCacheTTL:          env.GetDuration("AUTH_SUSPENSION_CACHE_TTL", 5*time.Second),
+		ImpersonationExpiration:     env.GetDuration("AUTH_IMPERSONATION_EXPIRES_IN", 15*time.Minute),
+		PasswordConfirmationTimeout: env.GetDuration("AUTH_PASSWORD_CONFIRMATION_TIMEOUT", 15*time.Minute),
+		Stealth:                     env.GetBool("AUTH_STEALTH", false),
+		Mode:                        strings.ToLower(env.GetString("AUTH_MODE", AuthModeBearer)),
+		Cookie: Cookie{
+			Domain:   env.GetString("AUTH_COOKIE_DOMAIN"),
+			SameSite: strings.ToLower(env.GetString("AUTH_COOKIE_SAMESITE", "lax")),
+		},
+		PasswordPolicy: PasswordPolicy{
+			MinLength:        env.GetInt("PASSWORD_MIN_LENGTH", 8),
+			MaxLength:        env.GetInt("PASSWORD_MAX_LENGTH", 128),
+			RequireUppercase: env.GetBool("PASSWORD_REQUIRE_UPPERCASE", false),
+			RequireLowercase: env.GetBool("PASSWORD_REQUIRE_LOWERCASE", false),
+			RequireDigit:     env.GetBool("PASSWORD_REQUIRE_DIGIT", false),
+			RequireSymbol:    env.GetBool("PASSWORD_REQUIRE_SYMBOL", false),
+			BreachCheck:      strings.ToLower(env.GetString("PASSWORD_BREACH_CHECK", "off")),
+			BreachAPIURL:     env.GetString("PASSWORD_BREACH_API_URL", "https://api.pwnedpasswords.com/range/"),
+			BreachTimeout:    env.GetDuration("PASSWORD_BREACH_TIMEOUT", 3*time.Second),
+			HistorySize:      env.GetInt("PASSWORD_HISTORY", 5),
+			MaxAge:           env.GetDuration("PASSWORD_MAX_AGE", 0),
+		},
+		PasswordHash: PasswordHash{
+			Memory:      uint32(env.GetInt("PASSWORD_HASH_MEMORY", 64*1024)),
+			Iterations:  uint32(env.GetInt("PASSWORD_HASH_ITERATIONS", 3)),
+			Parallelism: uint8(env.GetInt("PASSWORD_HASH_PARALLELISM", 1)),
+			SaltLength:  16,
+			KeyLength:   32,
+		},
+		WebAuthn: WebAuthn{
+			RPID:               env.GetString("WEBAUTHN_RP_ID"),
+			RPOrigins:          splitList(env.GetString("WEBAUTHN_RP_ORIGINS")),
+			CeremonyExpiration: 5 * time.Minute,
+		},
+	}
+}
+
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
 	}
+	return items
 }
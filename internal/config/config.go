@@ -11,7 +11,9 @@ type Config struct {
 	Auth     Auth
 	Database Database
 	Mail     Mail
+	OAuth    OAuth
 	Server   Server
+	Throttle Throttle
 }
 
 func Load() Config {
@@ -24,6 +26,8 @@ func Load() Config {
 		Auth:     getAuthConfig(),
 		Database: loadDatabaseConfig(),
 		Mail:     loadMailConfig(),
+		OAuth:    loadOAuthConfig(),
 		Server:   loadServerConfig(),
+		Throttle: loadThrottleConfig(),
 	}
 }
@@ -0,0 +1,48 @@
+package config
+
+import (
+	"strings"
+	"time"
+
+	"github.com/akfaiz/go-vue-starter-kit/pkg/env"
+)
+
+type OAuth struct {
+	Providers       []OAuthProvider
+	StateExpiration time.Duration
+}
+
+// OAuthProvider configures a social login provider. Providers with an Issuer use
+// OpenID Connect discovery; plain OAuth2 providers (e.g. GitHub) set the
+// AuthURL, TokenURL and UserInfoURL endpoints instead.
+type OAuthProvider struct {
+	Name         string
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	Scopes       []string
+	AuthURL      string
+	TokenURL     string
+	UserInfoURL  string
+}
+
+func loadOAuthConfig() OAuth {
+	var providers []OAuthProvider
+	for _, name := range splitList(env.GetString("OAUTH_PROVIDERS")) {
+		prefix := "OAUTH_" + strings.ToUpper(name) + "_"
+		providers = append(providers, OAuthProvider{
+			Name:         strings.ToLower(name),
+			Issuer:       env.GetString(prefix + "ISSUER"),
+			ClientID:     env.MustGetString(prefix + "CLIENT_ID"),
+			ClientSecret: env.MustGetString(prefix + "CLIENT_SECRET"),
+			Scopes:       splitList(env.GetString(prefix + "SCOPES")),
+			AuthURL:      env.GetString(prefix + "AUTH_URL"),
+			TokenURL:     env.GetString(prefix + "TOKEN_URL"),
+			UserInfoURL:  env.GetString(prefix + "USERINFO_URL"),
+		})
+	}
+	return OAuth{
+		Providers:       providers,
+		StateExpiration: 10 * time.Minute,
+	}
+}
@@ -1,13 +1,48 @@
 package config
 
-import "github.com/akfaiz/go-vue-starter-kit/pkg/env"
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/akfaiz/go-vue-starter-kit/pkg/env"
+)
 
 type Server struct {
 	Port int
+	// TrustedProxies are the ranges whose X-Forwarded-For header is believed.
+	// Without any, the client IP is the address of the connection.
+	TrustedProxies []*net.IPNet
 }
 
 func loadServerConfig() Server {
 	return Server{
-		Port: env.GetInt("SERVER_PORT", 8080),
+		Port:           env.GetInt("SERVER_PORT", 8080),
+		TrustedProxies: mustParseIPRanges(env.GetString("TRUSTED_PROXIES")),
+	}
+}
+
+// mustParseIPRanges parses a comma separated list of CIDRs and single IPs.
+func mustParseIPRanges(value string) []*net.IPNet {
+	var ranges []*net.IPNet
+	for _, item := range splitList(value) {
+		if !strings.Contains(item, "/") {
+			ip := net.ParseIP(item)
+			if ip == nil {
+				panic(fmt.Sprintf("invalid trusted proxy %q", item))
+			}
+			bits := 8 * net.IPv6len
+			if ip.To4() != nil {
+				ip, bits = ip.To4(), 8*net.IPv4len
+			}
+			ranges = append(ranges, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+		_, ipNet, err := net.ParseCIDR(item)
+		if err != nil {
+			panic(fmt.Sprintf("invalid trusted proxy %q", item))
+		}
+		ranges = append(ranges, ipNet)
 	}
+	return ranges
 }
@@ -0,0 +1,75 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/akfaiz/go-vue-starter-kit/pkg/env"
+)
+
+type Throttle struct {
+	Store     string // "postgres" shares counters across instances, "memory" is per process
+	Login     LoginThrottle
+	RateLimit RateLimit
+}
+
+// LoginThrottle configures brute-force protection for password logins.
+type LoginThrottle struct {
+	FreeAttempts     int           // failures per email and IP before backoff starts
+	BaseDelay        time.Duration // first backoff, doubled on every further failure
+	MaxDelay         time.Duration
+	LockoutThreshold int // failures per account before it is locked
+	LockoutDuration  time.Duration
+	Window           time.Duration // how long failures are remembered
+}
+
+// RateLimit holds the request rate limiting policies attached to API routes.
+type RateLimit struct {
+	Enabled bool
+	Auth    RateLimitPolicy // every /auth endpoint, per IP
+	Strict  RateLimitPolicy // endpoints that send email or create accounts, per route and IP
+	Profile RateLimitPolicy // /profile, per user
+}
+
+// RateLimitPolicy allows Limit requests per sliding Window.
+type RateLimitPolicy struct {
+	Name   string
+	Limit  int
+	Window time.Duration
+}
+
+func loadThrottleConfig() Throttle {
+	return Throttle{
+		Store: env.GetString("THROTTLE_STORE", "postgres"),
+		Login: LoginThrottle{
+			FreeAttempts:     env.GetInt("LOGIN_FREE_ATTEMPTS", 3),
+			BaseDelay:        env.GetDuration("LOGIN_BACKOFF_BASE", time.Second),
+			MaxDelay:         env.GetDuration("LOGIN_BACKOFF_MAX", 15*time.Minute),
+			LockoutThreshold: env.GetInt("LOGIN_LOCKOUT_THRESHOLD", 10),
+			LockoutDuration:  env.GetDuration("LOGIN_LOCKOUT_DURATION", 15*time.Minute),
+			Window:           time.Hour,
+		},
+		RateLimit: RateLimit{
+			Enabled: env.GetBool("RATE_LIMIT_ENABLED", true),
+			Auth:    mustParsePolicy("auth", env.GetString("RATE_LIMIT_AUTH", "30/1m")),
+			Strict:  mustParsePolicy("strict", env.GetString("RATE_LIMIT_STRICT", "5/15m")),
+			Profile: mustParsePolicy("profile", env.GetString("RATE_LIMIT_PROFILE", "120/1m")),
+		},
+	}
+}
+
+// mustParsePolicy parses a policy written as "<limit>/<window>", e.g. "5/15m".
+func mustParsePolicy(name, value string) RateLimitPolicy {
+	limit, window, ok := strings.Cut(value, "/")
+	n, err := strconv.Atoi(strings.TrimSpace(limit))
+	if !ok || err != nil || n <= 0 {
+		panic(fmt.Sprintf("invalid rate limit policy %q for %s", value, name))
+	}
+	d, err := time.ParseDuration(strings.TrimSpace(window))
+	if err != nil || d <= 0 {
+		panic(fmt.Sprintf("invalid rate limit policy %q for %s", value, name))
+	}
+	return RateLimitPolicy{Name: name, Limit: n, Window: d}
+}
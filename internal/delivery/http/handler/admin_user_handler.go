@@ -0,0 +1,162 @@
+package handler
+
+import (
+	"github.com/akfaiz/go-vue-starter-kit/internal/delivery/http/handler/dto"
+	"github.com/akfaiz/go-vue-starter-kit/internal/delivery/http/middleware/auth"
+	"github.com/akfaiz/go-vue-starter-kit/internal/domain"
+	"github.com/akfaiz/go-vue-starter-kit/internal/errdefs"
+	"github.com/cockroachdb/errors"
+	"github.com/labstack/echo/v4"
+)
+
+type AdminUserHandler struct {
+	adminUserService domain.AdminUserService
+}
+
+func NewAdminUserHandler(adminUserService domain.AdminUserService) *AdminUserHandler {
+	return &AdminUserHandler{
+		adminUserService: adminUserService,
+	}
+}
+
+func (h *AdminUserHandler) ListUsers(c echo.Context) error {
+	req := dto.ListUsersRequest{PaginationRequest: dto.NewPaginationRequest()}
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+	ctx := c.Request().Context()
+	users, total, err := h.adminUserService.List(ctx, req.ToFilter())
+	if err != nil {
+		return err
+	}
+
+	page := dto.NewPaginated(dto.NewAdminUserResponses(users), req.PaginationRequest, total)
+	res := dto.NewResponse(200, dto.AdminUserListResponse(page))
+	return c.JSON(res.Status, res)
+}
+
+func (h *AdminUserHandler) GetUser(c echo.Context) error {
+	var req dto.AdminUserRequest
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+	ctx := c.Request().Context()
+	user, err := h.adminUserService.FindByID(ctx, req.ID)
+	if err != nil {
+		return userError(err)
+	}
+
+	res := dto.NewResponse(200, dto.NewAdminUserResponse(user))
+	return c.JSON(res.Status, res)
+}
+
+func (h *AdminUserHandler) UpdateUser(c echo.Context) error {
+	var req dto.AdminUpdateUserRequest
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+	ctx := c.Request().Context()
+	user, err := h.adminUserService.Update(ctx, req.ID, &domain.AdminUserUpdate{
+		Name:     req.Name,
+		Email:    req.Email,
+		Verified: req.Verified,
+	})
+	if err != nil {
+		return userError(err)
+	}
+
+	res := dto.NewResponse(200, dto.NewAdminUserResponse(user), "User updated successfully")
+	return c.JSON(res.Status, res)
+}
+
+func (h *AdminUserHandler) SendPasswordReset(c echo.Context) error {
+	var req dto.AdminUserRequest
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+	ctx := c.Request().Context()
+	if err := h.adminUserService.SendPasswordReset(ctx, req.ID); err != nil {
+		return userError(err)
+	}
+
+	res := dto.NewMessage(200, "Password reset email sent successfully")
+	return c.JSON(res.Status, res)
+}
+
+func (h *AdminUserHandler) SuspendUser(c echo.Context) error {
+	var req dto.AdminSuspendUserRequest
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+	claims := auth.GetUser(c)
+	if claims == nil {
+		return errdefs.ErrUnauthorized()
+	}
+	ctx := c.Request().Context()
+	if err := h.adminUserService.Suspend(ctx, claims.ID, req.ID, req.Reason); err != nil {
+		return userError(err)
+	}
+
+	res := dto.NewMessage(200, "User suspended successfully")
+	return c.JSON(res.Status, res)
+}
+
+func (h *AdminUserHandler) UnsuspendUser(c echo.Context) error {
+	var req dto.AdminUserRequest
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+	ctx := c.Request().Context()
+	if err := h.adminUserService.Unsuspend(ctx, req.ID); err != nil {
+		return userError(err)
+	}
+
+	res := dto.NewMessage(200, "User unsuspended successfully")
+	return c.JSON(res.Status, res)
+}
+
+func (h *AdminUserHandler) DeleteUser(c echo.Context) error {
+	var req dto.AdminUserRequest
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+	claims := auth.GetUser(c)
+	if claims == nil {
+		return errdefs.ErrUnauthorized()
+	}
+	ctx := c.Request().Context()
+	if err := h.adminUserService.Delete(ctx, claims.ID, req.ID); err != nil {
+		return userError(err)
+	}
+
+	res := dto.NewMessage(200, "User deleted successfully")
+	return c.JSON(res.Status, res)
+}
+
+func userError(err error) error {
+	if errors.Is(err, domain.ErrResourceNotFound) {
+		return errdefs.ErrNotFound("User not found")
+	}
+	return err
+}
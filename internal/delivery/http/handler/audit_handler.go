@@ -0,0 +1,66 @@
+package handler
+
+import (
+	"github.com/akfaiz/go-vue-starter-kit/internal/delivery/http/handler/dto"
+	"github.com/akfaiz/go-vue-starter-kit/internal/delivery/http/middleware/auth"
+	"github.com/akfaiz/go-vue-starter-kit/internal/domain"
+	"github.com/akfaiz/go-vue-starter-kit/internal/errdefs"
+	"github.com/labstack/echo/v4"
+)
+
+type AuditHandler struct {
+	auditService domain.AuditService
+}
+
+func NewAuditHandler(auditService domain.AuditService) *AuditHandler {
+	return &AuditHandler{
+		auditService: auditService,
+	}
+}
+
+// ListActivity lists the events concerning the authenticated user's account.
+func (h *AuditHandler) ListActivity(c echo.Context) error {
+	req := dto.ListActivityRequest{PaginationRequest: dto.NewPaginationRequest()}
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+	claims := auth.GetUser(c)
+	if claims == nil {
+		return errdefs.ErrUnauthorized()
+	}
+	ctx := c.Request().Context()
+	events, total, err := h.auditService.List(ctx, domain.AuditFilter{
+		UserID: claims.ID,
+		Limit:  req.PerPage,
+		Offset: req.Offset(),
+	})
+	if err != nil {
+		return err
+	}
+
+	page := dto.NewPaginated(dto.NewAuditEventResponses(events), req.PaginationRequest, total)
+	res := dto.NewResponse(200, dto.AuditEventListResponse(page))
+	return c.JSON(res.Status, res)
+}
+
+func (h *AuditHandler) ListEvents(c echo.Context) error {
+	req := dto.ListAuditEventsRequest{PaginationRequest: dto.NewPaginationRequest()}
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+	ctx := c.Request().Context()
+	events, total, err := h.auditService.List(ctx, req.ToFilter())
+	if err != nil {
+		return err
+	}
+
+	page := dto.NewPaginated(dto.NewAuditEventResponses(events), req.PaginationRequest, total)
+	res := dto.NewResponse(200, dto.AuditEventListResponse(page))
+	return c.JSON(res.Status, res)
+}
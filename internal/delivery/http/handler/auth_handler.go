@@ -11,11 +11,13 @@ import (
 
 type AuthHandler struct {
 	authService domain.AuthService
+	cookies     *auth.Cookies
 }
 
-func NewAuthHandler(authService domain.AuthService) *AuthHandler {
+func NewAuthHandler(authService domain.AuthService, cookies *auth.Cookies) *AuthHandler {
 	return &AuthHandler{
 		authService: authService,
+		cookies:     cookies,
 	}
 }
 
@@ -29,10 +31,39 @@ func (h *AuthHandler) Login(c echo.Context) error {
 	}
 
 	ctx := c.Request().Context()
-	pairToken, err := h.authService.Login(ctx, req.Email, req.Password)
+	result, err := h.authService.Login(ctx, req.Email, req.Password)
 	if err != nil {
 		return err
 	}
+	if result.TwoFactorRequired() {
+		res := dto.NewResponse(200, dto.NewLoginResponse(result), "Two-factor authentication required")
+		return c.JSON(res.Status, res)
+	}
+	if result.Token, err = h.cookies.Issue(c, result.Token); err != nil {
+		return err
+	}
+
+	res := dto.NewResponse(200, dto.NewLoginResponse(result), "Login successful")
+	return c.JSON(res.Status, res)
+}
+
+func (h *AuthHandler) VerifyTwoFactor(c echo.Context) error {
+	var req dto.VerifyTwoFactorRequest
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	ctx := c.Request().Context()
+	pairToken, err := h.authService.VerifyTwoFactor(ctx, req.ChallengeToken, req.Code, req.RecoveryCode)
+	if err != nil {
+		return err
+	}
+	if pairToken, err = h.cookies.Issue(c, pairToken); err != nil {
+		return err
+	}
 
 	res := dto.NewResponse(200, dto.NewTokenResponse(pairToken), "Login successful")
 	return c.JSON(res.Status, res)
@@ -53,6 +84,14 @@ func (h *AuthHandler) Register(c echo.Context) error {
 	if err != nil {
 		return err
 	}
+	if token == nil {
+		// stealth mode: the same answer whether or not the email was taken
+		res := dto.NewMessage(202, i18n.T(ctx, "auth.registered"))
+		return c.JSON(res.Status, res)
+	}
+	if token, err = h.cookies.Issue(c, token); err != nil {
+		return err
+	}
 
 	res := dto.NewResponse(201, dto.NewTokenResponse(token), "User registered successfully")
 	return c.JSON(res.Status, res)
@@ -63,6 +102,9 @@ func (h *AuthHandler) RefreshToken(c echo.Context) error {
 	if err := c.Bind(&req); err != nil {
 		return err
 	}
+	if req.RefreshToken == "" {
+		req.RefreshToken = h.cookies.RefreshToken(c)
+	}
 	if err := c.Validate(&req); err != nil {
 		return err
 	}
@@ -72,11 +114,68 @@ func (h *AuthHandler) RefreshToken(c echo.Context) error {
 	if err != nil {
 		return err
 	}
+	if pairToken, err = h.cookies.Issue(c, pairToken); err != nil {
+		return err
+	}
 
 	res := dto.NewResponse(200, dto.NewTokenResponse(pairToken))
 	return c.JSON(res.Status, res)
 }
 
+func (h *AuthHandler) Logout(c echo.Context) error {
+	user := auth.GetUser(c)
+	if user == nil {
+		return errdefs.ErrUnauthorized()
+	}
+
+	ctx := c.Request().Context()
+	if err := h.authService.Logout(ctx, user.ID, user.SessionID); err != nil {
+		return err
+	}
+	h.cookies.Clear(c)
+
+	res := dto.NewMessage(200, "Logged out successfully")
+	return c.JSON(res.Status, res)
+}
+
+func (h *AuthHandler) LogoutAll(c echo.Context) error {
+	user := auth.GetUser(c)
+	if user == nil {
+		return errdefs.ErrUnauthorized()
+	}
+
+	ctx := c.Request().Context()
+	if err := h.authService.LogoutAll(ctx, user.ID); err != nil {
+		return err
+	}
+	h.cookies.Clear(c)
+
+	res := dto.NewMessage(200, "Logged out from all devices successfully")
+	return c.JSON(res.Status, res)
+}
+
+func (h *AuthHandler) ConfirmPassword(c echo.Context) error {
+	var req dto.ConfirmPasswordRequest
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+	user := auth.GetUser(c)
+	if user == nil {
+		return errdefs.ErrUnauthorized()
+	}
+
+	ctx := c.Request().Context()
+	if err := h.authService.ConfirmPassword(ctx, user.ID, user.SessionID, req.Password); err != nil {
+		return err
+	}
+
+	res := dto.NewMessage(200, "Password confirmed")
+	return c.JSON(res.Status, res)
+}
+
 func (h *AuthHandler) SendForgotPasswordEmail(c echo.Context) error {
 	var req dto.SendForgotPasswordEmailRequest
 	if err := c.Bind(&req); err != nil {
@@ -95,6 +194,50 @@ func (h *AuthHandler) SendForgotPasswordEmail(c echo.Context) error {
 	return c.JSON(res.Status, res)
 }
 
+func (h *AuthHandler) SendMagicLink(c echo.Context) error {
+	var req dto.SendMagicLinkRequest
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	ctx := c.Request().Context()
+	if err := h.authService.SendMagicLink(ctx, req.Email); err != nil {
+		return err
+	}
+
+	res := dto.NewMessage(200, i18n.T(ctx, "magic_link.sent"))
+	return c.JSON(res.Status, res)
+}
+
+func (h *AuthHandler) VerifyMagicLink(c echo.Context) error {
+	var req dto.VerifyMagicLinkRequest
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	ctx := c.Request().Context()
+	result, err := h.authService.VerifyMagicLink(ctx, req.Token, req.Email)
+	if err != nil {
+		return err
+	}
+	if result.TwoFactorRequired() {
+		res := dto.NewResponse(200, dto.NewLoginResponse(result), "Two-factor authentication required")
+		return c.JSON(res.Status, res)
+	}
+	if result.Token, err = h.cookies.Issue(c, result.Token); err != nil {
+		return err
+	}
+
+	res := dto.NewResponse(200, dto.NewLoginResponse(result), "Login successful")
+	return c.JSON(res.Status, res)
+}
+
 func (h *AuthHandler) ValidateResetPassword(c echo.Context) error {
 	var req dto.ValidateResetPasswordRequest
 	if err := c.Bind(&req); err != nil {
@@ -131,6 +274,32 @@ func (h *AuthHandler) ResetPassword(c echo.Context) error {
 	return c.JSON(res.Status, res)
 }
 
+func (h *AuthHandler) ChangeExpiredPassword(c echo.Context) error {
+	var req dto.ChangeExpiredPasswordRequest
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	ctx := c.Request().Context()
+	result, err := h.authService.ChangeExpiredPassword(ctx, req.Email, req.CurrentPassword, req.Password)
+	if err != nil {
+		return err
+	}
+	if result.TwoFactorRequired() {
+		res := dto.NewResponse(200, dto.NewLoginResponse(result), "Two-factor authentication required")
+		return c.JSON(res.Status, res)
+	}
+	if result.Token, err = h.cookies.Issue(c, result.Token); err != nil {
+		return err
+	}
+
+	res := dto.NewResponse(200, dto.NewLoginResponse(result), "Password changed successfully")
+	return c.JSON(res.Status, res)
+}
+
 func (h *AuthHandler) SendVerificationEmail(c echo.Context) error {
 	user := auth.GetUser(c)
 	if user == nil {
@@ -0,0 +1,69 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/akfaiz/go-vue-starter-kit/internal/domain"
+)
+
+type AuditEventResponse struct {
+	ID        int64          `json:"id"`
+	Type      string         `json:"type"`
+	ActorID   *int64         `json:"actor_id"`
+	UserID    *int64         `json:"user_id"`
+	IPAddress string         `json:"ip_address"`
+	UserAgent string         `json:"user_agent"`
+	RequestID string         `json:"request_id"`
+	Metadata  map[string]any `json:"metadata"`
+	CreatedAt time.Time      `json:"created_at"`
+}
+
+// AuditEventListResponse names the page type so the spec gets a readable schema.
+type AuditEventListResponse Paginated[AuditEventResponse]
+
+type ListActivityRequest struct {
+	PaginationRequest
+}
+
+type ListAuditEventsRequest struct {
+	PaginationRequest
+	UserID  int64      `query:"user_id" label:"User ID"`
+	ActorID int64      `query:"actor_id" label:"Actor ID"`
+	Type    string     `query:"type" label:"Type"`
+	From    *time.Time `query:"from" label:"From"`
+	To      *time.Time `query:"to" label:"To"`
+}
+
+func (r *ListAuditEventsRequest) ToFilter() domain.AuditFilter {
+	return domain.AuditFilter{
+		UserID:  r.UserID,
+		ActorID: r.ActorID,
+		Type:    domain.AuditEventType(r.Type),
+		From:    r.From,
+		To:      r.To,
+		Limit:   r.PerPage,
+		Offset:  r.Offset(),
+	}
+}
+
+func NewAuditEventResponse(event *domain.AuditEvent) *AuditEventResponse {
+	return &AuditEventResponse{
+		ID:        event.ID,
+		Type:      string(event.Type),
+		ActorID:   event.ActorID,
+		UserID:    event.UserID,
+		IPAddress: event.IPAddress,
+		UserAgent: event.UserAgent,
+		RequestID: event.RequestID,
+		Metadata:  event.Metadata,
+		CreatedAt: event.CreatedAt,
+	}
+}
+
+func NewAuditEventResponses(events []*domain.AuditEvent) []AuditEventResponse {
+	res := make([]AuditEventResponse, len(events))
+	for i, event := range events {
+		res[i] = *NewAuditEventResponse(event)
+	}
+	return res
+}
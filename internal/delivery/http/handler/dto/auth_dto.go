@@ -5,7 +5,7 @@ import "github.com/akfaiz/go-vue-starter-kit/internal/domain"
 type RegisterRequest struct {
 	Name                 string `json:"name" validate:"required" label:"Name"`
 	Email                string `json:"email" validate:"required|email" label:"Email"`
-	Password             string `json:"password" validate:"required|min:6" label:"Password"`
+	Password             string `json:"password" validate:"required" label:"Password"`
 	PasswordConfirmation string `json:"password_confirmation" validate:"required|eq_field:Password" label:"Confirm Password"`
 }
 
@@ -38,18 +38,39 @@ type SendForgotPasswordEmailRequest struct {
 type ResetPasswordRequest struct {
 	Token                string `json:"token" validate:"required" label:"Token"`
 	Email                string `json:"email" validate:"required|email" label:"Email"`
-	Password             string `json:"password" validate:"required|min:8" label:"Password"`
+	Password             string `json:"password" validate:"required" label:"Password"`
 	PasswordConfirmation string `json:"password_confirmation" validate:"required|eq_field:Password" label:"Confirm Password"`
 }
 
+type ChangeExpiredPasswordRequest struct {
+	Email                string `json:"email" validate:"required|email" label:"Email"`
+	CurrentPassword      string `json:"current_password" validate:"required" label:"Current Password"`
+	Password             string `json:"password" validate:"required" label:"Password"`
+	PasswordConfirmation string `json:"password_confirmation" validate:"required|eq_field:Password" label:"Confirm Password"`
+}
+
+type ConfirmPasswordRequest struct {
+	Password string `json:"password" validate:"required" label:"Password"`
+}
+
 type VerifyEmailRequest struct {
 	Token  string `json:"token" validate:"required" label:"Token"`
 	UserID int64  `json:"user_id" validate:"required" label:"User ID"`
 }
 
+type SendMagicLinkRequest struct {
+	Email string `json:"email" validate:"required|email" label:"Email"`
+}
+
+type VerifyMagicLinkRequest struct {
+	Token string `json:"token" validate:"required" label:"Token"`
+	Email string `json:"email" validate:"required|email" label:"Email"`
+}
+
+// TokenResponse is empty in cookie auth mode, where the tokens are set as cookies.
 type TokenResponse struct {
-	AccessToken  string `json:"access_token"`
-	RefreshToken string `json:"refresh_token"`
+	AccessToken  string `json:"access_token,omitempty"`
+	RefreshToken string `json:"refresh_token,omitempty"`
 }
 
 func NewTokenResponse(token *domain.PairToken) *TokenResponse {
@@ -58,3 +79,23 @@ func NewTokenResponse(token *domain.PairToken) *TokenResponse {
 		RefreshToken: token.RefreshToken,
 	}
 }
+
+type LoginResponse struct {
+	AccessToken       string `json:"access_token,omitempty"`
+	RefreshToken      string `json:"refresh_token,omitempty"`
+	TwoFactorRequired bool   `json:"two_factor_required"`
+	ChallengeToken    string `json:"challenge_token,omitempty"`
+}
+
+func NewLoginResponse(result *domain.LoginResult) *LoginResponse {
+	if result.TwoFactorRequired() {
+		return &LoginResponse{
+			TwoFactorRequired: true,
+			ChallengeToken:    result.ChallengeToken,
+		}
+	}
+	return &LoginResponse{
+		AccessToken:  result.Token.AccessToken,
+		RefreshToken: result.Token.RefreshToken,
+	}
+}
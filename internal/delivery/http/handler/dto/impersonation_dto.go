@@ -0,0 +1,42 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/akfaiz/go-vue-starter-kit/internal/domain"
+)
+
+type ImpersonateUserRequest struct {
+	ID int64 `param:"id" path:"id" validate:"required" label:"User ID"`
+}
+
+// ImpersonationResponse holds an access token for the impersonated user. There
+// is no refresh token; impersonation ends when the token expires.
+type ImpersonationResponse struct {
+	AccessToken string    `json:"access_token"`
+	ExpiresAt   time.Time `json:"expires_at"`
+}
+
+type ImpersonatorResponse struct {
+	ID    int64  `json:"id"`
+	Name  string `json:"name"`
+	Email string `json:"email"`
+}
+
+func NewImpersonationResponse(token *domain.ImpersonationToken) *ImpersonationResponse {
+	return &ImpersonationResponse{
+		AccessToken: token.AccessToken,
+		ExpiresAt:   token.ExpiresAt,
+	}
+}
+
+func NewImpersonatorResponse(actor *domain.JWTActor) *ImpersonatorResponse {
+	if actor == nil {
+		return nil
+	}
+	return &ImpersonatorResponse{
+		ID:    actor.ID,
+		Name:  actor.Name,
+		Email: actor.Email,
+	}
+}
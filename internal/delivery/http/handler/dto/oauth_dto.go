@@ -0,0 +1,45 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/akfaiz/go-vue-starter-kit/internal/domain"
+)
+
+type OAuthProviderRequest struct {
+	Provider string `param:"provider" path:"provider" validate:"required" label:"Provider"`
+}
+
+type OAuthCallbackRequest struct {
+	Provider         string `param:"provider" path:"provider" validate:"required" label:"Provider"`
+	Code             string `query:"code"`
+	State            string `query:"state"`
+	Error            string `query:"error"`
+	ErrorDescription string `query:"error_description"`
+}
+
+type OAuthProvidersResponse struct {
+	Providers []string `json:"providers"`
+}
+
+type OAuthRedirectResponse struct {
+	RedirectURL string `json:"redirect_url"`
+}
+
+type UserIdentityResponse struct {
+	Provider  string    `json:"provider"`
+	Email     string    `json:"email"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func NewUserIdentityResponses(identities []*domain.UserIdentity) []UserIdentityResponse {
+	res := make([]UserIdentityResponse, len(identities))
+	for i, identity := range identities {
+		res[i] = UserIdentityResponse{
+			Provider:  identity.Provider,
+			Email:     identity.Email,
+			CreatedAt: identity.CreatedAt,
+		}
+	}
+	return res
+}
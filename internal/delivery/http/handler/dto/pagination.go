@@ -0,0 +1,48 @@
+package dto
+
+const (
+	DefaultPerPage = 20
+	MaxPerPage     = 100
+)
+
+type PaginationRequest struct {
+	Page    int `query:"page" validate:"min:1" label:"Page"`
+	PerPage int `query:"per_page" validate:"min:1|max:100" label:"Per Page"`
+}
+
+// NewPaginationRequest returns the defaults used when the query omits them.
+func NewPaginationRequest() PaginationRequest {
+	return PaginationRequest{Page: 1, PerPage: DefaultPerPage}
+}
+
+func (p PaginationRequest) Offset() int {
+	return (p.Page - 1) * p.PerPage
+}
+
+type PageMeta struct {
+	Page     int `json:"page"`
+	PerPage  int `json:"per_page"`
+	Total    int `json:"total"`
+	LastPage int `json:"last_page"`
+}
+
+type Paginated[T any] struct {
+	Items []T      `json:"items"`
+	Meta  PageMeta `json:"meta"`
+}
+
+func NewPaginated[T any](items []T, page PaginationRequest, total int) Paginated[T] {
+	lastPage := (total + page.PerPage - 1) / page.PerPage
+	if lastPage < 1 {
+		lastPage = 1
+	}
+	return Paginated[T]{
+		Items: items,
+		Meta: PageMeta{
+			Page:     page.Page,
+			PerPage:  page.PerPage,
+			Total:    total,
+			LastPage: lastPage,
+		},
+	}
+}
@@ -0,0 +1,67 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/akfaiz/go-vue-starter-kit/internal/domain"
+)
+
+type PasskeyResponse struct {
+	ID         int64      `json:"id"`
+	Name       string     `json:"name"`
+	Transports []string   `json:"transports"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+func NewPasskeyResponse(passkey *domain.Passkey) *PasskeyResponse {
+	transports := passkey.Transports
+	if transports == nil {
+		transports = []string{}
+	}
+	return &PasskeyResponse{
+		ID:         passkey.ID,
+		Name:       passkey.Name,
+		Transports: transports,
+		LastUsedAt: passkey.LastUsedAt,
+		CreatedAt:  passkey.CreatedAt,
+	}
+}
+
+func NewPasskeyResponses(passkeys []*domain.Passkey) []PasskeyResponse {
+	res := make([]PasskeyResponse, len(passkeys))
+	for i, passkey := range passkeys {
+		res[i] = *NewPasskeyResponse(passkey)
+	}
+	return res
+}
+
+// PasskeyCeremonyResponse carries the options for navigator.credentials.create()
+// or navigator.credentials.get() and the ceremony ID to send back when finishing.
+type PasskeyCeremonyResponse struct {
+	CeremonyID string          `json:"ceremony_id"`
+	Options    json.RawMessage `json:"options"`
+}
+
+func NewPasskeyCeremonyResponse(ceremony *domain.PasskeyCeremony) *PasskeyCeremonyResponse {
+	return &PasskeyCeremonyResponse{
+		CeremonyID: ceremony.ID,
+		Options:    ceremony.Options,
+	}
+}
+
+type FinishPasskeyRegistrationRequest struct {
+	CeremonyID string          `json:"ceremony_id" validate:"required" label:"Ceremony ID"`
+	Name       string          `json:"name" validate:"max_len:255" label:"Name"`
+	Credential json.RawMessage `json:"credential" validate:"required" label:"Credential"`
+}
+
+type FinishPasskeyLoginRequest struct {
+	CeremonyID string          `json:"ceremony_id" validate:"required" label:"Ceremony ID"`
+	Credential json.RawMessage `json:"credential" validate:"required" label:"Credential"`
+}
+
+type DeletePasskeyRequest struct {
+	ID int64 `param:"id" path:"id" validate:"required" label:"Passkey ID"`
+}
@@ -0,0 +1,54 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/akfaiz/go-vue-starter-kit/internal/domain"
+)
+
+type PersonalAccessTokenResponse struct {
+	ID         int64      `json:"id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	Scopes     []string   `json:"scopes"`
+	ExpiresAt  *time.Time `json:"expires_at"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// CreatedPersonalAccessTokenResponse includes the plain text token, which is
+// only ever returned once.
+type CreatedPersonalAccessTokenResponse struct {
+	PersonalAccessTokenResponse
+	Token string `json:"token"`
+}
+
+type CreatePersonalAccessTokenRequest struct {
+	Name      string     `json:"name" validate:"required|max_len:255" label:"Name"`
+	Scopes    []string   `json:"scopes" validate:"required" label:"Scopes"`
+	ExpiresAt *time.Time `json:"expires_at" label:"Expires At"`
+}
+
+type DeletePersonalAccessTokenRequest struct {
+	ID int64 `param:"id" path:"id" validate:"required" label:"Token ID"`
+}
+
+func NewPersonalAccessTokenResponse(token *domain.PersonalAccessToken) *PersonalAccessTokenResponse {
+	return &PersonalAccessTokenResponse{
+		ID:         token.ID,
+		Name:       token.Name,
+		Prefix:     token.Prefix,
+		Scopes:     token.Scopes,
+		ExpiresAt:  token.ExpiresAt,
+		LastUsedAt: token.LastUsedAt,
+		CreatedAt:  token.CreatedAt,
+	}
+}
+
+func NewPersonalAccessTokenResponses(tokens []*domain.PersonalAccessToken) []PersonalAccessTokenResponse {
+	res := make([]PersonalAccessTokenResponse, len(tokens))
+	for i, token := range tokens {
+		res[i] = *NewPersonalAccessTokenResponse(token)
+	}
+	return res
+}
@@ -7,22 +7,35 @@ import (
 )
 
 type ProfileResponse struct {
-	ID              int64      `json:"id"`
-	Name            string     `json:"name"`
-	Email           string     `json:"email"`
-	EmailVerifiedAt *time.Time `json:"email_verified_at"`
-	CreatedAt       time.Time  `json:"created_at"`
-	UpdatedAt       time.Time  `json:"updated_at"`
+	ID               int64                 `json:"id"`
+	Name             string                `json:"name"`
+	Email            string                `json:"email"`
+	EmailVerifiedAt  *time.Time            `json:"email_verified_at"`
+	PendingEmail     *string               `json:"pending_email"`
+	TwoFactorEnabled bool                  `json:"two_factor_enabled"`
+	Roles            []string              `json:"roles"`
+	Permissions      []string              `json:"permissions"`
+	Impersonator     *ImpersonatorResponse `json:"impersonator"`
+	CreatedAt        time.Time             `json:"created_at"`
+	UpdatedAt        time.Time             `json:"updated_at"`
 }
 
 type UpdateProfileRequest struct {
-	Name  string `json:"name" validate:"required" label:"Name"`
+	Name string `json:"name" validate:"required" label:"Name"`
+}
+
+type ChangeEmailRequest struct {
 	Email string `json:"email" validate:"required|email" label:"Email"`
 }
 
+type EmailChangeRequest struct {
+	Token  string `json:"token" validate:"required" label:"Token"`
+	UserID int64  `json:"user_id" validate:"required" label:"User ID"`
+}
+
 type ChangePasswordRequest struct {
 	CurrentPassword         string `json:"current_password" validate:"required" label:"Current Password"`
-	NewPassword             string `json:"new_password" validate:"required|min:8" label:"New Password"`
+	NewPassword             string `json:"new_password" validate:"required" label:"New Password"`
 	NewPasswordConfirmation string `json:"new_password_confirmation" validate:"required|eq_field:NewPassword" label:"Confirm New Password"`
 }
 
@@ -30,13 +43,16 @@ type DeleteAccountRequest struct {
 	Password string `json:"password" validate:"required" label:"Password"`
 }
 
-func NewProfileResponse(user *domain.User) *ProfileResponse {
+func NewProfileResponse(user *domain.User, authorization *domain.Authorization) *ProfileResponse {
 	return &ProfileResponse{
-		ID:              user.ID,
-		Name:            user.Name,
-		Email:           user.Email,
-		EmailVerifiedAt: user.EmailVerifiedAt,
-		CreatedAt:       user.CreatedAt,
-		UpdatedAt:       user.UpdatedAt,
+		ID:               user.ID,
+		Name:             user.Name,
+		Email:            user.Email,
+		EmailVerifiedAt:  user.EmailVerifiedAt,
+		TwoFactorEnabled: user.HasTwoFactorEnabled(),
+		Roles:            authorization.Roles,
+		Permissions:      authorization.Permissions,
+		CreatedAt:        user.CreatedAt,
+		UpdatedAt:        user.UpdatedAt,
 	}
 }
@@ -0,0 +1,23 @@
+package dto
+
+import "github.com/akfaiz/go-vue-starter-kit/internal/domain"
+
+type RoleResponse struct {
+	ID          int64    `json:"id"`
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Permissions []string `json:"permissions"`
+}
+
+func NewRoleResponses(roles []*domain.Role) []RoleResponse {
+	res := make([]RoleResponse, len(roles))
+	for i, role := range roles {
+		res[i] = RoleResponse{
+			ID:          role.ID,
+			Name:        role.Name,
+			Description: role.Description,
+			Permissions: role.Permissions,
+		}
+	}
+	return res
+}
@@ -0,0 +1,35 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/akfaiz/go-vue-starter-kit/internal/domain"
+)
+
+type SessionResponse struct {
+	ID         string    `json:"id"`
+	UserAgent  string    `json:"user_agent"`
+	IPAddress  string    `json:"ip_address"`
+	Current    bool      `json:"current"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastUsedAt time.Time `json:"last_used_at"`
+}
+
+type RevokeSessionRequest struct {
+	ID string `param:"id" path:"id" validate:"required" label:"Session ID"`
+}
+
+func NewSessionResponses(sessions []*domain.Session, currentSessionID string) []SessionResponse {
+	res := make([]SessionResponse, len(sessions))
+	for i, s := range sessions {
+		res[i] = SessionResponse{
+			ID:         s.ID,
+			UserAgent:  s.UserAgent,
+			IPAddress:  s.IPAddress,
+			Current:    s.ID == currentSessionID,
+			CreatedAt:  s.CreatedAt,
+			LastUsedAt: s.LastUsedAt,
+		}
+	}
+	return res
+}
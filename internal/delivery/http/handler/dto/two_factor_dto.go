@@ -0,0 +1,43 @@
+package dto
+
+import "github.com/akfaiz/go-vue-starter-kit/internal/domain"
+
+type ConfirmTwoFactorRequest struct {
+	Code string `json:"code" validate:"required" label:"Code"`
+}
+
+type DisableTwoFactorRequest struct {
+	Password string `json:"password" validate:"required" label:"Password"`
+}
+
+type RegenerateRecoveryCodesRequest struct {
+	Password string `json:"password" validate:"required" label:"Password"`
+}
+
+type VerifyTwoFactorRequest struct {
+	ChallengeToken string `json:"challenge_token" validate:"required" label:"Challenge Token"`
+	Code           string `json:"code" validate:"required_without:RecoveryCode" label:"Code"`
+	RecoveryCode   string `json:"recovery_code" label:"Recovery Code"`
+}
+
+type TwoFactorSetupResponse struct {
+	Secret string `json:"secret"`
+	URI    string `json:"otpauth_uri"`
+}
+
+func NewTwoFactorSetupResponse(setup *domain.TwoFactorSetup) *TwoFactorSetupResponse {
+	return &TwoFactorSetupResponse{
+		Secret: setup.Secret,
+		URI:    setup.URI,
+	}
+}
+
+type RecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+func NewRecoveryCodesResponse(codes []string) *RecoveryCodesResponse {
+	return &RecoveryCodesResponse{
+		RecoveryCodes: codes,
+	}
+}
@@ -0,0 +1,55 @@
+package handler
+
+import (
+	"github.com/akfaiz/go-vue-starter-kit/internal/delivery/http/handler/dto"
+	"github.com/akfaiz/go-vue-starter-kit/internal/delivery/http/middleware/auth"
+	"github.com/akfaiz/go-vue-starter-kit/internal/domain"
+	"github.com/akfaiz/go-vue-starter-kit/internal/errdefs"
+	"github.com/labstack/echo/v4"
+)
+
+type ImpersonationHandler struct {
+	impersonationService domain.ImpersonationService
+}
+
+func NewImpersonationHandler(impersonationService domain.ImpersonationService) *ImpersonationHandler {
+	return &ImpersonationHandler{
+		impersonationService: impersonationService,
+	}
+}
+
+func (h *ImpersonationHandler) Start(c echo.Context) error {
+	var req dto.ImpersonateUserRequest
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+	claims := auth.GetUser(c)
+	if claims == nil {
+		return errdefs.ErrUnauthorized()
+	}
+	ctx := c.Request().Context()
+	token, err := h.impersonationService.Start(ctx, claims.ID, req.ID)
+	if err != nil {
+		return userError(err)
+	}
+
+	res := dto.NewResponse(201, dto.NewImpersonationResponse(token), "Impersonation started")
+	return c.JSON(res.Status, res)
+}
+
+func (h *ImpersonationHandler) Stop(c echo.Context) error {
+	claims := auth.GetUser(c)
+	if claims == nil {
+		return errdefs.ErrUnauthorized()
+	}
+	ctx := c.Request().Context()
+	if err := h.impersonationService.Stop(ctx, claims); err != nil {
+		return err
+	}
+
+	res := dto.NewMessage(200, "Impersonation stopped")
+	return c.JSON(res.Status, res)
+}
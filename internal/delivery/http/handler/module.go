@@ -7,8 +7,18 @@ import (
 var Module = fx.Module("handler",
 	fx.Provide(
 		NewHealthCheckHandler,
+		NewWellKnownHandler,
 		NewSPAHandler,
 		NewAuthHandler,
 		NewProfileHandler,
+		NewSessionHandler,
+		NewTwoFactorHandler,
+		NewPasskeyHandler,
+		NewOAuthHandler,
+		NewPersonalAccessTokenHandler,
+		NewRoleHandler,
+		NewAdminUserHandler,
+		NewImpersonationHandler,
+		NewAuditHandler,
 	),
 )
@@ -0,0 +1,209 @@
+package handler
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/akfaiz/go-vue-starter-kit/internal/config"
+	"github.com/akfaiz/go-vue-starter-kit/internal/delivery/http/handler/dto"
+	"github.com/akfaiz/go-vue-starter-kit/internal/delivery/http/middleware/auth"
+	"github.com/akfaiz/go-vue-starter-kit/internal/domain"
+	"github.com/akfaiz/go-vue-starter-kit/internal/errdefs"
+	"github.com/cockroachdb/errors"
+	"github.com/labstack/echo/v4"
+)
+
+const oauthStateCookie = "oauth_state"
+
+type OAuthHandler struct {
+	cfg          config.Config
+	oauthService domain.OAuthService
+	cookies      *auth.Cookies
+}
+
+func NewOAuthHandler(cfg config.Config, oauthService domain.OAuthService, cookies *auth.Cookies) *OAuthHandler {
+	return &OAuthHandler{
+		cfg:          cfg,
+		oauthService: oauthService,
+		cookies:      cookies,
+	}
+}
+
+func (h *OAuthHandler) Providers(c echo.Context) error {
+	res := dto.NewResponse(200, dto.OAuthProvidersResponse{Providers: h.oauthService.Providers()})
+	return c.JSON(res.Status, res)
+}
+
+func (h *OAuthHandler) Redirect(c echo.Context) error {
+	var req dto.OAuthProviderRequest
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+	ctx := c.Request().Context()
+	redirect, err := h.oauthService.Redirect(ctx, req.Provider, 0)
+	if err != nil {
+		return err
+	}
+
+	h.setStateCookie(c, redirect.FlowState)
+	return c.Redirect(http.StatusFound, redirect.URL)
+}
+
+// Callback finishes the flow started by Redirect or Link. The browser arrives
+// here from the provider, so the outcome is handed to the frontend in the URL
+// fragment, which is never sent to a server. In cookie mode the tokens are set as
+// cookies and the fragment only says the user is signed in.
+func (h *OAuthHandler) Callback(c echo.Context) error {
+	var req dto.OAuthCallbackRequest
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+	flowState := ""
+	if cookie, err := c.Cookie(oauthStateCookie); err == nil {
+		flowState = cookie.Value
+	}
+	h.clearStateCookie(c)
+
+	fragment := url.Values{}
+	switch {
+	case req.Error != "":
+		fragment.Set("error", firstNonEmpty(req.ErrorDescription, req.Error))
+	default:
+		ctx := c.Request().Context()
+		result, err := h.oauthService.Callback(ctx, req.Provider, req.Code, req.State, flowState)
+		switch {
+		case err != nil:
+			fragment.Set("error", callbackError(c, err))
+		case result.Linked:
+			fragment.Set("linked", req.Provider)
+		case result.Login.TwoFactorRequired():
+			fragment.Set("two_factor_required", "1")
+			fragment.Set("challenge_token", result.Login.ChallengeToken)
+		case h.cookies.Enabled():
+			if _, err := h.cookies.Issue(c, result.Login.Token); err != nil {
+				fragment.Set("error", callbackError(c, err))
+			} else {
+				fragment.Set("authenticated", "1")
+			}
+		default:
+			fragment.Set("access_token", result.Login.Token.AccessToken)
+			fragment.Set("refresh_token", result.Login.Token.RefreshToken)
+		}
+	}
+
+	target := strings.TrimSuffix(h.cfg.App.FrontendBaseURL, "/") + "/oauth/callback#" + fragment.Encode()
+	return c.Redirect(http.StatusFound, target)
+}
+
+func (h *OAuthHandler) ListIdentities(c echo.Context) error {
+	claims := auth.GetUser(c)
+	if claims == nil {
+		return errdefs.ErrUnauthorized()
+	}
+	ctx := c.Request().Context()
+	identities, err := h.oauthService.ListIdentities(ctx, claims.ID)
+	if err != nil {
+		return err
+	}
+
+	res := dto.NewResponse(200, dto.NewUserIdentityResponses(identities))
+	return c.JSON(res.Status, res)
+}
+
+// Link starts the flow for connecting a provider account to the signed in user.
+// It is called with the access token, so it returns the provider URL instead of
+// redirecting and the frontend navigates there itself.
+func (h *OAuthHandler) Link(c echo.Context) error {
+	var req dto.OAuthProviderRequest
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+	claims := auth.GetUser(c)
+	if claims == nil {
+		return errdefs.ErrUnauthorized()
+	}
+	ctx := c.Request().Context()
+	redirect, err := h.oauthService.Redirect(ctx, req.Provider, claims.ID)
+	if err != nil {
+		return err
+	}
+
+	h.setStateCookie(c, redirect.FlowState)
+	res := dto.NewResponse(200, dto.OAuthRedirectResponse{RedirectURL: redirect.URL})
+	return c.JSON(res.Status, res)
+}
+
+func (h *OAuthHandler) Unlink(c echo.Context) error {
+	var req dto.OAuthProviderRequest
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+	claims := auth.GetUser(c)
+	if claims == nil {
+		return errdefs.ErrUnauthorized()
+	}
+	ctx := c.Request().Context()
+	if err := h.oauthService.Unlink(ctx, claims.ID, req.Provider); err != nil {
+		if errors.Is(err, domain.ErrResourceNotFound) {
+			return errdefs.ErrNotFound("Linked account not found")
+		}
+		return err
+	}
+
+	res := dto.NewMessage(200, "Account unlinked successfully")
+	return c.JSON(res.Status, res)
+}
+
+func (h *OAuthHandler) setStateCookie(c echo.Context, value string) {
+	c.SetCookie(h.stateCookie(value, int(h.cfg.OAuth.StateExpiration.Seconds())))
+}
+
+func (h *OAuthHandler) clearStateCookie(c echo.Context) {
+	c.SetCookie(h.stateCookie("", -1))
+}
+
+func (h *OAuthHandler) stateCookie(value string, maxAge int) *http.Cookie {
+	path := "/v1/auth/oauth"
+	secure := false
+	if u, err := url.Parse(h.cfg.App.ApiBaseURL); err == nil {
+		path = strings.TrimSuffix(u.Path, "/") + path
+		secure = u.Scheme == "https"
+	}
+	return &http.Cookie{
+		Name:     oauthStateCookie,
+		Value:    value,
+		Path:     path,
+		MaxAge:   maxAge,
+		HttpOnly: true,
+		Secure:   secure,
+		SameSite: http.SameSiteLaxMode,
+	}
+}
+
+func callbackError(c echo.Context, err error) string {
+	var appError *errdefs.AppError
+	if errors.As(err, &appError) {
+		return firstNonEmpty(appError.Detail, appError.Title)
+	}
+	c.Logger().Error(err)
+	return errdefs.ErrInternalServer().Title
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
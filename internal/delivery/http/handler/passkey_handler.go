@@ -0,0 +1,130 @@
+package handler
+
+import (
+	"github.com/akfaiz/go-vue-starter-kit/internal/delivery/http/handler/dto"
+	"github.com/akfaiz/go-vue-starter-kit/internal/delivery/http/middleware/auth"
+	"github.com/akfaiz/go-vue-starter-kit/internal/domain"
+	"github.com/akfaiz/go-vue-starter-kit/internal/errdefs"
+	"github.com/cockroachdb/errors"
+	"github.com/labstack/echo/v4"
+)
+
+type PasskeyHandler struct {
+	passkeyService domain.PasskeyService
+	cookies        *auth.Cookies
+}
+
+func NewPasskeyHandler(passkeyService domain.PasskeyService, cookies *auth.Cookies) *PasskeyHandler {
+	return &PasskeyHandler{
+		passkeyService: passkeyService,
+		cookies:        cookies,
+	}
+}
+
+func (h *PasskeyHandler) ListPasskeys(c echo.Context) error {
+	claims := auth.GetUser(c)
+	if claims == nil {
+		return errdefs.ErrUnauthorized()
+	}
+	ctx := c.Request().Context()
+	passkeys, err := h.passkeyService.List(ctx, claims.ID)
+	if err != nil {
+		return err
+	}
+
+	res := dto.NewResponse(200, dto.NewPasskeyResponses(passkeys))
+	return c.JSON(res.Status, res)
+}
+
+func (h *PasskeyHandler) BeginRegistration(c echo.Context) error {
+	claims := auth.GetUser(c)
+	if claims == nil {
+		return errdefs.ErrUnauthorized()
+	}
+	ctx := c.Request().Context()
+	ceremony, err := h.passkeyService.BeginRegistration(ctx, claims.ID)
+	if err != nil {
+		return err
+	}
+
+	res := dto.NewResponse(200, dto.NewPasskeyCeremonyResponse(ceremony))
+	return c.JSON(res.Status, res)
+}
+
+func (h *PasskeyHandler) FinishRegistration(c echo.Context) error {
+	var req dto.FinishPasskeyRegistrationRequest
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+	claims := auth.GetUser(c)
+	if claims == nil {
+		return errdefs.ErrUnauthorized()
+	}
+	ctx := c.Request().Context()
+	passkey, err := h.passkeyService.FinishRegistration(ctx, claims.ID, req.CeremonyID, req.Name, req.Credential)
+	if err != nil {
+		return err
+	}
+
+	res := dto.NewResponse(201, dto.NewPasskeyResponse(passkey), "Passkey registered successfully")
+	return c.JSON(res.Status, res)
+}
+
+func (h *PasskeyHandler) DeletePasskey(c echo.Context) error {
+	var req dto.DeletePasskeyRequest
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+	claims := auth.GetUser(c)
+	if claims == nil {
+		return errdefs.ErrUnauthorized()
+	}
+	ctx := c.Request().Context()
+	if err := h.passkeyService.Delete(ctx, claims.ID, req.ID); err != nil {
+		if errors.Is(err, domain.ErrResourceNotFound) {
+			return errdefs.ErrNotFound("Passkey not found")
+		}
+		return err
+	}
+
+	res := dto.NewMessage(200, "Passkey deleted successfully")
+	return c.JSON(res.Status, res)
+}
+
+func (h *PasskeyHandler) BeginLogin(c echo.Context) error {
+	ctx := c.Request().Context()
+	ceremony, err := h.passkeyService.BeginLogin(ctx)
+	if err != nil {
+		return err
+	}
+
+	res := dto.NewResponse(200, dto.NewPasskeyCeremonyResponse(ceremony))
+	return c.JSON(res.Status, res)
+}
+
+func (h *PasskeyHandler) FinishLogin(c echo.Context) error {
+	var req dto.FinishPasskeyLoginRequest
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+	ctx := c.Request().Context()
+	pairToken, err := h.passkeyService.FinishLogin(ctx, req.CeremonyID, req.Credential)
+	if err != nil {
+		return err
+	}
+	if pairToken, err = h.cookies.Issue(c, pairToken); err != nil {
+		return err
+	}
+
+	res := dto.NewResponse(200, dto.NewTokenResponse(pairToken), "Login successful")
+	return c.JSON(res.Status, res)
+}
@@ -0,0 +1,84 @@
+package handler
+
+import (
+	"github.com/akfaiz/go-vue-starter-kit/internal/delivery/http/handler/dto"
+	"github.com/akfaiz/go-vue-starter-kit/internal/delivery/http/middleware/auth"
+	"github.com/akfaiz/go-vue-starter-kit/internal/domain"
+	"github.com/akfaiz/go-vue-starter-kit/internal/errdefs"
+	"github.com/cockroachdb/errors"
+	"github.com/labstack/echo/v4"
+)
+
+type PersonalAccessTokenHandler struct {
+	tokenService domain.PersonalAccessTokenService
+}
+
+func NewPersonalAccessTokenHandler(tokenService domain.PersonalAccessTokenService) *PersonalAccessTokenHandler {
+	return &PersonalAccessTokenHandler{
+		tokenService: tokenService,
+	}
+}
+
+func (h *PersonalAccessTokenHandler) ListTokens(c echo.Context) error {
+	claims := auth.GetUser(c)
+	if claims == nil {
+		return errdefs.ErrUnauthorized()
+	}
+	ctx := c.Request().Context()
+	tokens, err := h.tokenService.List(ctx, claims.ID)
+	if err != nil {
+		return err
+	}
+
+	res := dto.NewResponse(200, dto.NewPersonalAccessTokenResponses(tokens))
+	return c.JSON(res.Status, res)
+}
+
+func (h *PersonalAccessTokenHandler) CreateToken(c echo.Context) error {
+	var req dto.CreatePersonalAccessTokenRequest
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+	claims := auth.GetUser(c)
+	if claims == nil {
+		return errdefs.ErrUnauthorized()
+	}
+	ctx := c.Request().Context()
+	token, plainText, err := h.tokenService.Create(ctx, claims.ID, req.Name, req.Scopes, req.ExpiresAt)
+	if err != nil {
+		return err
+	}
+
+	res := dto.NewResponse(201, dto.CreatedPersonalAccessTokenResponse{
+		PersonalAccessTokenResponse: *dto.NewPersonalAccessTokenResponse(token),
+		Token:                       plainText,
+	}, "Token created successfully. Copy it now, it will not be shown again.")
+	return c.JSON(res.Status, res)
+}
+
+func (h *PersonalAccessTokenHandler) DeleteToken(c echo.Context) error {
+	var req dto.DeletePersonalAccessTokenRequest
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+	claims := auth.GetUser(c)
+	if claims == nil {
+		return errdefs.ErrUnauthorized()
+	}
+	ctx := c.Request().Context()
+	if err := h.tokenService.Delete(ctx, claims.ID, req.ID); err != nil {
+		if errors.Is(err, domain.ErrResourceNotFound) {
+			return errdefs.ErrNotFound("Token not found")
+		}
+		return err
+	}
+
+	res := dto.NewMessage(200, "Token deleted successfully")
+	return c.JSON(res.Status, res)
+}
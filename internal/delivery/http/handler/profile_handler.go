@@ -1,6 +1,8 @@
 package handler
 
 import (
+	"context"
+
 	"github.com/akfaiz/go-vue-starter-kit/internal/delivery/http/handler/dto"
 	"github.com/akfaiz/go-vue-starter-kit/internal/delivery/http/middleware/auth"
 	"github.com/akfaiz/go-vue-starter-kit/internal/domain"
@@ -10,11 +12,13 @@ import (
 
 type ProfileHandler struct {
 	userService domain.UserService
+	rbacService domain.RBACService
 }
 
-func NewProfileHandler(userService domain.UserService) *ProfileHandler {
+func NewProfileHandler(userService domain.UserService, rbacService domain.RBACService) *ProfileHandler {
 	return &ProfileHandler{
 		userService: userService,
+		rbacService: rbacService,
 	}
 }
 
@@ -25,12 +29,11 @@ func (h *ProfileHandler) GetProfile(c echo.Context) error {
 	if claims == nil {
 		return errdefs.ErrUnauthorized()
 	}
-	user, err := h.userService.FindByID(ctx, claims.ID)
+	profile, err := h.profile(ctx, claims)
 	if err != nil {
 		return err
 	}
-
-	res := dto.NewResponse(200, dto.NewProfileResponse(user))
+	res := dto.NewResponse(200, profile)
 	return c.JSON(res.Status, res)
 }
 
@@ -47,16 +50,72 @@ func (h *ProfileHandler) UpdateProfile(c echo.Context) error {
 	if claims == nil {
 		return errdefs.ErrUnauthorized()
 	}
-	user, err := h.userService.FindByID(ctx, claims.ID)
+	if err := h.userService.UpdateProfile(ctx, claims.ID, &domain.User{
+		Name: req.Name,
+	}); err != nil {
+		return err
+	}
+	profile, err := h.profile(ctx, claims)
 	if err != nil {
 		return err
 	}
-	user.Name = req.Name
-	user.Email = req.Email
-	if err := h.userService.UpdateProfile(ctx, claims.ID, user); err != nil {
+	res := dto.NewResponse(200, profile)
+	return c.JSON(res.Status, res)
+}
+
+func (h *ProfileHandler) ChangeEmail(c echo.Context) error {
+	var req dto.ChangeEmailRequest
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+	ctx := c.Request().Context()
+	claims := auth.GetUser(c)
+	if claims == nil {
+		return errdefs.ErrUnauthorized()
+	}
+	if err := h.userService.ChangeEmail(ctx, claims.ID, req.Email); err != nil {
+		return err
+	}
+	profile, err := h.profile(ctx, claims)
+	if err != nil {
+		return err
+	}
+	res := dto.NewResponse(200, profile)
+	return c.JSON(res.Status, res)
+}
+
+func (h *ProfileHandler) ConfirmEmailChange(c echo.Context) error {
+	var req dto.EmailChangeRequest
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+	ctx := c.Request().Context()
+	if err := h.userService.ConfirmEmailChange(ctx, req.UserID, req.Token); err != nil {
 		return err
 	}
-	res := dto.NewResponse(200, dto.NewProfileResponse(user))
+	res := dto.NewMessage(200, "Email address changed successfully")
+	return c.JSON(res.Status, res)
+}
+
+func (h *ProfileHandler) RevertEmailChange(c echo.Context) error {
+	var req dto.EmailChangeRequest
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+	ctx := c.Request().Context()
+	if err := h.userService.RevertEmailChange(ctx, req.UserID, req.Token); err != nil {
+		return err
+	}
+	res := dto.NewMessage(200, "Email address restored and all devices signed out")
 	return c.JSON(res.Status, res)
 }
 
@@ -99,3 +158,25 @@ func (h *ProfileHandler) DeleteAccount(c echo.Context) error {
 	res := dto.NewMessage(200, "Account deleted successfully")
 	return c.JSON(res.Status, res)
 }
+
+func (h *ProfileHandler) profile(ctx context.Context, claims *domain.JWTClaims) (*dto.ProfileResponse, error) {
+	user, err := h.userService.FindByID(ctx, claims.ID)
+	if err != nil {
+		return nil, err
+	}
+	authorization, err := h.rbacService.Authorization(ctx, claims.ID)
+	if err != nil {
+		return nil, err
+	}
+	pendingEmail, err := h.userService.PendingEmail(ctx, claims.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	profile := dto.NewProfileResponse(user, authorization)
+	if pendingEmail != "" {
+		profile.PendingEmail = &pendingEmail
+	}
+	profile.Impersonator = dto.NewImpersonatorResponse(claims.Actor)
+	return profile, nil
+}
@@ -0,0 +1,28 @@
+package handler
+
+import (
+	"github.com/akfaiz/go-vue-starter-kit/internal/delivery/http/handler/dto"
+	"github.com/akfaiz/go-vue-starter-kit/internal/domain"
+	"github.com/labstack/echo/v4"
+)
+
+type RoleHandler struct {
+	rbacService domain.RBACService
+}
+
+func NewRoleHandler(rbacService domain.RBACService) *RoleHandler {
+	return &RoleHandler{
+		rbacService: rbacService,
+	}
+}
+
+func (h *RoleHandler) ListRoles(c echo.Context) error {
+	ctx := c.Request().Context()
+	roles, err := h.rbacService.ListRoles(ctx)
+	if err != nil {
+		return err
+	}
+
+	res := dto.NewResponse(200, dto.NewRoleResponses(roles))
+	return c.JSON(res.Status, res)
+}
@@ -0,0 +1,64 @@
+package handler
+
+import (
+	"github.com/akfaiz/go-vue-starter-kit/internal/delivery/http/handler/dto"
+	"github.com/akfaiz/go-vue-starter-kit/internal/delivery/http/middleware/auth"
+	"github.com/akfaiz/go-vue-starter-kit/internal/domain"
+	"github.com/akfaiz/go-vue-starter-kit/internal/errdefs"
+	"github.com/cockroachdb/errors"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+type SessionHandler struct {
+	sessionService domain.SessionService
+}
+
+func NewSessionHandler(sessionService domain.SessionService) *SessionHandler {
+	return &SessionHandler{
+		sessionService: sessionService,
+	}
+}
+
+func (h *SessionHandler) ListSessions(c echo.Context) error {
+	claims := auth.GetUser(c)
+	if claims == nil {
+		return errdefs.ErrUnauthorized()
+	}
+	ctx := c.Request().Context()
+	sessions, err := h.sessionService.List(ctx, claims.ID)
+	if err != nil {
+		return err
+	}
+
+	res := dto.NewResponse(200, dto.NewSessionResponses(sessions, auth.GetSessionID(c)))
+	return c.JSON(res.Status, res)
+}
+
+func (h *SessionHandler) RevokeSession(c echo.Context) error {
+	var req dto.RevokeSessionRequest
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+	claims := auth.GetUser(c)
+	if claims == nil {
+		return errdefs.ErrUnauthorized()
+	}
+	// A malformed ID cannot name a session; Postgres would reject it as a uuid.
+	if err := uuid.Validate(req.ID); err != nil {
+		return errdefs.ErrNotFound("Session not found")
+	}
+	ctx := c.Request().Context()
+	if err := h.sessionService.Revoke(ctx, claims.ID, req.ID); err != nil {
+		if errors.Is(err, domain.ErrResourceNotFound) {
+			return errdefs.ErrNotFound("Session not found")
+		}
+		return err
+	}
+
+	res := dto.NewMessage(200, "Session revoked successfully")
+	return c.JSON(res.Status, res)
+}
@@ -36,14 +36,18 @@ func NewSPAHandler(root fs.FS, cfg config.Config) *SPAHandler {
 }
 
 type AppConfig struct {
-	ApiBaseUrl string `json:"apiBaseUrl"`
-	Env        string `json:"env"`
+	ApiBaseUrl       string `json:"apiBaseUrl"`
+	Env              string `json:"env"`
+	MagicLinkEnabled bool   `json:"magicLinkEnabled"`
+	AuthMode         string `json:"authMode"`
 }
 
 func (h *SPAHandler) Env(c echo.Context) error {
 	appConfig := AppConfig{
-		ApiBaseUrl: h.cfg.App.ApiBaseURL,
-		Env:        h.cfg.App.Env,
+		ApiBaseUrl:       h.cfg.App.ApiBaseURL,
+		Env:              h.cfg.App.Env,
+		MagicLinkEnabled: h.cfg.Auth.MagicLinkEnabled,
+		AuthMode:         h.cfg.Auth.Mode,
 	}
 	b, _ := json.Marshal(appConfig)
 	w := c.Response().Writer
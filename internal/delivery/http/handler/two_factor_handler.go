@@ -0,0 +1,99 @@
+package handler
+
+import (
+	"github.com/akfaiz/go-vue-starter-kit/internal/delivery/http/handler/dto"
+	"github.com/akfaiz/go-vue-starter-kit/internal/delivery/http/middleware/auth"
+	"github.com/akfaiz/go-vue-starter-kit/internal/domain"
+	"github.com/akfaiz/go-vue-starter-kit/internal/errdefs"
+	"github.com/labstack/echo/v4"
+)
+
+type TwoFactorHandler struct {
+	twoFactorService domain.TwoFactorService
+}
+
+func NewTwoFactorHandler(twoFactorService domain.TwoFactorService) *TwoFactorHandler {
+	return &TwoFactorHandler{
+		twoFactorService: twoFactorService,
+	}
+}
+
+func (h *TwoFactorHandler) Enable(c echo.Context) error {
+	claims := auth.GetUser(c)
+	if claims == nil {
+		return errdefs.ErrUnauthorized()
+	}
+	ctx := c.Request().Context()
+	setup, err := h.twoFactorService.Enable(ctx, claims.ID)
+	if err != nil {
+		return err
+	}
+
+	res := dto.NewResponse(200, dto.NewTwoFactorSetupResponse(setup), "Scan the QR code and confirm with a code from your authenticator app")
+	return c.JSON(res.Status, res)
+}
+
+func (h *TwoFactorHandler) Confirm(c echo.Context) error {
+	var req dto.ConfirmTwoFactorRequest
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+	claims := auth.GetUser(c)
+	if claims == nil {
+		return errdefs.ErrUnauthorized()
+	}
+	ctx := c.Request().Context()
+	codes, err := h.twoFactorService.Confirm(ctx, claims.ID, req.Code)
+	if err != nil {
+		return err
+	}
+
+	res := dto.NewResponse(200, dto.NewRecoveryCodesResponse(codes), "Two-factor authentication enabled successfully")
+	return c.JSON(res.Status, res)
+}
+
+func (h *TwoFactorHandler) Disable(c echo.Context) error {
+	var req dto.DisableTwoFactorRequest
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+	claims := auth.GetUser(c)
+	if claims == nil {
+		return errdefs.ErrUnauthorized()
+	}
+	ctx := c.Request().Context()
+	if err := h.twoFactorService.Disable(ctx, claims.ID, req.Password); err != nil {
+		return err
+	}
+
+	res := dto.NewMessage(200, "Two-factor authentication disabled successfully")
+	return c.JSON(res.Status, res)
+}
+
+func (h *TwoFactorHandler) RegenerateRecoveryCodes(c echo.Context) error {
+	var req dto.RegenerateRecoveryCodesRequest
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+	claims := auth.GetUser(c)
+	if claims == nil {
+		return errdefs.ErrUnauthorized()
+	}
+	ctx := c.Request().Context()
+	codes, err := h.twoFactorService.RegenerateRecoveryCodes(ctx, claims.ID, req.Password)
+	if err != nil {
+		return err
+	}
+
+	res := dto.NewResponse(200, dto.NewRecoveryCodesResponse(codes), "Recovery codes regenerated successfully")
+	return c.JSON(res.Status, res)
+}
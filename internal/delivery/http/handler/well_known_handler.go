@@ -0,0 +1,25 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/akfaiz/go-vue-starter-kit/internal/domain"
+	"github.com/labstack/echo/v4"
+)
+
+type WellKnownHandler struct {
+	jwtManager domain.JWTManager
+}
+
+func NewWellKnownHandler(jwtManager domain.JWTManager) *WellKnownHandler {
+	return &WellKnownHandler{
+		jwtManager: jwtManager,
+	}
+}
+
+// JWKS publishes the access token verification keys. Verifiers may cache the
+// set briefly; rotated keys stay listed until the tokens they signed expire.
+func (h *WellKnownHandler) JWKS(c echo.Context) error {
+	c.Response().Header().Set("Cache-Control", "public, max-age=300")
+	return c.JSON(http.StatusOK, h.jwtManager.JWKS())
+}
@@ -2,33 +2,42 @@ package auth
 
 import (
 	"context"
+	"errors"
+	"strings"
+	"time"
 
 	"github.com/akfaiz/go-vue-starter-kit/internal/domain"
 	"github.com/akfaiz/go-vue-starter-kit/internal/errdefs"
+	"github.com/invopop/ctxi18n/i18n"
 	"github.com/labstack/echo/v4"
 )
 
-type contextKey string
-
-const userContextKey contextKey = "user"
 const userKey = "user"
 
-func New(jwtManager domain.JWTManager) echo.MiddlewareFunc {
+// New authenticates the request with either a JWT access token or a personal
+// access token in the Authorization header. In cookie mode a request without the
+// header may carry the access token in its cookie instead. Suspended users are
+// rejected.
+func New(
+	jwtManager domain.JWTManager,
+	denylist domain.TokenDenylist,
+	tokenService domain.PersonalAccessTokenService,
+	suspensionService domain.SuspensionService,
+	cookies *Cookies,
+) echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
-			token := c.Request().Header.Get("Authorization")
-			if token == "" {
-				return errdefs.ErrUnauthorized("missing Authorization header")
-			}
-			if len(token) < 7 || token[:7] != "Bearer " {
-				return errdefs.ErrUnauthorized("Authorization header must start with 'Bearer '")
-			}
-			token = token[7:] // Remove "Bearer " prefix
-			if token == "" {
-				return errdefs.ErrUnauthorized("missing token in Authorization header")
+			token, fromCookie, err := requestToken(c, cookies)
+			if err != nil {
+				return err
 			}
 
-			claims, err := jwtManager.VerifyAccessToken(token)
+			var claims *domain.JWTClaims
+			if !fromCookie && strings.HasPrefix(token, domain.PersonalAccessTokenPrefix) {
+				claims, err = tokenService.Authenticate(c.Request().Context(), token)
+			} else {
+				claims, err = verifyAccessToken(c.Request().Context(), jwtManager, denylist, suspensionService, token)
+			}
 			if err != nil {
 				return err
 			}
@@ -36,7 +45,7 @@ func New(jwtManager domain.JWTManager) echo.MiddlewareFunc {
 			c.Set(userKey, claims) // Set user in context for Echo
 
 			req := c.Request()
-			ctx := context.WithValue(req.Context(), userContextKey, claims)
+			ctx := domain.ContextWithClaims(req.Context(), claims)
 			c.SetRequest(req.WithContext(ctx)) // Update request context
 
 			return next(c)
@@ -44,6 +53,156 @@ func New(jwtManager domain.JWTManager) echo.MiddlewareFunc {
 	}
 }
 
+func requestToken(c echo.Context, cookies *Cookies) (string, bool, error) {
+	token := c.Request().Header.Get("Authorization")
+	if token == "" {
+		if token = cookies.accessToken(c); token != "" {
+			return token, true, nil
+		}
+		return "", false, errdefs.ErrUnauthorized("missing Authorization header")
+	}
+	if len(token) < 7 || token[:7] != "Bearer " {
+		return "", false, errdefs.ErrUnauthorized("Authorization header must start with 'Bearer '")
+	}
+	token = token[7:] // Remove "Bearer " prefix
+	if token == "" {
+		return "", false, errdefs.ErrUnauthorized("missing token in Authorization header")
+	}
+	return token, false, nil
+}
+
+func verifyAccessToken(
+	ctx context.Context,
+	jwtManager domain.JWTManager,
+	denylist domain.TokenDenylist,
+	suspensionService domain.SuspensionService,
+	token string,
+) (*domain.JWTClaims, error) {
+	claims, err := jwtManager.VerifyAccessToken(token)
+	if err != nil {
+		return nil, err
+	}
+	// Checked before the denylist: suspending revokes the sessions too, and the
+	// user should learn why they were signed out rather than see an invalid token.
+	// Impersonation ends when either user is suspended.
+	userIDs := []int64{claims.ID}
+	if claims.IsImpersonated() {
+		userIDs = append(userIDs, claims.Actor.ID)
+	}
+	for _, userID := range userIDs {
+		suspended, err := suspensionService.IsSuspended(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+		if suspended {
+			return nil, errdefs.ErrAccountSuspended(i18n.T(ctx, "auth.suspended"))
+		}
+	}
+	revoked, err := denylist.IsRevoked(ctx, claims)
+	if err != nil {
+		return nil, err
+	}
+	if revoked {
+		return nil, errdefs.ErrTokenInvalid()
+	}
+	return claims, nil
+}
+
+// RequireScope rejects personal access tokens that were not granted scope.
+func RequireScope(scope string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if !HasScope(c, scope) {
+				return errdefs.ErrForbidden("This token is missing the " + scope + " scope.")
+			}
+			return next(c)
+		}
+	}
+}
+
+// RequireSession rejects personal access tokens and impersonation tokens, for
+// actions such as managing credentials that only the user may do after signing in.
+func RequireSession(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		if claims := GetUser(c); claims != nil {
+			if claims.Type == domain.JWTTypePersonalAccess {
+				return errdefs.ErrForbidden("Personal access tokens cannot be used for this action.")
+			}
+			if claims.IsImpersonated() {
+				return errdefs.ErrForbidden("This action is not allowed while impersonating a user.")
+			}
+		}
+		return next(c)
+	}
+}
+
+// PermissionGuard builds middleware that only lets users through whose roles
+// grant the permission.
+type PermissionGuard func(permission string) echo.MiddlewareFunc
+
+func NewPermissionGuard(rbacService domain.RBACService) PermissionGuard {
+	return func(permission string) echo.MiddlewareFunc {
+		return func(next echo.HandlerFunc) echo.HandlerFunc {
+			return func(c echo.Context) error {
+				claims := GetUser(c)
+				if claims == nil {
+					return errdefs.ErrUnauthorized()
+				}
+				// Personal access tokens only carry profile scopes.
+				if claims.Type == domain.JWTTypePersonalAccess {
+					return errdefs.ErrForbidden("Personal access tokens cannot be used for this action.")
+				}
+				// The impersonated user's permissions must not be usable by the impersonator.
+				if claims.IsImpersonated() {
+					return errdefs.ErrForbidden("This action is not allowed while impersonating a user.")
+				}
+				allowed, err := rbacService.HasPermission(c.Request().Context(), claims.ID, permission)
+				if err != nil {
+					return err
+				}
+				if !allowed {
+					return errdefs.ErrForbidden("You do not have permission to perform this action.")
+				}
+				return next(c)
+			}
+		}
+	}
+}
+
+// RecentAuthGuard builds middleware that only lets sessions through whose user
+// signed in or confirmed their password within maxAge. Others get the
+// password-confirmation-required problem, so the client can prompt for the
+// password, call the confirm endpoint and retry.
+type RecentAuthGuard func(maxAge time.Duration) echo.MiddlewareFunc
+
+func NewRecentAuthGuard(sessionService domain.SessionService) RecentAuthGuard {
+	return func(maxAge time.Duration) echo.MiddlewareFunc {
+		return func(next echo.HandlerFunc) echo.HandlerFunc {
+			return RequireSession(func(c echo.Context) error {
+				claims := GetUser(c)
+				if claims == nil {
+					return errdefs.ErrUnauthorized()
+				}
+				if claims.SessionID == "" {
+					return errdefs.ErrForbidden("This action requires signing in.")
+				}
+				ctx := c.Request().Context()
+				authenticatedAt, err := sessionService.AuthenticatedAt(ctx, claims.ID, claims.SessionID)
+				if err != nil {
+					if errors.Is(err, domain.ErrResourceNotFound) {
+						return errdefs.ErrTokenInvalid()
+					}
+					return err
+				}
+				if time.Since(authenticatedAt) > maxAge {
+					return errdefs.ErrPasswordConfirmationRequired()
+				}
+				return next(c)
+			})
+		}
+	}
+}
+
 func GetUser(c echo.Context) *domain.JWTClaims {
 	claims, ok := c.Get(userKey).(*domain.JWTClaims)
 	if !ok {
@@ -52,10 +211,21 @@ func GetUser(c echo.Context) *domain.JWTClaims {
 	return claims
 }
 
-func GetUserFromContext(ctx context.Context) *domain.JWTClaims {
-	claims, ok := ctx.Value(userContextKey).(*domain.JWTClaims)
-	if !ok {
-		return nil
+// HasScope reports whether the current request may act within scope.
+func HasScope(c echo.Context, scope string) bool {
+	claims := GetUser(c)
+	return claims != nil && claims.HasScope(scope)
+}
+
+// GetSessionID returns the ID of the session the current access token belongs to.
+func GetSessionID(c echo.Context) string {
+	claims := GetUser(c)
+	if claims == nil {
+		return ""
 	}
-	return claims
+	return claims.SessionID
+}
+
+func GetUserFromContext(ctx context.Context) *domain.JWTClaims {
+	return domain.ClaimsFromContext(ctx)
 }
@@ -0,0 +1,133 @@
+package auth
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/akfaiz/go-vue-starter-kit/internal/config"
+	"github.com/akfaiz/go-vue-starter-kit/internal/domain"
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	AccessTokenCookie  = "access_token"
+	RefreshTokenCookie = "refresh_token"
+	CSRFTokenCookie    = "csrf_token"
+	CSRFTokenHeader    = "X-CSRF-Token"
+
+	refreshTokenPath = "/v1/auth/refresh-token"
+)
+
+// Cookies hands the session tokens to browsers in HttpOnly cookies when the auth
+// mode is cookie. The refresh token cookie is only sent to the refresh endpoint.
+// Alongside them goes a CSRF token in a cookie scripts can read, which the
+// frontend echoes in the X-CSRF-Token header (see CSRF).
+type Cookies struct {
+	enabled        bool
+	basePath       string
+	domain         string
+	secure         bool
+	sameSite       http.SameSite
+	accessExpires  int
+	refreshExpires int
+}
+
+func NewCookies(cfg config.Config) *Cookies {
+	k := &Cookies{
+		enabled:        cfg.Auth.CookieMode(),
+		domain:         cfg.Auth.Cookie.Domain,
+		sameSite:       http.SameSiteLaxMode,
+		accessExpires:  int(cfg.Auth.JWT.AccessExpires.Seconds()),
+		refreshExpires: int(cfg.Auth.JWT.RefreshExpires.Seconds()),
+	}
+	if u, err := url.Parse(cfg.App.ApiBaseURL); err == nil {
+		k.basePath = strings.TrimSuffix(u.Path, "/")
+		k.secure = u.Scheme == "https"
+	}
+	if cfg.Auth.Cookie.SameSite == "strict" {
+		k.sameSite = http.SameSiteStrictMode
+	}
+	return k
+}
+
+// Enabled reports whether the tokens travel in cookies.
+func (k *Cookies) Enabled() bool {
+	return k != nil && k.enabled
+}
+
+// Issue sets the token cookies and returns the tokens for the response body,
+// which are empty in cookie mode so scripts never see them.
+func (k *Cookies) Issue(c echo.Context, token *domain.PairToken) (*domain.PairToken, error) {
+	if !k.Enabled() {
+		return token, nil
+	}
+	csrfToken, err := randomToken()
+	if err != nil {
+		return nil, err
+	}
+	c.SetCookie(k.cookie(AccessTokenCookie, token.AccessToken, k.path(""), k.accessExpires, true))
+	c.SetCookie(k.cookie(RefreshTokenCookie, token.RefreshToken, k.path(refreshTokenPath), k.refreshExpires, true))
+	c.SetCookie(k.cookie(CSRFTokenCookie, csrfToken, "/", k.refreshExpires, false))
+	return &domain.PairToken{}, nil
+}
+
+// Clear expires the token cookies, for logging out.
+func (k *Cookies) Clear(c echo.Context) {
+	if !k.Enabled() {
+		return
+	}
+	c.SetCookie(k.cookie(AccessTokenCookie, "", k.path(""), -1, true))
+	c.SetCookie(k.cookie(RefreshTokenCookie, "", k.path(refreshTokenPath), -1, true))
+	c.SetCookie(k.cookie(CSRFTokenCookie, "", "/", -1, false))
+}
+
+// RefreshToken returns the refresh token cookie sent with the request.
+func (k *Cookies) RefreshToken(c echo.Context) string {
+	return k.value(c, RefreshTokenCookie)
+}
+
+func (k *Cookies) accessToken(c echo.Context) string {
+	return k.value(c, AccessTokenCookie)
+}
+
+func (k *Cookies) value(c echo.Context, name string) string {
+	if !k.Enabled() {
+		return ""
+	}
+	cookie, err := c.Cookie(name)
+	if err != nil {
+		return ""
+	}
+	return cookie.Value
+}
+
+func (k *Cookies) path(suffix string) string {
+	if path := k.basePath + suffix; path != "" {
+		return path
+	}
+	return "/"
+}
+
+func (k *Cookies) cookie(name, value, path string, maxAge int, httpOnly bool) *http.Cookie {
+	return &http.Cookie{
+		Name:     name,
+		Value:    value,
+		Path:     path,
+		Domain:   k.domain,
+		MaxAge:   maxAge,
+		HttpOnly: httpOnly,
+		Secure:   k.secure,
+		SameSite: k.sameSite,
+	}
+}
+
+func randomToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
@@ -0,0 +1,150 @@
+package auth_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/akfaiz/go-vue-starter-kit/internal/config"
+	"github.com/akfaiz/go-vue-starter-kit/internal/delivery/http/middleware/auth"
+	"github.com/akfaiz/go-vue-starter-kit/internal/domain"
+	"github.com/akfaiz/go-vue-starter-kit/internal/errdefs"
+	"github.com/labstack/echo/v4"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Auth Cookies", Label("unit", "middleware"), func() {
+	var (
+		e       *echo.Echo
+		cfg     config.Config
+		cookies *auth.Cookies
+	)
+	BeforeEach(func() {
+		e = echo.New()
+		cfg = config.Config{
+			App: config.App{ApiBaseURL: "https://example.com/api"},
+			Auth: config.Auth{
+				Mode:   config.AuthModeCookie,
+				Cookie: config.Cookie{SameSite: "strict"},
+				JWT:    config.JWT{AccessExpires: 15 * time.Minute, RefreshExpires: time.Hour},
+			},
+		}
+		cookies = auth.NewCookies(cfg)
+	})
+
+	issued := func(rec *httptest.ResponseRecorder) map[string]*http.Cookie {
+		byName := map[string]*http.Cookie{}
+		for _, cookie := range rec.Result().Cookies() {
+			byName[cookie.Name] = cookie
+		}
+		return byName
+	}
+
+	Describe("Issue", func() {
+		It("should set the tokens as cookies and keep them out of the body", func() {
+			rec := httptest.NewRecorder()
+			c := e.NewContext(httptest.NewRequest(http.MethodPost, "/", nil), rec)
+
+			body, err := cookies.Issue(c, &domain.PairToken{AccessToken: "access", RefreshToken: "refresh"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(body.AccessToken).To(BeEmpty())
+			Expect(body.RefreshToken).To(BeEmpty())
+
+			set := issued(rec)
+			Expect(set[auth.AccessTokenCookie].Value).To(Equal("access"))
+			Expect(set[auth.AccessTokenCookie].Path).To(Equal("/api"))
+			Expect(set[auth.AccessTokenCookie].HttpOnly).To(BeTrue())
+			Expect(set[auth.AccessTokenCookie].Secure).To(BeTrue())
+			Expect(set[auth.AccessTokenCookie].SameSite).To(Equal(http.SameSiteStrictMode))
+			Expect(set[auth.AccessTokenCookie].MaxAge).To(Equal(900))
+			Expect(set[auth.RefreshTokenCookie].Value).To(Equal("refresh"))
+			Expect(set[auth.RefreshTokenCookie].Path).To(Equal("/api/v1/auth/refresh-token"))
+			Expect(set[auth.RefreshTokenCookie].HttpOnly).To(BeTrue())
+			Expect(set[auth.CSRFTokenCookie].Value).NotTo(BeEmpty())
+			Expect(set[auth.CSRFTokenCookie].HttpOnly).To(BeFalse())
+		})
+
+		It("should return the tokens untouched in bearer mode", func() {
+			cfg.Auth.Mode = config.AuthModeBearer
+			cookies = auth.NewCookies(cfg)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(httptest.NewRequest(http.MethodPost, "/", nil), rec)
+
+			body, err := cookies.Issue(c, &domain.PairToken{AccessToken: "access", RefreshToken: "refresh"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(body.AccessToken).To(Equal("access"))
+			Expect(rec.Result().Cookies()).To(BeEmpty())
+		})
+	})
+
+	Describe("Clear", func() {
+		It("should expire every cookie", func() {
+			rec := httptest.NewRecorder()
+			cookies.Clear(e.NewContext(httptest.NewRequest(http.MethodPost, "/", nil), rec))
+
+			set := issued(rec)
+			Expect(set).To(HaveLen(3))
+			for _, cookie := range set {
+				Expect(cookie.MaxAge).To(BeNumerically("<", 0))
+			}
+		})
+	})
+
+	Describe("CSRF", func() {
+		var handler echo.HandlerFunc
+		BeforeEach(func() {
+			handler = auth.CSRF(cookies)(func(c echo.Context) error {
+				return c.NoContent(http.StatusNoContent)
+			})
+		})
+
+		call := func(method string, prepare func(*http.Request)) error {
+			req := httptest.NewRequest(method, "/", nil)
+			prepare(req)
+			return handler(e.NewContext(req, httptest.NewRecorder()))
+		}
+		withCookies := func(req *http.Request) {
+			req.AddCookie(&http.Cookie{Name: auth.AccessTokenCookie, Value: "access"})
+			req.AddCookie(&http.Cookie{Name: auth.CSRFTokenCookie, Value: "csrf"})
+		}
+		expectMismatch := func(err error) {
+			var appErr *errdefs.AppError
+			Expect(errors.As(err, &appErr)).To(BeTrue())
+			Expect(appErr.Status).To(Equal(http.StatusForbidden))
+			Expect(appErr.Type).To(Equal("/problems/csrf-token-mismatch"))
+		}
+
+		It("should accept a header matching the cookie", func() {
+			err := call(http.MethodPost, func(req *http.Request) {
+				withCookies(req)
+				req.Header.Set(auth.CSRFTokenHeader, "csrf")
+			})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should reject a missing or different header", func() {
+			expectMismatch(call(http.MethodPost, withCookies))
+			expectMismatch(call(http.MethodDelete, func(req *http.Request) {
+				withCookies(req)
+				req.Header.Set(auth.CSRFTokenHeader, "other")
+			}))
+		})
+
+		It("should protect the refresh token cookie", func() {
+			expectMismatch(call(http.MethodPost, func(req *http.Request) {
+				req.AddCookie(&http.Cookie{Name: auth.RefreshTokenCookie, Value: "refresh"})
+			}))
+		})
+
+		It("should let safe methods, bearer tokens and anonymous requests through", func() {
+			Expect(call(http.MethodGet, withCookies)).To(Succeed())
+			Expect(call(http.MethodPost, func(req *http.Request) {
+				withCookies(req)
+				req.Header.Set(echo.HeaderAuthorization, "Bearer token")
+			})).To(Succeed())
+			Expect(call(http.MethodPost, func(*http.Request) {})).To(Succeed())
+		})
+	})
+})
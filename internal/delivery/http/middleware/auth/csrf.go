@@ -0,0 +1,40 @@
+package auth
+
+import (
+	"crypto/subtle"
+	"net/http"
+
+	"github.com/akfaiz/go-vue-starter-kit/internal/errdefs"
+	"github.com/labstack/echo/v4"
+)
+
+// CSRF protects state-changing requests authenticated by the token cookies with
+// the double-submit pattern: the X-CSRF-Token header must match the CSRF cookie,
+// which another site can neither read nor set. Requests with an Authorization
+// header are not sent by browsers on their own and pass through.
+func CSRF(cookies *Cookies) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		if !cookies.Enabled() {
+			return next
+		}
+		return func(c echo.Context) error {
+			req := c.Request()
+			switch req.Method {
+			case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace:
+				return next(c)
+			}
+			if req.Header.Get(echo.HeaderAuthorization) != "" {
+				return next(c)
+			}
+			if cookies.accessToken(c) == "" && cookies.RefreshToken(c) == "" {
+				return next(c)
+			}
+			expected := cookies.value(c, CSRFTokenCookie)
+			actual := req.Header.Get(CSRFTokenHeader)
+			if expected == "" || subtle.ConstantTimeCompare([]byte(expected), []byte(actual)) != 1 {
+				return errdefs.ErrCSRFTokenMismatch()
+			}
+			return next(c)
+		}
+	}
+}
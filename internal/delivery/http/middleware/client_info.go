@@ -0,0 +1,34 @@
+package middleware
+
+import (
+	"strings"
+
+	"github.com/akfaiz/go-vue-starter-kit/internal/domain"
+	"github.com/labstack/echo/v4"
+)
+
+const maxUserAgentLength = 512
+
+// ClientInfo stores the caller's IP address, user agent and request ID in the request context
+// so services can record where an action came from. It must run after the RequestID middleware.
+func ClientInfo() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			userAgent := c.Request().UserAgent()
+			if len(userAgent) > maxUserAgentLength {
+				userAgent = userAgent[:maxUserAgentLength]
+			}
+			// Dropping invalid sequences also removes a rune split by the cut,
+			// which Postgres would refuse to store.
+			userAgent = strings.ToValidUTF8(userAgent, "")
+			info := domain.ClientInfo{
+				IPAddress: c.RealIP(),
+				UserAgent: userAgent,
+				RequestID: c.Response().Header().Get(echo.HeaderXRequestID),
+			}
+			ctx := domain.ContextWithClientInfo(c.Request().Context(), info)
+			c.SetRequest(c.Request().WithContext(ctx))
+			return next(c)
+		}
+	}
+}
@@ -1,7 +1,9 @@
 package middleware
 
 import (
+	"github.com/akfaiz/go-vue-starter-kit/internal/config"
 	"github.com/akfaiz/go-vue-starter-kit/internal/delivery/http/middleware/auth"
+	"github.com/akfaiz/go-vue-starter-kit/internal/delivery/http/middleware/ratelimit"
 	"github.com/akfaiz/go-vue-starter-kit/internal/domain"
 	"github.com/labstack/echo/v4"
 	"go.uber.org/fx"
@@ -11,16 +13,50 @@ type Middleware struct {
 	fx.Out
 
 	Auth echo.MiddlewareFunc `name:"auth"`
+	CSRF echo.MiddlewareFunc `name:"csrf"`
+
+	Cookies *auth.Cookies
+
+	RequirePermission auth.PermissionGuard
+	RequireRecentAuth auth.RecentAuthGuard
+
+	RateLimitAuth    echo.MiddlewareFunc `name:"ratelimit_auth"`
+	RateLimitStrict  echo.MiddlewareFunc `name:"ratelimit_strict"`
+	RateLimitProfile echo.MiddlewareFunc `name:"ratelimit_profile"`
 }
 
 type MiddlewareConfig struct {
 	fx.In
 
-	JWTManager domain.JWTManager
+	Config            config.Config
+	JWTManager        domain.JWTManager
+	TokenDenylist     domain.TokenDenylist
+	TokenService      domain.PersonalAccessTokenService
+	SuspensionService domain.SuspensionService
+	RBACService       domain.RBACService
+	SessionService    domain.SessionService
+	ThrottleStore     domain.ThrottleStore
 }
 
 func New(cfg MiddlewareConfig) Middleware {
-	return Middleware{
-		Auth: auth.New(cfg.JWTManager),
+	cookies := auth.NewCookies(cfg.Config)
+	m := Middleware{
+		Auth: auth.New(cfg.JWTManager, cfg.TokenDenylist, cfg.TokenService, cfg.SuspensionService, cookies),
+		CSRF: auth.CSRF(cookies),
+
+		Cookies: cookies,
+
+		RequirePermission: auth.NewPermissionGuard(cfg.RBACService),
+		RequireRecentAuth: auth.NewRecentAuthGuard(cfg.SessionService),
+
+		RateLimitAuth:    ratelimit.Disabled,
+		RateLimitStrict:  ratelimit.Disabled,
+		RateLimitProfile: ratelimit.Disabled,
+	}
+	if rl := cfg.Config.Throttle.RateLimit; rl.Enabled {
+		m.RateLimitAuth = ratelimit.New(cfg.ThrottleStore, rl.Auth, ratelimit.ByIP)
+		m.RateLimitStrict = ratelimit.New(cfg.ThrottleStore, rl.Strict, ratelimit.ByRouteAndIP)
+		m.RateLimitProfile = ratelimit.New(cfg.ThrottleStore, rl.Profile, ratelimit.ByUser)
 	}
+	return m
 }
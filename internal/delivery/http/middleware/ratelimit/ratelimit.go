@@ -0,0 +1,140 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/akfaiz/go-vue-starter-kit/internal/config"
+	"github.com/akfaiz/go-vue-starter-kit/internal/delivery/http/middleware/auth"
+	"github.com/akfaiz/go-vue-starter-kit/internal/domain"
+	"github.com/akfaiz/go-vue-starter-kit/internal/errdefs"
+	"github.com/invopop/ctxi18n/i18n"
+	"github.com/labstack/echo/v4"
+)
+
+const sweepInterval = time.Minute
+
+// KeyFunc identifies the client a request is counted against.
+type KeyFunc func(c echo.Context) string
+
+// ByIP counts requests per client IP.
+func ByIP(c echo.Context) string {
+	return "ip:" + c.RealIP()
+}
+
+// ByRouteAndIP counts requests per route and client IP, so one policy can
+// guard several endpoints without them sharing a quota.
+func ByRouteAndIP(c echo.Context) string {
+	return "route:" + c.Request().Method + " " + c.Path() + ":" + ByIP(c)
+}
+
+// ByUser counts requests per authenticated user, falling back to the client IP.
+// It must run after the auth middleware.
+func ByUser(c echo.Context) string {
+	if claims := auth.GetUser(c); claims != nil {
+		return "user:" + strconv.FormatInt(claims.ID, 10)
+	}
+	return ByIP(c)
+}
+
+// Limiter implements a sliding window counter on top of a ThrottleStore: the
+// count of the previous fixed window is weighted by how much of it still
+// overlaps the sliding window.
+type Limiter struct {
+	store     domain.ThrottleStore
+	policy    config.RateLimitPolicy
+	lastSweep atomic.Int64
+}
+
+type Result struct {
+	Allowed   bool
+	Limit     int
+	Remaining int
+	Reset     time.Duration // until the current fixed window ends
+}
+
+func NewLimiter(store domain.ThrottleStore, policy config.RateLimitPolicy) *Limiter {
+	return &Limiter{store: store, policy: policy}
+}
+
+func (l *Limiter) Allow(ctx context.Context, key string) (*Result, error) {
+	now := time.Now()
+	window := l.policy.Window
+	start := now.Truncate(window)
+	elapsed := now.Sub(start)
+
+	l.sweep(ctx, now)
+
+	current, err := l.store.Hit(ctx, l.key(key, start), 2*window)
+	if err != nil {
+		return nil, err
+	}
+	previous := 0
+	counter, err := l.store.Get(ctx, l.key(key, start.Add(-window)))
+	switch {
+	case err == nil:
+		previous = counter.Hits
+	case !errors.Is(err, domain.ErrResourceNotFound):
+		return nil, err
+	}
+
+	overlap := float64(window-elapsed) / float64(window)
+	count := int(float64(previous)*overlap) + current.Hits
+	return &Result{
+		Allowed:   count <= l.policy.Limit,
+		Limit:     l.policy.Limit,
+		Remaining: max(l.policy.Limit-count, 0),
+		Reset:     window - elapsed,
+	}, nil
+}
+
+func (l *Limiter) key(key string, windowStart time.Time) string {
+	return fmt.Sprintf("ratelimit:%s:%s:%d", l.policy.Name, key, windowStart.Unix())
+}
+
+// sweep drops expired counters at most once per sweepInterval.
+func (l *Limiter) sweep(ctx context.Context, now time.Time) {
+	last := l.lastSweep.Load()
+	if now.Unix()-last < int64(sweepInterval.Seconds()) || !l.lastSweep.CompareAndSwap(last, now.Unix()) {
+		return
+	}
+	_ = l.store.DeleteExpired(ctx)
+}
+
+// New returns a middleware enforcing policy per key. It sets the RateLimit-*
+// headers from the IETF draft on every response and answers 429 when exceeded.
+// When the store fails the request is let through rather than failing closed.
+func New(store domain.ThrottleStore, policy config.RateLimitPolicy, keyFunc KeyFunc) echo.MiddlewareFunc {
+	limiter := NewLimiter(store, policy)
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			ctx := c.Request().Context()
+			result, err := limiter.Allow(ctx, keyFunc(c))
+			if err != nil {
+				c.Logger().Error(err)
+				return next(c)
+			}
+
+			reset := int(result.Reset.Round(time.Second) / time.Second)
+			header := c.Response().Header()
+			header.Set("RateLimit-Limit", strconv.Itoa(result.Limit))
+			header.Set("RateLimit-Remaining", strconv.Itoa(result.Remaining))
+			header.Set("RateLimit-Reset", strconv.Itoa(reset))
+			header.Set("RateLimit-Policy", fmt.Sprintf("%d;w=%d", policy.Limit, int(policy.Window/time.Second)))
+
+			if !result.Allowed {
+				return errdefs.ErrTooManyRequests(i18n.T(ctx, "rate_limit.exceeded")).WithRetryAfter(result.Reset)
+			}
+			return next(c)
+		}
+	}
+}
+
+// Disabled is used in place of a limiter when rate limiting is turned off.
+func Disabled(next echo.HandlerFunc) echo.HandlerFunc {
+	return next
+}
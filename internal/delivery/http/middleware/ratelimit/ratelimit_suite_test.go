@@ -0,0 +1,18 @@
+package ratelimit_test
+
+import (
+	"testing"
+
+	"github.com/akfaiz/go-vue-starter-kit/internal/lang"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestRateLimit(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Rate Limit Middleware Suite")
+}
+
+var _ = BeforeSuite(func() {
+	lang.Init()
+})
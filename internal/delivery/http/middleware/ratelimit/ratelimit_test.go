@@ -0,0 +1,149 @@
+package ratelimit_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"time"
+
+	"github.com/akfaiz/go-vue-starter-kit/internal/config"
+	"github.com/akfaiz/go-vue-starter-kit/internal/delivery/http/middleware/ratelimit"
+	"github.com/akfaiz/go-vue-starter-kit/internal/delivery/http/server"
+	"github.com/akfaiz/go-vue-starter-kit/internal/domain"
+	"github.com/akfaiz/go-vue-starter-kit/internal/errdefs"
+	throttlestore "github.com/akfaiz/go-vue-starter-kit/internal/repository/throttle"
+	"github.com/invopop/ctxi18n"
+	"github.com/labstack/echo/v4"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Rate Limit Middleware", Label("unit", "middleware"), func() {
+	var (
+		e       *echo.Echo
+		store   domain.ThrottleStore
+		handler echo.HandlerFunc
+	)
+	BeforeEach(func() {
+		e = echo.New()
+		store = throttlestore.NewMemoryStore()
+		policy := config.RateLimitPolicy{Name: "test", Limit: 3, Window: time.Hour}
+		handler = ratelimit.New(store, policy, ratelimit.ByIP)(func(c echo.Context) error {
+			return c.NoContent(http.StatusNoContent)
+		})
+	})
+
+	call := func(ip string) (*httptest.ResponseRecorder, error) {
+		req := httptest.NewRequest(http.MethodPost, "/", nil)
+		req.Header.Set(echo.HeaderXRealIP, ip)
+		ctx, _ := ctxi18n.WithLocale(req.Context(), "en")
+		rec := httptest.NewRecorder()
+		return rec, handler(e.NewContext(req.WithContext(ctx), rec))
+	}
+
+	It("should let requests through and report the remaining quota", func() {
+		rec, err := call("203.0.113.7")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(rec.Code).To(Equal(http.StatusNoContent))
+		Expect(rec.Header().Get("RateLimit-Limit")).To(Equal("3"))
+		Expect(rec.Header().Get("RateLimit-Remaining")).To(Equal("2"))
+		Expect(rec.Header().Get("RateLimit-Policy")).To(Equal("3;w=3600"))
+		Expect(rec.Header().Get("RateLimit-Reset")).NotTo(BeEmpty())
+	})
+
+	It("should answer 429 with Retry-After once the limit is exceeded", func() {
+		for range 3 {
+			_, err := call("203.0.113.7")
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		rec, err := call("203.0.113.7")
+		var appErr *errdefs.AppError
+		Expect(errors.As(err, &appErr)).To(BeTrue())
+		Expect(appErr.Status).To(Equal(http.StatusTooManyRequests))
+		Expect(appErr.RetryAfter()).To(BeNumerically(">", 0))
+		Expect(rec.Header().Get("RateLimit-Remaining")).To(Equal("0"))
+	})
+
+	It("should count every client separately", func() {
+		for range 4 {
+			_, _ = call("203.0.113.7")
+		}
+		_, err := call("198.51.100.1")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should count every route separately when keyed by route", func() {
+		policy := config.RateLimitPolicy{Name: "strict", Limit: 1, Window: time.Hour}
+		strict := ratelimit.New(store, policy, ratelimit.ByRouteAndIP)(func(c echo.Context) error {
+			return c.NoContent(http.StatusNoContent)
+		})
+		callRoute := func(path string) error {
+			req := httptest.NewRequest(http.MethodPost, path, nil)
+			req.Header.Set(echo.HeaderXRealIP, "203.0.113.7")
+			ctx, _ := ctxi18n.WithLocale(req.Context(), "en")
+			c := e.NewContext(req.WithContext(ctx), httptest.NewRecorder())
+			c.SetPath(path)
+			return strict(c)
+		}
+
+		Expect(callRoute("/api/auth/register")).To(Succeed())
+		Expect(callRoute("/api/auth/forgot-password")).To(Succeed())
+		Expect(callRoute("/api/auth/register")).NotTo(Succeed())
+	})
+
+	Describe("behind the server's IP extraction", func() {
+		serve := func(cfg config.Config, remoteAddr, forwardedFor string) int {
+			e := server.New(cfg)
+			policy := config.RateLimitPolicy{Name: "forwarded", Limit: 1, Window: time.Hour}
+			e.POST("/login", func(c echo.Context) error {
+				return c.NoContent(http.StatusNoContent)
+			}, ratelimit.New(store, policy, ratelimit.ByIP))
+
+			req := httptest.NewRequest(http.MethodPost, "/login", nil)
+			req.RemoteAddr = remoteAddr
+			req.Header.Set(echo.HeaderXForwardedFor, forwardedFor)
+			req.Header.Set(echo.HeaderXRealIP, forwardedFor)
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+			return rec.Code
+		}
+
+		It("should not let a forged X-Forwarded-For reset the limit", func() {
+			cfg := config.Config{}
+			Expect(serve(cfg, "203.0.113.7:1234", "198.51.100.1")).To(Equal(http.StatusNoContent))
+			Expect(serve(cfg, "203.0.113.7:1234", "198.51.100.2")).To(Equal(http.StatusTooManyRequests))
+		})
+
+		It("should count the forwarded client when the proxy is trusted", func() {
+			cfg := config.Config{}
+			_, proxies, err := net.ParseCIDR("10.0.0.0/8")
+			Expect(err).NotTo(HaveOccurred())
+			cfg.Server.TrustedProxies = []*net.IPNet{proxies}
+
+			Expect(serve(cfg, "10.0.0.5:1234", "198.51.100.1")).To(Equal(http.StatusNoContent))
+			Expect(serve(cfg, "10.0.0.5:1234", "198.51.100.2")).To(Equal(http.StatusNoContent))
+			Expect(serve(cfg, "10.0.0.5:1234", "198.51.100.1")).To(Equal(http.StatusTooManyRequests))
+		})
+	})
+
+	It("should weight the previous window into the count", func() {
+		ctx := context.Background()
+		limiter := ratelimit.NewLimiter(store, config.RateLimitPolicy{Name: "slide", Limit: 10, Window: time.Hour})
+		previous := time.Now().Truncate(time.Hour).Add(-time.Hour).Unix()
+		for range 10 {
+			_, err := store.Hit(ctx, "ratelimit:slide:client:"+strconv.FormatInt(previous, 10), 2*time.Hour)
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		now := time.Now()
+		overlap := float64(time.Hour-now.Sub(now.Truncate(time.Hour))) / float64(time.Hour)
+		result, err := limiter.Allow(ctx, "client")
+		Expect(err).NotTo(HaveOccurred())
+		// The part of the previous window still inside the sliding window counts.
+		Expect(result.Remaining).To(BeNumerically("~", 9-int(10*overlap), 1))
+	})
+})
@@ -10,6 +10,8 @@ import (
 	"github.com/akfaiz/go-vue-starter-kit/internal/config"
 	"github.com/akfaiz/go-vue-starter-kit/internal/delivery/http/handler"
 	"github.com/akfaiz/go-vue-starter-kit/internal/delivery/http/handler/dto"
+	authmw "github.com/akfaiz/go-vue-starter-kit/internal/delivery/http/middleware/auth"
+	"github.com/akfaiz/go-vue-starter-kit/internal/domain"
 	"github.com/labstack/echo/v4"
 	"github.com/oaswrap/spec/adapter/echoopenapi"
 	"github.com/oaswrap/spec/option"
@@ -21,16 +23,34 @@ type RouteConfig struct {
 	Echo   *echo.Echo
 	Config config.Config
 
-	AuthMiddleware echo.MiddlewareFunc `name:"auth"`
+	AuthMiddleware    echo.MiddlewareFunc `name:"auth"`
+	CSRF              echo.MiddlewareFunc `name:"csrf"`
+	RequirePermission authmw.PermissionGuard
+	RequireRecentAuth authmw.RecentAuthGuard
 
-	AuthHandler        *handler.AuthHandler
-	ProfileHandler     *handler.ProfileHandler
-	HealthCheckHandler *handler.HealthCheckHandler
-	SPAHandler         *handler.SPAHandler
+	RateLimitAuth    echo.MiddlewareFunc `name:"ratelimit_auth"`
+	RateLimitStrict  echo.MiddlewareFunc `name:"ratelimit_strict"`
+	RateLimitProfile echo.MiddlewareFunc `name:"ratelimit_profile"`
+
+	AuthHandler                *handler.AuthHandler
+	ProfileHandler             *handler.ProfileHandler
+	SessionHandler             *handler.SessionHandler
+	TwoFactorHandler           *handler.TwoFactorHandler
+	PasskeyHandler             *handler.PasskeyHandler
+	OAuthHandler               *handler.OAuthHandler
+	PersonalAccessTokenHandler *handler.PersonalAccessTokenHandler
+	RoleHandler                *handler.RoleHandler
+	AdminUserHandler           *handler.AdminUserHandler
+	ImpersonationHandler       *handler.ImpersonationHandler
+	AuditHandler               *handler.AuditHandler
+	HealthCheckHandler         *handler.HealthCheckHandler
+	WellKnownHandler           *handler.WellKnownHandler
+	SPAHandler                 *handler.SPAHandler
 }
 
 func Register(rc RouteConfig) {
 	rc.Echo.GET("/health", rc.HealthCheckHandler.HealthCheck)
+	rc.Echo.GET("/.well-known/jwks.json", rc.WellKnownHandler.JWKS)
 
 	rc.registerAPI()
 	rc.registerWeb()
@@ -63,33 +83,99 @@ func (rc RouteConfig) registerAPI() {
 		option.WithServer("https://go-vue-starter-kit.fly.dev/", option.ServerDescription("Production server")),
 		option.WithServer(fmt.Sprintf("http://localhost:%d", rc.Config.Server.Port), option.ServerDescription("Local server")),
 	)
-	v1 := r.Group("/api/v1")
+	v1 := r.Group("/api/v1", rc.CSRF)
 
-	auth := v1.Group("/auth").With(option.GroupTags("Authentication"))
+	auth := v1.Group("/auth", rc.RateLimitAuth).With(option.GroupTags("Authentication"))
 	auth.POST("/login", rc.AuthHandler.Login).With(
 		option.Summary("User Login"),
-		option.Description("Authenticate user and return access and refresh tokens"),
+		option.Description("Authenticate user and return access and refresh tokens, or a challenge token when two-factor authentication is enabled"),
 		option.Request(new(dto.LoginRequest)),
+		option.Response(200, responseOf(dto.LoginResponse{})),
+	)
+	auth.POST("/two-factor/verify", rc.AuthHandler.VerifyTwoFactor).With(
+		option.Summary("Verify Two-Factor Login"),
+		option.Description("Exchange a login challenge token and a TOTP or recovery code for access and refresh tokens"),
+		option.Request(new(dto.VerifyTwoFactorRequest)),
+		option.Response(200, responseOf(dto.TokenResponse{})),
+	)
+	auth.POST("/passkey/login/begin", rc.PasskeyHandler.BeginLogin).With(
+		option.Summary("Begin Passkey Login"),
+		option.Description("Start a passkey login ceremony and return the options for navigator.credentials.get()"),
+		option.Response(200, responseOf(dto.PasskeyCeremonyResponse{})),
+	)
+	auth.POST("/passkey/login/finish", rc.PasskeyHandler.FinishLogin).With(
+		option.Summary("Finish Passkey Login"),
+		option.Description("Verify the passkey assertion and return access and refresh tokens"),
+		option.Request(new(dto.FinishPasskeyLoginRequest)),
 		option.Response(200, responseOf(dto.TokenResponse{})),
 	)
-	auth.POST("/register", rc.AuthHandler.Register).With(
+	auth.GET("/oauth/providers", rc.OAuthHandler.Providers).With(
+		option.Summary("List Social Login Providers"),
+		option.Description("List the configured OAuth 2.0 / OpenID Connect providers"),
+		option.Response(200, responseOf(dto.OAuthProvidersResponse{})),
+	)
+	auth.GET("/oauth/:provider/redirect", rc.OAuthHandler.Redirect).With(
+		option.Summary("Social Login Redirect"),
+		option.Description("Redirect the browser to the provider's authorization page"),
+		option.Request(new(dto.OAuthProviderRequest)),
+		option.Response(302, nil),
+	)
+	auth.GET("/oauth/:provider/callback", rc.OAuthHandler.Callback).With(
+		option.Summary("Social Login Callback"),
+		option.Description("Finish the provider flow and redirect to the frontend with the tokens, a two-factor challenge or an error in the URL fragment"),
+		option.Request(new(dto.OAuthCallbackRequest)),
+		option.Response(302, nil),
+	)
+	auth.POST("/register", rc.AuthHandler.Register, rc.RateLimitStrict).With(
 		option.Summary("User Registration"),
-		option.Description("Register a new user"),
+		option.Description("Register a new user. In stealth mode no tokens are returned: the user is asked to check their email, whether or not the address was already taken"),
 		option.Request(new(dto.RegisterRequest)),
 		option.Response(201, responseOf(dto.TokenResponse{})),
+		option.Response(202, responseOf[any](nil)),
 	)
 	auth.POST("/refresh-token", rc.AuthHandler.RefreshToken).With(
 		option.Summary("Refresh Token"),
-		option.Description("Refresh access and refresh tokens using a valid refresh token"),
+		option.Description("Refresh access and refresh tokens using a valid refresh token, read from the refresh token cookie in cookie auth mode"),
 		option.Request(new(dto.RefreshTokenRequest)),
 		option.Response(200, responseOf(dto.TokenResponse{})),
 	)
-	auth.POST("/forgot-password", rc.AuthHandler.SendForgotPasswordEmail).With(
+	auth.POST("/logout", rc.AuthHandler.Logout, rc.AuthMiddleware).With(
+		option.Summary("Logout"),
+		option.Description("Revoke the current session and its refresh token"),
+		option.Response(200, responseOf[any](nil)),
+		option.Security("bearerAuth"),
+	)
+	auth.POST("/logout-all", rc.AuthHandler.LogoutAll, rc.AuthMiddleware, authmw.RequireSession).With(
+		option.Summary("Logout Everywhere"),
+		option.Description("Revoke every session of the authenticated user"),
+		option.Response(200, responseOf[any](nil)),
+		option.Security("bearerAuth"),
+	)
+	auth.POST("/confirm-password", rc.AuthHandler.ConfirmPassword, rc.AuthMiddleware, authmw.RequireSession, rc.RateLimitStrict).With(
+		option.Summary("Confirm Password"),
+		option.Description("Re-enter the password to unlock sensitive actions for this session for a while"),
+		option.Request(new(dto.ConfirmPasswordRequest)),
+		option.Response(200, responseOf[any](nil)),
+		option.Security("bearerAuth"),
+	)
+	auth.POST("/forgot-password", rc.AuthHandler.SendForgotPasswordEmail, rc.RateLimitStrict).With(
 		option.Summary("Send Forgot Password Email"),
 		option.Description("Send a password reset email to the user"),
 		option.Request(new(dto.SendForgotPasswordEmailRequest)),
 		option.Response(200, responseOf[any](nil)),
 	)
+	auth.POST("/magic-link", rc.AuthHandler.SendMagicLink, rc.RateLimitStrict).With(
+		option.Summary("Send Magic Link"),
+		option.Description("Email a single-use, short-lived sign-in link to the user"),
+		option.Request(new(dto.SendMagicLinkRequest)),
+		option.Response(200, responseOf[any](nil)),
+	)
+	auth.POST("/magic-link/verify", rc.AuthHandler.VerifyMagicLink).With(
+		option.Summary("Verify Magic Link"),
+		option.Description("Exchange a sign-in link token for access and refresh tokens, or a challenge token when two-factor authentication is enabled"),
+		option.Request(new(dto.VerifyMagicLinkRequest)),
+		option.Response(200, responseOf(dto.LoginResponse{})),
+	)
 	auth.POST("/validate-reset-password", rc.AuthHandler.ValidateResetPassword).With(
 		option.Summary("Validate Reset Password Token"),
 		option.Description("Validate the password reset token and email"),
@@ -102,7 +188,13 @@ func (rc RouteConfig) registerAPI() {
 		option.Request(new(dto.ResetPasswordRequest)),
 		option.Response(200, responseOf[any](nil)),
 	)
-	auth.POST("/email/send-verification", rc.AuthHandler.SendVerificationEmail, rc.AuthMiddleware).With(
+	auth.POST("/password/expired", rc.AuthHandler.ChangeExpiredPassword, rc.RateLimitStrict).With(
+		option.Summary("Change Expired Password"),
+		option.Description("Replace a password the login rejected as expired, then sign in like the login endpoint"),
+		option.Request(new(dto.ChangeExpiredPasswordRequest)),
+		option.Response(200, responseOf(dto.LoginResponse{})),
+	)
+	auth.POST("/email/send-verification", rc.AuthHandler.SendVerificationEmail, rc.AuthMiddleware, rc.RateLimitStrict).With(
 		option.Summary("Send Verification Email"),
 		option.Description("Send an email verification link to the user"),
 		option.Response(200, responseOf[any](nil)),
@@ -115,34 +207,227 @@ func (rc RouteConfig) registerAPI() {
 		option.Response(200, responseOf[any](nil)),
 		option.Security("bearerAuth"),
 	)
+	auth.POST("/email/change/confirm", rc.ProfileHandler.ConfirmEmailChange).With(
+		option.Summary("Confirm Email Change"),
+		option.Description("Switch to the requested email address using the token sent to it"),
+		option.Request(new(dto.EmailChangeRequest)),
+		option.Response(200, responseOf[any](nil)),
+	)
+	auth.POST("/email/change/revert", rc.ProfileHandler.RevertEmailChange).With(
+		option.Summary("Revert Email Change"),
+		option.Description("Restore the previous email address using the token sent to it and sign out all devices"),
+		option.Request(new(dto.EmailChangeRequest)),
+		option.Response(200, responseOf[any](nil)),
+	)
 
-	profile := v1.Group("/profile", rc.AuthMiddleware).With(
+	// Managing credentials needs a recent sign-in or password confirmation.
+	recentAuth := rc.RequireRecentAuth(rc.Config.Auth.PasswordConfirmationTimeout)
+	profile := v1.Group("/profile", rc.AuthMiddleware, rc.RateLimitProfile).With(
 		option.GroupTags("Profile"),
 		option.GroupSecurity("bearerAuth"),
 	)
-	profile.GET("", rc.ProfileHandler.GetProfile).With(
+	profile.GET("", rc.ProfileHandler.GetProfile, authmw.RequireScope(domain.ScopeProfileRead)).With(
 		option.Summary("Get User Profile"),
 		option.Description("Retrieve the profile information of the authenticated user"),
 		option.Response(200, responseOf(dto.ProfileResponse{})),
 	)
-	profile.PUT("", rc.ProfileHandler.UpdateProfile).With(
+	profile.PUT("", rc.ProfileHandler.UpdateProfile, authmw.RequireScope(domain.ScopeProfileWrite)).With(
 		option.Summary("Update User Profile"),
 		option.Description("Update the profile information of the authenticated user"),
 		option.Request(new(dto.UpdateProfileRequest)),
 		option.Response(200, responseOf(dto.ProfileResponse{})),
 	)
-	profile.DELETE("", rc.ProfileHandler.DeleteAccount).With(
+	profile.PUT("/email", rc.ProfileHandler.ChangeEmail, recentAuth).With(
+		option.Summary("Change Email"),
+		option.Description("Send a confirmation link to a new email address, which only takes effect once confirmed"),
+		option.Request(new(dto.ChangeEmailRequest)),
+		option.Response(200, responseOf(dto.ProfileResponse{})),
+	)
+	profile.DELETE("", rc.ProfileHandler.DeleteAccount, authmw.RequireSession).With(
 		option.Summary("Delete User Account"),
 		option.Description("Delete the authenticated user's account"),
 		option.Request(new(dto.DeleteAccountRequest)),
 		option.Response(200, responseOf[any](nil)),
 	)
-	profile.PUT("/password", rc.ProfileHandler.ChangePassword).With(
+	profile.PUT("/password", rc.ProfileHandler.ChangePassword, authmw.RequireSession).With(
 		option.Summary("Change Password"),
 		option.Description("Change the password of the authenticated user"),
 		option.Request(new(dto.ChangePasswordRequest)),
 		option.Response(200, responseOf[any](nil)),
 	)
+	profile.POST("/two-factor", rc.TwoFactorHandler.Enable, recentAuth).With(
+		option.Summary("Enable Two-Factor Authentication"),
+		option.Description("Generate a new TOTP secret and otpauth URI; two-factor stays inactive until confirmed"),
+		option.Response(200, responseOf(dto.TwoFactorSetupResponse{})),
+	)
+	profile.POST("/two-factor/confirm", rc.TwoFactorHandler.Confirm, authmw.RequireSession).With(
+		option.Summary("Confirm Two-Factor Authentication"),
+		option.Description("Activate two-factor authentication with a code from the authenticator app and return recovery codes"),
+		option.Request(new(dto.ConfirmTwoFactorRequest)),
+		option.Response(200, responseOf(dto.RecoveryCodesResponse{})),
+	)
+	profile.DELETE("/two-factor", rc.TwoFactorHandler.Disable, authmw.RequireSession).With(
+		option.Summary("Disable Two-Factor Authentication"),
+		option.Description("Turn off two-factor authentication after confirming the password"),
+		option.Request(new(dto.DisableTwoFactorRequest)),
+		option.Response(200, responseOf[any](nil)),
+	)
+	profile.POST("/two-factor/recovery-codes", rc.TwoFactorHandler.RegenerateRecoveryCodes, authmw.RequireSession).With(
+		option.Summary("Regenerate Recovery Codes"),
+		option.Description("Replace the remaining recovery codes with a new set"),
+		option.Request(new(dto.RegenerateRecoveryCodesRequest)),
+		option.Response(200, responseOf(dto.RecoveryCodesResponse{})),
+	)
+	profile.GET("/passkeys", rc.PasskeyHandler.ListPasskeys, authmw.RequireScope(domain.ScopeProfileRead)).With(
+		option.Summary("List Passkeys"),
+		option.Description("List the passkeys registered by the authenticated user"),
+		option.Response(200, responseOf([]dto.PasskeyResponse{})),
+	)
+	profile.POST("/passkeys/begin", rc.PasskeyHandler.BeginRegistration, recentAuth).With(
+		option.Summary("Begin Passkey Registration"),
+		option.Description("Start a passkey registration ceremony and return the options for navigator.credentials.create()"),
+		option.Response(200, responseOf(dto.PasskeyCeremonyResponse{})),
+	)
+	profile.POST("/passkeys/finish", rc.PasskeyHandler.FinishRegistration, authmw.RequireSession).With(
+		option.Summary("Finish Passkey Registration"),
+		option.Description("Verify the attestation from the authenticator and store the new passkey"),
+		option.Request(new(dto.FinishPasskeyRegistrationRequest)),
+		option.Response(201, responseOf(dto.PasskeyResponse{})),
+	)
+	profile.DELETE("/passkeys/:id", rc.PasskeyHandler.DeletePasskey, recentAuth).With(
+		option.Summary("Delete Passkey"),
+		option.Description("Remove a passkey from the authenticated user's account"),
+		option.Request(new(dto.DeletePasskeyRequest)),
+		option.Response(200, responseOf[any](nil)),
+	)
+	profile.GET("/identities", rc.OAuthHandler.ListIdentities, authmw.RequireScope(domain.ScopeProfileRead)).With(
+		option.Summary("List Linked Accounts"),
+		option.Description("List the social login accounts linked to the authenticated user"),
+		option.Response(200, responseOf([]dto.UserIdentityResponse{})),
+	)
+	profile.POST("/identities/:provider", rc.OAuthHandler.Link, recentAuth).With(
+		option.Summary("Link Account"),
+		option.Description("Start linking a social login account and return the provider URL to navigate to"),
+		option.Request(new(dto.OAuthProviderRequest)),
+		option.Response(200, responseOf(dto.OAuthRedirectResponse{})),
+	)
+	profile.DELETE("/identities/:provider", rc.OAuthHandler.Unlink, recentAuth).With(
+		option.Summary("Unlink Account"),
+		option.Description("Remove a linked social login account; the last sign-in method cannot be removed"),
+		option.Request(new(dto.OAuthProviderRequest)),
+		option.Response(200, responseOf[any](nil)),
+	)
+	profile.GET("/sessions", rc.SessionHandler.ListSessions, authmw.RequireScope(domain.ScopeProfileRead)).With(
+		option.Summary("List Active Sessions"),
+		option.Description("List the devices the authenticated user is currently signed in on"),
+		option.Response(200, responseOf([]dto.SessionResponse{})),
+	)
+	profile.DELETE("/sessions/:id", rc.SessionHandler.RevokeSession, authmw.RequireSession).With(
+		option.Summary("Revoke Session"),
+		option.Description("Sign out a single device by revoking its session"),
+		option.Request(new(dto.RevokeSessionRequest)),
+		option.Response(200, responseOf[any](nil)),
+	)
+	profile.GET("/activity", rc.AuditHandler.ListActivity, authmw.RequireScope(domain.ScopeProfileRead)).With(
+		option.Summary("List Account Activity"),
+		option.Description("List the security events of the authenticated user's account, newest first"),
+		option.Request(new(dto.ListActivityRequest)),
+		option.Response(200, responseOf(dto.AuditEventListResponse{})),
+	)
+	profile.DELETE("/impersonation", rc.ImpersonationHandler.Stop).With(
+		option.Summary("Stop Impersonating"),
+		option.Description("Revoke the impersonation token used for the request"),
+		option.Response(200, responseOf[any](nil)),
+	)
+	profile.GET("/tokens", rc.PersonalAccessTokenHandler.ListTokens, authmw.RequireSession).With(
+		option.Summary("List Personal Access Tokens"),
+		option.Description("List the personal access tokens created by the authenticated user"),
+		option.Response(200, responseOf([]dto.PersonalAccessTokenResponse{})),
+	)
+	profile.POST("/tokens", rc.PersonalAccessTokenHandler.CreateToken, recentAuth).With(
+		option.Summary("Create Personal Access Token"),
+		option.Description("Create a scoped token for scripts and integrations; the token is only returned once"),
+		option.Request(new(dto.CreatePersonalAccessTokenRequest)),
+		option.Response(201, responseOf(dto.CreatedPersonalAccessTokenResponse{})),
+	)
+	profile.DELETE("/tokens/:id", rc.PersonalAccessTokenHandler.DeleteToken, authmw.RequireSession).With(
+		option.Summary("Delete Personal Access Token"),
+		option.Description("Revoke a personal access token"),
+		option.Request(new(dto.DeletePersonalAccessTokenRequest)),
+		option.Response(200, responseOf[any](nil)),
+	)
+
+	// Admin routes are guarded per route by permission; the permission is listed
+	// as the scope of the bearerAuth requirement in the spec.
+	admin := v1.Group("/admin", rc.AuthMiddleware).With(
+		option.GroupTags("Admin"),
+	)
+	admin.GET("/roles", rc.RoleHandler.ListRoles, rc.RequirePermission(domain.PermissionRolesManage)).With(
+		option.Summary("List Roles"),
+		option.Description("List the roles and the permissions they grant"),
+		option.Response(200, responseOf([]dto.RoleResponse{})),
+		option.Security("bearerAuth", domain.PermissionRolesManage),
+	)
+	admin.POST("/users/:id/impersonate", rc.ImpersonationHandler.Start, rc.RequirePermission(domain.PermissionUsersImpersonate)).With(
+		option.Summary("Impersonate User"),
+		option.Description("Issue a short-lived access token to act as the user; it cannot be refreshed and is refused for sensitive actions"),
+		option.Request(new(dto.ImpersonateUserRequest)),
+		option.Response(201, responseOf(dto.ImpersonationResponse{})),
+		option.Security("bearerAuth", domain.PermissionUsersImpersonate),
+	)
+	admin.GET("/audit-events", rc.AuditHandler.ListEvents, rc.RequirePermission(domain.PermissionAuditRead)).With(
+		option.Summary("List Audit Events"),
+		option.Description("List security events newest first, optionally filtering by user, actor, type and date"),
+		option.Request(new(dto.ListAuditEventsRequest)),
+		option.Response(200, responseOf(dto.AuditEventListResponse{})),
+		option.Security("bearerAuth", domain.PermissionAuditRead),
+	)
+
+	users := admin.Group("/users", rc.RequirePermission(domain.PermissionUsersManage)).With(
+		option.GroupSecurity("bearerAuth", domain.PermissionUsersManage),
+	)
+	users.GET("", rc.AdminUserHandler.ListUsers).With(
+		option.Summary("List Users"),
+		option.Description("List users page by page, optionally searching by name or email and filtering by status, verification and creation date"),
+		option.Request(new(dto.ListUsersRequest)),
+		option.Response(200, responseOf(dto.AdminUserListResponse{})),
+	)
+	users.GET("/:id", rc.AdminUserHandler.GetUser).With(
+		option.Summary("Get User"),
+		option.Description("Get a single user"),
+		option.Request(new(dto.AdminUserRequest)),
+		option.Response(200, responseOf(dto.AdminUserResponse{})),
+	)
+	users.PUT("/:id", rc.AdminUserHandler.UpdateUser).With(
+		option.Summary("Update User"),
+		option.Description("Update a user's name, email and verification status"),
+		option.Request(new(dto.AdminUpdateUserRequest)),
+		option.Response(200, responseOf(dto.AdminUserResponse{})),
+	)
+	users.POST("/:id/password-reset", rc.AdminUserHandler.SendPasswordReset).With(
+		option.Summary("Send Password Reset"),
+		option.Description("Email the user a link to reset their password"),
+		option.Request(new(dto.AdminUserRequest)),
+		option.Response(200, responseOf[any](nil)),
+	)
+	users.POST("/:id/suspend", rc.AdminUserHandler.SuspendUser).With(
+		option.Summary("Suspend User"),
+		option.Description("Sign the user out everywhere and block them from signing in; the user is notified by email"),
+		option.Request(new(dto.AdminSuspendUserRequest)),
+		option.Response(200, responseOf[any](nil)),
+	)
+	users.POST("/:id/unsuspend", rc.AdminUserHandler.UnsuspendUser).With(
+		option.Summary("Unsuspend User"),
+		option.Description("Lift a suspension so the user can sign in again; the user is notified by email"),
+		option.Request(new(dto.AdminUserRequest)),
+		option.Response(200, responseOf[any](nil)),
+	)
+	users.DELETE("/:id", rc.AdminUserHandler.DeleteUser).With(
+		option.Summary("Delete User"),
+		option.Description("Permanently delete a user and sign them out everywhere"),
+		option.Request(new(dto.AdminUserRequest)),
+		option.Response(200, responseOf[any](nil)),
+	)
 }
 
 func responseOf[T any](model T) any {
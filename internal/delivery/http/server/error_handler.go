@@ -2,7 +2,9 @@ package server
 
 import (
 	"fmt"
+	"math"
 	"net/http"
+	"strconv"
 
 	"github.com/akfaiz/go-vue-starter-kit/internal/errdefs"
 	"github.com/akfaiz/go-vue-starter-kit/internal/validator"
@@ -29,6 +31,9 @@ func customHTTPErrorHandler(err error, c echo.Context) {
 	// Check if the error is a custom application error
 	var appError *errdefs.AppError
 	if errors.As(err, &appError) {
+		if retryAfter := appError.RetryAfter(); retryAfter > 0 {
+			res.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+		}
 		err := c.JSON(appError.Status, appError.WithInstance(instance))
 		if err != nil {
 			c.Logger().Error(err)
@@ -2,20 +2,23 @@ package server
 
 import (
 	"log/slog"
+	"net"
 
+	"github.com/akfaiz/go-vue-starter-kit/internal/config"
 	"github.com/akfaiz/go-vue-starter-kit/internal/delivery/http/middleware"
 	"github.com/akfaiz/go-vue-starter-kit/internal/validator"
 	"github.com/labstack/echo/v4"
 	echomiddleware "github.com/labstack/echo/v4/middleware"
 )
 
-func New() *echo.Echo {
+func New(cfg config.Config) *echo.Echo {
 	e := echo.New()
 
 	e.Validator = validator.New()
 	e.HTTPErrorHandler = customHTTPErrorHandler
 	e.HideBanner = true
 	e.HidePort = true
+	e.IPExtractor = ipExtractor(cfg.Server.TrustedProxies)
 
 	// logCfg := zap.NewProductionConfig()
 	// logger := zap.Must(logCfg.Build())
@@ -27,8 +30,28 @@ func New() *echo.Echo {
 	e.Use(middleware.Logger(slog.Default()))
 	e.Use(echomiddleware.Recover())
 	e.Use(echomiddleware.RequestID())
+	e.Use(middleware.ClientInfo())
 	e.Use(echomiddleware.CORS())
 	e.Use(middleware.I18n())
 
 	return e
 }
+
+// ipExtractor decides where c.RealIP() comes from, which rate limits, login
+// throttling, sessions and the audit log all key on. Forwarding headers are
+// only believed when the connection comes from a trusted proxy; otherwise any
+// client could pick its own IP.
+func ipExtractor(trustedProxies []*net.IPNet) echo.IPExtractor {
+	if len(trustedProxies) == 0 {
+		return echo.ExtractIPDirect()
+	}
+	options := []echo.TrustOption{
+		echo.TrustLoopback(false),
+		echo.TrustLinkLocal(false),
+		echo.TrustPrivateNet(false),
+	}
+	for _, ipRange := range trustedProxies {
+		options = append(options, echo.TrustIPRange(ipRange))
+	}
+	return echo.ExtractIPFromXFFHeader(options...)
+}
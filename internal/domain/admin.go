@@ -0,0 +1,22 @@
+//go:generate mockgen -source=admin.go -destination=../mocks/admin_mock.go -package=mocks
+package domain
+
+import "context"
+
+// AdminUserService manages other users' accounts on behalf of an administrator.
+// The actorID is the administrator performing the operation.
+type AdminUserService interface {
+	List(ctx context.Context, filter UserFilter) ([]*User, int, error)
+	FindByID(ctx context.Context, id int64) (*User, error)
+	Update(ctx context.Context, id int64, data *AdminUserUpdate) (*User, error)
+	SendPasswordReset(ctx context.Context, id int64) error
+	Suspend(ctx context.Context, actorID, id int64, reason string) error
+	Unsuspend(ctx context.Context, id int64) error
+	Delete(ctx context.Context, actorID, id int64) error
+}
+
+type AdminUserUpdate struct {
+	Name     string
+	Email    string
+	Verified bool
+}
@@ -0,0 +1,74 @@
+//go:generate mockgen -source=audit.go -destination=../mocks/audit_mock.go -package=mocks
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+type AuditEventType string
+
+const (
+	AuditRegistered             AuditEventType = "auth.registered"
+	AuditLogin                  AuditEventType = "auth.login"
+	AuditLoginFailed            AuditEventType = "auth.login_failed"
+	AuditLogout                 AuditEventType = "auth.logout"
+	AuditLogoutAll              AuditEventType = "auth.logout_all"
+	AuditPasswordConfirmed      AuditEventType = "auth.password_confirmed"
+	AuditPasswordResetRequested AuditEventType = "auth.password_reset_requested"
+	AuditPasswordReset          AuditEventType = "auth.password_reset"
+	AuditEmailChangeRequested   AuditEventType = "user.email_change_requested"
+	AuditEmailChanged           AuditEventType = "user.email_changed"
+	AuditEmailChangeReverted    AuditEventType = "user.email_change_reverted"
+	AuditPasswordChanged        AuditEventType = "user.password_changed"
+	AuditAccountDeleted         AuditEventType = "user.deleted"
+	AuditUserUpdated            AuditEventType = "admin.user_updated"
+	AuditUserDeleted            AuditEventType = "admin.user_deleted"
+	AuditUserSuspended          AuditEventType = "admin.user_suspended"
+	AuditUserUnsuspended        AuditEventType = "admin.user_unsuspended"
+	AuditImpersonationStarted   AuditEventType = "admin.impersonation_started"
+	AuditImpersonationStopped   AuditEventType = "admin.impersonation_stopped"
+)
+
+type AuditRepository interface {
+	Create(ctx context.Context, event *AuditEvent) error
+	List(ctx context.Context, filter AuditFilter) ([]*AuditEvent, error)
+	Count(ctx context.Context, filter AuditFilter) (int, error)
+}
+
+// AuditLogger records security relevant events. The actor, client IP, user
+// agent and request ID are taken from ctx. Logging never fails the operation
+// being audited; write errors are only reported to the application log.
+type AuditLogger interface {
+	// Log records an event about userID, which is zero when no user is known,
+	// such as a failed login for an unknown email.
+	Log(ctx context.Context, eventType AuditEventType, userID int64, metadata map[string]any)
+}
+
+type AuditService interface {
+	List(ctx context.Context, filter AuditFilter) ([]*AuditEvent, int, error)
+}
+
+type AuditEvent struct {
+	ID        int64
+	Type      AuditEventType
+	ActorID   *int64 // who performed the action, nil when not signed in
+	UserID    *int64 // whose account the event concerns
+	IPAddress string
+	UserAgent string
+	RequestID string
+	Metadata  map[string]any
+	CreatedAt time.Time
+}
+
+// AuditFilter narrows down the events returned by AuditRepository.List and
+// AuditRepository.Count. Zero values do not filter.
+type AuditFilter struct {
+	UserID  int64
+	ActorID int64
+	Type    AuditEventType
+	From    *time.Time
+	To      *time.Time
+	Limit   int
+	Offset  int
+}
@@ -3,42 +3,116 @@ package domain
 
 import (
 	"context"
+	"slices"
 
+	"github.com/go-jose/go-jose/v4"
 	"github.com/golang-jwt/jwt/v5"
 )
 
 type AuthService interface {
+	// Register returns no token in stealth mode, where the new user is sent a
+	// verification email and signs in afterwards, and a taken email is not an
+	// error but gets its owner an email about the attempt.
 	Register(ctx context.Context, user *User) (*PairToken, error)
-	Login(ctx context.Context, email, password string) (*PairToken, error)
+	Login(ctx context.Context, email, password string) (*LoginResult, error)
+	VerifyTwoFactor(ctx context.Context, challengeToken, code, recoveryCode string) (*PairToken, error)
 	RefreshToken(ctx context.Context, refreshToken string) (*PairToken, error)
+	Logout(ctx context.Context, userID int64, sessionID string) error
+	LogoutAll(ctx context.Context, userID int64) error
+	// ConfirmPassword re-checks the password of a signed in user and marks the
+	// session as recently authenticated, as required by sensitive actions.
+	ConfirmPassword(ctx context.Context, userID int64, sessionID, password string) error
 	SendForgotPasswordEmail(ctx context.Context, email string) error
 	ValidateResetPassword(ctx context.Context, token, email string) error
 	ResetPassword(ctx context.Context, token, email, newPassword string) error
+	// ChangeExpiredPassword replaces a password Login rejected as expired and
+	// signs the user in.
+	ChangeExpiredPassword(ctx context.Context, email, currentPassword, newPassword string) (*LoginResult, error)
 	SendVerificationEmail(ctx context.Context, email string) error
 	VerifyEmail(ctx context.Context, token string, userID int64) error
+	SendMagicLink(ctx context.Context, email string) error
+	// VerifyMagicLink signs the user in with a link from SendMagicLink. The link
+	// only proves control of the inbox, so two-factor is still required when enabled.
+	VerifyMagicLink(ctx context.Context, token, email string) (*LoginResult, error)
 }
 
 type PasswordHasher interface {
 	Hash(password string) (string, error)
+	// Verify also accepts hashes in the older formats the hasher can read.
 	Verify(password, hashed string) (bool, error)
+	// NeedsRehash reports whether hashed was made with another algorithm or
+	// other parameters than Hash uses now. It is false for an empty hash.
+	NeedsRehash(hashed string) bool
 }
 
 type JWTManager interface {
 	GeneratePairToken(claims *JWTClaims) (*PairToken, error)
+	// GenerateAccessToken keeps a jti already set on the claims, and an expiry
+	// that is earlier than the configured one.
 	GenerateAccessToken(claims *JWTClaims) (string, error)
 	GenerateRefreshToken(claims *JWTClaims) (string, error)
 	VerifyAccessToken(token string) (*JWTClaims, error)
 	VerifyRefreshToken(token string) (*JWTClaims, error)
+	// JWKS returns the public keys that verify access tokens.
+	JWKS() jose.JSONWebKeySet
 }
 
+// JWT types distinguish access from refresh tokens through the typ claim.
+// Claims of requests authenticated by a personal access token use
+// JWTTypePersonalAccess; they are never signed.
+const (
+	JWTTypeAccess         = "access"
+	JWTTypeRefresh        = "refresh"
+	JWTTypePersonalAccess = "pat"
+)
+
 type JWTClaims struct {
 	jwt.RegisteredClaims
-	ID    int64  `json:"id"`
-	Name  string `json:"name"`
-	Email string `json:"email"`
+	Type      string    `json:"typ"`
+	ID        int64     `json:"id"`
+	Name      string    `json:"name"`
+	Email     string    `json:"email"`
+	SessionID string    `json:"sid,omitempty"`
+	Scopes    []string  `json:"scopes,omitempty"`
+	Actor     *JWTActor `json:"act,omitempty"`
+}
+
+// JWTActor is the act claim (RFC 8693) of an impersonation token: the user
+// acting on behalf of the subject.
+type JWTActor struct {
+	Subject string `json:"sub"`
+	ID      int64  `json:"id"`
+	Name    string `json:"name"`
+	Email   string `json:"email"`
+}
+
+// IsImpersonated reports whether the claims were issued to someone
+// impersonating the user.
+func (c *JWTClaims) IsImpersonated() bool {
+	return c.Actor != nil
+}
+
+// HasScope reports whether the claims grant scope. Signing in grants every
+// scope; a personal access token only the scopes it was created with.
+func (c *JWTClaims) HasScope(scope string) bool {
+	if c.Type != JWTTypePersonalAccess {
+		return true
+	}
+	return slices.Contains(c.Scopes, scope)
 }
 
 type PairToken struct {
 	AccessToken  string
 	RefreshToken string
 }
+
+// LoginResult holds either the issued tokens or, when the user has two-factor
+// authentication enabled, a short-lived challenge token to exchange for them.
+type LoginResult struct {
+	Token          *PairToken
+	ChallengeToken string
+}
+
+func (r *LoginResult) TwoFactorRequired() bool {
+	return r.ChallengeToken != ""
+}
@@ -0,0 +1,41 @@
+package domain
+
+import "context"
+
+type contextKey string
+
+const claimsContextKey contextKey = "claims"
+
+// ContextWithClaims returns a copy of ctx carrying the authenticated user's claims.
+func ContextWithClaims(ctx context.Context, claims *JWTClaims) context.Context {
+	return context.WithValue(ctx, claimsContextKey, claims)
+}
+
+// ClaimsFromContext returns the authenticated user's claims, or nil for anonymous requests.
+func ClaimsFromContext(ctx context.Context) *JWTClaims {
+	claims, ok := ctx.Value(claimsContextKey).(*JWTClaims)
+	if !ok {
+		return nil
+	}
+	return claims
+}
+
+const clientInfoContextKey contextKey = "client_info"
+
+// ClientInfo describes the client that issued the current request.
+type ClientInfo struct {
+	IPAddress string
+	UserAgent string
+	RequestID string
+}
+
+// ContextWithClientInfo returns a copy of ctx carrying info about the requesting client.
+func ContextWithClientInfo(ctx context.Context, info ClientInfo) context.Context {
+	return context.WithValue(ctx, clientInfoContextKey, info)
+}
+
+// ClientInfoFromContext returns the requesting client info, or the zero value outside of a request.
+func ClientInfoFromContext(ctx context.Context) ClientInfo {
+	info, _ := ctx.Value(clientInfoContextKey).(ClientInfo)
+	return info
+}
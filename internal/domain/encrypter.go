@@ -0,0 +1,8 @@
+//go:generate mockgen -source=encrypter.go -destination=../mocks/encrypter_mock.go -package=mocks
+package domain
+
+// Encrypter provides authenticated symmetric encryption for secrets stored at rest.
+type Encrypter interface {
+	Encrypt(plaintext string) (string, error)
+	Decrypt(ciphertext string) (string, error)
+}
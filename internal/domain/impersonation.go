@@ -0,0 +1,42 @@
+//go:generate mockgen -source=impersonation.go -destination=../mocks/impersonation_mock.go -package=mocks
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+type ImpersonationRepository interface {
+	Create(ctx context.Context, impersonation *Impersonation) error
+	// End records when the impersonation using the token stopped, returning
+	// ErrResourceNotFound when it already ended.
+	End(ctx context.Context, tokenID string, endedAt time.Time) error
+}
+
+// ImpersonationService lets support staff act as another user through a
+// short-lived access token carrying an act claim. Impersonation tokens cannot
+// be refreshed and are refused for sensitive actions.
+type ImpersonationService interface {
+	Start(ctx context.Context, impersonatorID, userID int64) (*ImpersonationToken, error)
+	// Stop revokes the impersonation token the claims belong to.
+	Stop(ctx context.Context, claims *JWTClaims) error
+}
+
+type ImpersonationToken struct {
+	AccessToken string
+	ExpiresAt   time.Time
+}
+
+// Impersonation is the audit record of one impersonation token, kept after
+// either user is deleted.
+type Impersonation struct {
+	ID             int64
+	ImpersonatorID int64
+	UserID         int64
+	TokenID        string // jti of the access token
+	UserAgent      string
+	IPAddress      string
+	ExpiresAt      time.Time
+	EndedAt        *time.Time
+	CreatedAt      time.Time
+}
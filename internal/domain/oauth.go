@@ -0,0 +1,46 @@
+//go:generate mockgen -source=oauth.go -destination=../mocks/oauth_mock.go -package=mocks
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+type UserIdentityRepository interface {
+	Create(ctx context.Context, identity *UserIdentity) error
+	FindByProviderSubject(ctx context.Context, provider, subject string) (*UserIdentity, error)
+	FindByUserID(ctx context.Context, userID int64) ([]*UserIdentity, error)
+	Delete(ctx context.Context, userID int64, provider string) error
+}
+
+type OAuthService interface {
+	Providers() []string
+	// Redirect starts the authorization code flow. When linkUserID is not zero the
+	// provider account is linked to that user instead of signing in.
+	Redirect(ctx context.Context, provider string, linkUserID int64) (*OAuthRedirect, error)
+	Callback(ctx context.Context, provider, code, state, flowState string) (*OAuthCallbackResult, error)
+	ListIdentities(ctx context.Context, userID int64) ([]*UserIdentity, error)
+	Unlink(ctx context.Context, userID int64, provider string) error
+}
+
+// UserIdentity links a user to an account at a social login provider.
+type UserIdentity struct {
+	ID        int64
+	UserID    int64
+	Provider  string
+	Subject   string
+	Email     string
+	CreatedAt time.Time
+}
+
+type OAuthRedirect struct {
+	URL string
+	// FlowState is the encrypted state, nonce and PKCE verifier the client must
+	// hand back on the callback. It is kept in an HttpOnly cookie.
+	FlowState string
+}
+
+type OAuthCallbackResult struct {
+	Login  *LoginResult // set when the user signed in
+	Linked bool         // set when the identity was linked to the signed in user
+}
@@ -0,0 +1,68 @@
+//go:generate mockgen -source=passkey.go -destination=../mocks/passkey_mock.go -package=mocks
+package domain
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+type PasskeyRepository interface {
+	Create(ctx context.Context, passkey *Passkey) error
+	FindByUserID(ctx context.Context, userID int64) ([]*Passkey, error)
+	// UpdateUsage records a successful login with the passkey.
+	UpdateUsage(ctx context.Context, id int64, signCount uint32, backupState bool) error
+	// Delete removes the passkey, returning ErrResourceNotFound when the user does not own it.
+	Delete(ctx context.Context, userID, id int64) error
+}
+
+// WebAuthnSessionRepository stores in-flight WebAuthn ceremonies so the
+// challenge never has to leave the server.
+type WebAuthnSessionRepository interface {
+	Create(ctx context.Context, session *WebAuthnSession) error
+	// Consume deletes and returns the ceremony, returning ErrResourceNotFound when
+	// it does not exist or has expired. A ceremony can only be consumed once.
+	Consume(ctx context.Context, id string) (*WebAuthnSession, error)
+	DeleteExpired(ctx context.Context) error
+}
+
+type PasskeyService interface {
+	BeginRegistration(ctx context.Context, userID int64) (*PasskeyCeremony, error)
+	FinishRegistration(ctx context.Context, userID int64, ceremonyID, name string, credential json.RawMessage) (*Passkey, error)
+	List(ctx context.Context, userID int64) ([]*Passkey, error)
+	Delete(ctx context.Context, userID, id int64) error
+	BeginLogin(ctx context.Context) (*PasskeyCeremony, error)
+	FinishLogin(ctx context.Context, ceremonyID string, credential json.RawMessage) (*PairToken, error)
+}
+
+// Passkey is a WebAuthn public key credential registered by a user.
+type Passkey struct {
+	ID              int64
+	UserID          int64
+	Name            string
+	CredentialID    []byte
+	PublicKey       []byte // COSE encoded
+	AttestationType string
+	Transports      []string
+	AAGUID          []byte
+	SignCount       uint32
+	BackupEligible  bool
+	BackupState     bool
+	LastUsedAt      *time.Time
+	CreatedAt       time.Time
+}
+
+type WebAuthnSession struct {
+	ID        string
+	UserID    *int64 // nil for discoverable login ceremonies
+	Data      json.RawMessage
+	ExpiresAt time.Time
+	CreatedAt time.Time
+}
+
+// PasskeyCeremony is returned when a ceremony starts. Options are passed as-is to
+// navigator.credentials.create() or navigator.credentials.get() in the browser.
+type PasskeyCeremony struct {
+	ID      string
+	Options json.RawMessage
+}
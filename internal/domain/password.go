@@ -0,0 +1,44 @@
+//go:generate mockgen -source=password.go -destination=../mocks/password_mock.go -package=mocks
+package domain
+
+import "context"
+
+// PasswordPolicy checks new passwords against the configured rules.
+type PasswordPolicy interface {
+	// Validate returns a *validator.ValidationError for field with a message per
+	// broken rule. user is the account the password is for and may be unsaved.
+	Validate(ctx context.Context, field, password string, user *User) error
+}
+
+// PasswordRangeClient looks up breached passwords by k-anonymity: only the first
+// five hex characters of the password's SHA-1 hash are sent, and the suffixes of
+// every breached hash with that prefix come back with how often each was seen.
+type PasswordRangeClient interface {
+	Range(ctx context.Context, prefix string) (map[string]int, error)
+}
+
+// PasswordHistory stops users from cycling back to recent passwords.
+type PasswordHistory interface {
+	// Check returns a *validator.ValidationError for field when password is the
+	// user's current password or one of the remembered ones before it.
+	Check(ctx context.Context, field, password string, user *User) error
+	// Record remembers the hash a password change replaces and forgets the
+	// oldest ones beyond the configured history size.
+	Record(ctx context.Context, userID int64, replacedHash string) error
+}
+
+// PasswordChanger replaces a user's password.
+type PasswordChanger interface {
+	// Change stores newPassword once it passes the policy and was not used
+	// recently, reporting problems against field, and updates user to match.
+	Change(ctx context.Context, field string, user *User, newPassword string) error
+}
+
+// PasswordHistoryRepository stores the hashes of the passwords users had before.
+type PasswordHistoryRepository interface {
+	Create(ctx context.Context, userID int64, passwordHash string) error
+	// ListRecent returns up to limit of the user's hashes, newest first.
+	ListRecent(ctx context.Context, userID int64, limit int) ([]string, error)
+	// Prune deletes all but the newest keep hashes of the user.
+	Prune(ctx context.Context, userID int64, keep int) error
+}
@@ -0,0 +1,58 @@
+//go:generate mockgen -source=personal_access_token.go -destination=../mocks/personal_access_token_mock.go -package=mocks
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// PersonalAccessTokenPrefix starts every personal access token, which is how the
+// auth middleware tells them apart from JWTs.
+const PersonalAccessTokenPrefix = "pat_"
+
+// Scopes a personal access token can be granted. Access tokens issued by signing
+// in carry every scope.
+const (
+	ScopeProfileRead  = "profile:read"
+	ScopeProfileWrite = "profile:write"
+)
+
+var Scopes = []string{ScopeProfileRead, ScopeProfileWrite}
+
+type PersonalAccessTokenRepository interface {
+	Create(ctx context.Context, token *PersonalAccessToken) error
+	FindByPrefix(ctx context.Context, prefix string) (*PersonalAccessToken, error)
+	FindByUserID(ctx context.Context, userID int64) ([]*PersonalAccessToken, error)
+	// TouchLastUsed records that the token authenticated a request.
+	TouchLastUsed(ctx context.Context, id int64) error
+	// Delete removes the token, returning ErrResourceNotFound when the user does not own it.
+	Delete(ctx context.Context, userID, id int64) error
+}
+
+type PersonalAccessTokenService interface {
+	// Create stores a new token and returns it with its plain text value, which
+	// is not stored and cannot be shown again.
+	Create(ctx context.Context, userID int64, name string, scopes []string, expiresAt *time.Time) (*PersonalAccessToken, string, error)
+	List(ctx context.Context, userID int64) ([]*PersonalAccessToken, error)
+	Delete(ctx context.Context, userID, id int64) error
+	// Authenticate resolves a plain text token to the claims of its owner.
+	Authenticate(ctx context.Context, token string) (*JWTClaims, error)
+}
+
+// PersonalAccessToken lets scripts call the API as a user without a password.
+// Only a hash of the token is stored; Prefix is stored in clear to find it.
+type PersonalAccessToken struct {
+	ID         int64
+	UserID     int64
+	Name       string
+	Prefix     string
+	TokenHash  string
+	Scopes     []string
+	ExpiresAt  *time.Time
+	LastUsedAt *time.Time
+	CreatedAt  time.Time
+}
+
+func (t *PersonalAccessToken) IsExpired() bool {
+	return t.ExpiresAt != nil && !time.Now().Before(*t.ExpiresAt)
+}
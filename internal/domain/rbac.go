@@ -0,0 +1,56 @@
+//go:generate mockgen -source=rbac.go -destination=../mocks/rbac_mock.go -package=mocks
+package domain
+
+import (
+	"context"
+	"slices"
+)
+
+// RoleAdmin is created by the migrations and granted every permission.
+const RoleAdmin = "admin"
+
+// Permissions checked by the API. Each is created by a migration that also
+// grants it to the admin role.
+const (
+	PermissionUsersManage      = "users.manage"
+	PermissionUsersImpersonate = "users.impersonate"
+	PermissionRolesManage      = "roles.manage"
+	PermissionAuditRead        = "audit.read"
+)
+
+type RoleRepository interface {
+	FindAll(ctx context.Context) ([]*Role, error)
+	FindByName(ctx context.Context, name string) (*Role, error)
+	FindByUserID(ctx context.Context, userID int64) ([]*Role, error)
+	AssignToUser(ctx context.Context, userID, roleID int64) error
+	// RemoveFromUser returns ErrResourceNotFound when the user does not have the role.
+	RemoveFromUser(ctx context.Context, userID, roleID int64) error
+}
+
+type RBACService interface {
+	// Authorization returns the roles and permissions of the user. Results are
+	// cached briefly, so changes made through another instance apply after the
+	// cache TTL.
+	Authorization(ctx context.Context, userID int64) (*Authorization, error)
+	HasPermission(ctx context.Context, userID int64, permission string) (bool, error)
+	ListRoles(ctx context.Context) ([]*Role, error)
+	AssignRole(ctx context.Context, userID int64, roleName string) error
+	RemoveRole(ctx context.Context, userID int64, roleName string) error
+}
+
+type Role struct {
+	ID          int64
+	Name        string
+	Description string
+	Permissions []string
+}
+
+// Authorization is what a user may do through the roles assigned to them.
+type Authorization struct {
+	Roles       []string
+	Permissions []string
+}
+
+func (a *Authorization) Can(permission string) bool {
+	return slices.Contains(a.Permissions, permission)
+}
@@ -0,0 +1,56 @@
+//go:generate mockgen -source=session.go -destination=../mocks/session_mock.go -package=mocks
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+type SessionRepository interface {
+	Create(ctx context.Context, session *Session) error
+	FindByID(ctx context.Context, id string) (*Session, error)
+	FindActiveByUserID(ctx context.Context, userID int64) ([]*Session, error)
+	// Rotate swaps the stored refresh token hash only if it still equals currentHash,
+	// returning ErrResourceNotFound when the session was rotated concurrently.
+	Rotate(ctx context.Context, id, currentHash, newHash string, expiresAt time.Time) error
+	Revoke(ctx context.Context, id string) error
+	// MarkAuthenticated records that the user of an active session just proved
+	// their identity again, returning ErrResourceNotFound when it is not active.
+	MarkAuthenticated(ctx context.Context, id string, at time.Time) error
+	// RevokeByUserID revokes every active session of the user except exceptID (if not empty)
+	// and returns the IDs of the revoked sessions.
+	RevokeByUserID(ctx context.Context, userID int64, exceptID string) ([]string, error)
+}
+
+type SessionService interface {
+	Create(ctx context.Context, user *User) (*PairToken, error)
+	Refresh(ctx context.Context, refreshToken string) (*PairToken, error)
+	List(ctx context.Context, userID int64) ([]*Session, error)
+	Revoke(ctx context.Context, userID int64, sessionID string) error
+	RevokeAll(ctx context.Context, userID int64, exceptSessionID string) error
+	// Reauthenticate restarts the recent authentication window of the session.
+	Reauthenticate(ctx context.Context, userID int64, sessionID string) error
+	// AuthenticatedAt returns when the user last proved their identity in the
+	// session, returning ErrResourceNotFound for sessions that are not active.
+	AuthenticatedAt(ctx context.Context, userID int64, sessionID string) (time.Time, error)
+}
+
+// Session is a refresh token family created on login. Every refresh rotates the
+// token hash; presenting a token that is no longer current revokes the session.
+// AuthenticatedAt starts at sign-in and moves on each password confirmation.
+type Session struct {
+	ID              string
+	UserID          int64
+	TokenHash       string
+	UserAgent       string
+	IPAddress       string
+	ExpiresAt       time.Time
+	LastUsedAt      time.Time
+	AuthenticatedAt time.Time
+	RevokedAt       *time.Time
+	CreatedAt       time.Time
+}
+
+func (s *Session) IsActive() bool {
+	return s.RevokedAt == nil && time.Now().Before(s.ExpiresAt)
+}
@@ -0,0 +1,12 @@
+//go:generate mockgen -source=suspension.go -destination=../mocks/suspension_mock.go -package=mocks
+package domain
+
+import "context"
+
+// SuspensionService suspends accounts. Suspended users are signed out everywhere
+// and can neither sign in nor use their tokens until they are unsuspended.
+type SuspensionService interface {
+	Suspend(ctx context.Context, userID int64, reason string) error
+	Unsuspend(ctx context.Context, userID int64) error
+	IsSuspended(ctx context.Context, userID int64) (bool, error)
+}
@@ -0,0 +1,46 @@
+//go:generate mockgen -source=throttle.go -destination=../mocks/throttle_mock.go -package=mocks
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// ThrottleStore keeps short-lived attempt counters. Implementations must be safe
+// for concurrent use; the Postgres store shares counters across instances.
+type ThrottleStore interface {
+	// Hit increments the counter for key. When no counter is active a new one is
+	// started that expires after ttl.
+	Hit(ctx context.Context, key string, ttl time.Duration) (*ThrottleCounter, error)
+	// Get returns the active counter for key or ErrResourceNotFound.
+	Get(ctx context.Context, key string) (*ThrottleCounter, error)
+	// Block rejects key until the given time, keeping the counter alive at least that long.
+	Block(ctx context.Context, key string, until time.Time) error
+	Reset(ctx context.Context, key string) error
+	DeleteExpired(ctx context.Context) error
+}
+
+// LoginThrottler slows down password guessing. Failures are counted per email and
+// client IP with exponential backoff, and per account to lock it temporarily.
+type LoginThrottler interface {
+	// Check returns a 423 problem while the account is locked and a 429 problem
+	// while the client has to back off.
+	Check(ctx context.Context, email string) error
+	Fail(ctx context.Context, email string) error
+	Succeed(ctx context.Context, email string) error
+}
+
+type ThrottleCounter struct {
+	Key          string
+	Hits         int
+	BlockedUntil *time.Time
+	ExpiresAt    time.Time
+}
+
+// BlockedFor returns how long the key is still blocked, or zero.
+func (c *ThrottleCounter) BlockedFor(now time.Time) time.Duration {
+	if c.BlockedUntil == nil || !c.BlockedUntil.After(now) {
+		return 0
+	}
+	return c.BlockedUntil.Sub(now)
+}
@@ -0,0 +1,26 @@
+//go:generate mockgen -source=token_denylist.go -destination=../mocks/token_denylist_mock.go -package=mocks
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// RevokedTokenRepository stores denylist keys until the tokens they match expire.
+type RevokedTokenRepository interface {
+	// Create stores key until expiresAt, keeping the later expiry if it exists.
+	Create(ctx context.Context, key string, expiresAt time.Time) error
+	// FindActive returns the keys that are revoked and not yet expired.
+	FindActive(ctx context.Context, keys []string) ([]string, error)
+	DeleteExpired(ctx context.Context) error
+}
+
+// TokenDenylist rejects access tokens before they expire, either one token by
+// its jti or every token issued for a session.
+type TokenDenylist interface {
+	Revoke(ctx context.Context, jti string, expiresAt time.Time) error
+	// RevokeSessions rejects the access tokens of the sessions for as long as an
+	// access token issued now would live.
+	RevokeSessions(ctx context.Context, sessionIDs ...string) error
+	IsRevoked(ctx context.Context, claims *JWTClaims) (bool, error)
+}
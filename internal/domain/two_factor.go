@@ -0,0 +1,33 @@
+//go:generate mockgen -source=two_factor.go -destination=../mocks/two_factor_mock.go -package=mocks
+package domain
+
+import "context"
+
+type TwoFactorService interface {
+	// Enable generates a new secret for the user. Two-factor stays inactive until Confirm succeeds.
+	Enable(ctx context.Context, userID int64) (*TwoFactorSetup, error)
+	// Confirm activates two-factor authentication and returns the plain recovery codes.
+	Confirm(ctx context.Context, userID int64, code string) ([]string, error)
+	Disable(ctx context.Context, userID int64, password string) error
+	RegenerateRecoveryCodes(ctx context.Context, userID int64, password string) ([]string, error)
+	// Verify reports whether code is a valid TOTP code for the user's secret
+	// that has not been accepted before.
+	Verify(ctx context.Context, user *User, code string) (bool, error)
+	// UseRecoveryCode consumes a recovery code, reporting whether it was valid.
+	UseRecoveryCode(ctx context.Context, user *User, code string) (bool, error)
+	// CreateChallenge issues the short-lived token proving the user passed the first login factor.
+	CreateChallenge(ctx context.Context, user *User) (string, error)
+	// ParseChallenge returns the user ID of a valid challenge token, one that is
+	// unexpired, unused and has not failed too often.
+	ParseChallenge(ctx context.Context, token string) (int64, error)
+	// FailChallenge counts a wrong code against the challenge, which stops
+	// being valid after a few.
+	FailChallenge(ctx context.Context, token string) error
+	// ConsumeChallenge makes the challenge invalid once it led to a sign-in.
+	ConsumeChallenge(ctx context.Context, token string) error
+}
+
+type TwoFactorSetup struct {
+	Secret string
+	URI    string
+}
@@ -15,36 +15,119 @@ type UserRepository interface {
 	FindByID(ctx context.Context, id int64) (*User, error)
 	Update(ctx context.Context, id int64, user *UserUpdate) error
 	Delete(ctx context.Context, id int64) error
+	List(ctx context.Context, filter UserFilter) ([]*User, error)
+	Count(ctx context.Context, filter UserFilter) (int, error)
+	// UseTwoFactorStep records step as the last TOTP time step accepted from
+	// the user, unless it is not later than the one recorded, and reports
+	// whether it did. Codes are thereby accepted only once.
+	UseTwoFactorStep(ctx context.Context, id int64, step int64) (bool, error)
+	// UseTwoFactorRecoveryCode removes hashedCode from the user's recovery
+	// codes and reports whether it was there, so each is accepted only once.
+	UseTwoFactorRecoveryCode(ctx context.Context, id int64, hashedCode string) (bool, error)
 }
 
 type UserService interface {
 	FindByID(ctx context.Context, id int64) (*User, error)
+	// UpdateProfile saves the name. The email is changed through ChangeEmail.
 	UpdateProfile(ctx context.Context, id int64, user *User) error
+	// ChangeEmail sends a confirmation link to newEmail. It only takes effect
+	// once confirmed through ConfirmEmailChange; until then it is pending.
+	ChangeEmail(ctx context.Context, id int64, newEmail string) error
+	// PendingEmail returns the address awaiting confirmation, or "" if none.
+	PendingEmail(ctx context.Context, id int64) (string, error)
+	ConfirmEmailChange(ctx context.Context, id int64, token string) error
+	// RevertEmailChange restores the previous address and signs the user out
+	// everywhere, for when the change was not made by the owner.
+	RevertEmailChange(ctx context.Context, id int64, token string) error
 	ChangePassword(ctx context.Context, id int64, currentPassword, newPassword string) error
 	Delete(ctx context.Context, id int64, password string) error
 }
 
+type UserStatus string
+
+const (
+	UserStatusActive    UserStatus = "active"
+	UserStatusSuspended UserStatus = "suspended" // signed out and unable to sign in until unsuspended
+	UserStatusPending   UserStatus = "pending"   // created on someone's behalf and not activated yet
+)
+
+func (s UserStatus) IsValid() bool {
+	switch s {
+	case UserStatusActive, UserStatusSuspended, UserStatusPending:
+		return true
+	}
+	return false
+}
+
 type User struct {
-	ID              int64
-	Name            string
-	Email           string
-	Password        string
-	EmailVerifiedAt *time.Time
-	CreatedAt       time.Time
-	UpdatedAt       time.Time
+	ID                     int64
+	Name                   string
+	Email                  string
+	Password               string
+	EmailVerifiedAt        *time.Time
+	TwoFactorSecret        *string    // encrypted with the application key
+	TwoFactorRecoveryCodes []string   // SHA-256 hashes of the unused recovery codes
+	TwoFactorConfirmedAt   *time.Time // nil until the user proves the authenticator works
+	Status                 UserStatus
+	SuspendedAt            *time.Time
+	SuspensionReason       *string
+	PasswordChangedAt      time.Time
+	CreatedAt              time.Time
+	UpdatedAt              time.Time
 }
 
 type UserUpdate struct {
-	Name            omit.Val[string]
-	Email           omit.Val[string]
-	Password        omit.Val[string]
-	EmailVerifiedAt omitnull.Val[time.Time]
+	Name                   omit.Val[string]
+	Email                  omit.Val[string]
+	Password               omit.Val[string]
+	PasswordRehash         omit.Val[string] // same password, new hash; keeps PasswordChangedAt
+	EmailVerifiedAt        omitnull.Val[time.Time]
+	TwoFactorSecret        omitnull.Val[string]
+	TwoFactorRecoveryCodes omitnull.Val[[]string]
+	TwoFactorConfirmedAt   omitnull.Val[time.Time]
+	Status                 omit.Val[UserStatus]
+	SuspendedAt            omitnull.Val[time.Time]
+	SuspensionReason       omitnull.Val[string]
+}
+
+// UserFilter narrows down the users returned by UserRepository.List and
+// UserRepository.Count. Zero values do not filter.
+type UserFilter struct {
+	Search      string // matched against the name and the email
+	Status      UserStatus
+	Verified    *bool
+	CreatedFrom *time.Time
+	CreatedTo   *time.Time
+	Limit       int
+	Offset      int
 }
 
 func (uu *UserUpdate) IsEmpty() bool {
-	return uu.Name.IsUnset() && uu.Email.IsUnset() && uu.Password.IsUnset() && uu.EmailVerifiedAt.IsUnset()
+	return uu.Name.IsUnset() && uu.Email.IsUnset() && uu.Password.IsUnset() && uu.PasswordRehash.IsUnset() && uu.EmailVerifiedAt.IsUnset() &&
+		uu.TwoFactorSecret.IsUnset() && uu.TwoFactorRecoveryCodes.IsUnset() && uu.TwoFactorConfirmedAt.IsUnset() &&
+		uu.Status.IsUnset() && uu.SuspendedAt.IsUnset() && uu.SuspensionReason.IsUnset()
 }
 
 func (u *User) IsVerified() bool {
 	return u.EmailVerifiedAt != nil
 }
+
+// HasPassword reports whether the user can sign in with a password. Users created
+// through a social login have none until they reset it.
+func (u *User) HasPassword() bool {
+	return u.Password != ""
+}
+
+// PasswordExpired reports whether the password is older than maxAge. A zero
+// maxAge never expires passwords.
+func (u *User) PasswordExpired(maxAge time.Duration) bool {
+	return maxAge > 0 && u.HasPassword() && time.Since(u.PasswordChangedAt) > maxAge
+}
+
+func (u *User) IsSuspended() bool {
+	return u.Status == UserStatusSuspended
+}
+
+func (u *User) HasTwoFactorEnabled() bool {
+	return u.TwoFactorSecret != nil && u.TwoFactorConfirmedAt != nil
+}
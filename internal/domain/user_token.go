@@ -10,6 +10,9 @@ type UserTokenRepository interface {
 	Create(ctx context.Context, token *UserToken) error
 	FindOne(ctx context.Context, userID int64, tokenType TokenType) (*UserToken, error)
 	Delete(ctx context.Context, userID int64, tokenType TokenType) error
+	// Consume deletes the token with id. It returns ErrResourceNotFound when
+	// the token is already gone, so only one caller gets to use it.
+	Consume(ctx context.Context, id int64) error
 }
 
 type UserToken struct {
@@ -17,6 +20,7 @@ type UserToken struct {
 	UserID    int64
 	Token     string
 	TokenType TokenType
+	Email     string // the requested address of an email change, the previous one of a revert
 	CreatedAt time.Time
 	ExpiresAt time.Time
 }
@@ -26,4 +30,7 @@ type TokenType string
 const (
 	TokenTypeVerification  TokenType = "verification"
 	TokenTypeResetPassword TokenType = "reset_password"
+	TokenTypeMagicLink     TokenType = "magic_link"
+	TokenTypeEmailChange   TokenType = "email_change"
+	TokenTypeEmailRevert   TokenType = "email_revert"
 )
@@ -2,6 +2,7 @@ package errdefs
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/cockroachdb/errors"
 )
@@ -13,6 +14,8 @@ var (
 	ErrNotFound            = register("Resource not found", "about:blank", 404)
 	ErrConflict            = register("Resource conflict", "about:blank", 409)
 	ErrUnprocessableEntity = register("Unprocessable entity", "about:blank", 422)
+	ErrLocked              = register("Locked", "about:blank", 423)
+	ErrTooManyRequests     = register("Too Many Requests", "about:blank", 429)
 	ErrInternalServer      = register("Internal Server Error", "about:blank", 500)
 
 	ErrInvalidRequestBody = register("Invalid request body", "about:blank", 400, "Your request body is malformed. Please check your JSON format.")
@@ -21,6 +24,14 @@ var (
 
 	ErrTokenExpired = register("Unauthorized", "about:blank", 401, "Your session has expired. Please log in again.")
 	ErrTokenInvalid = register("Unauthorized", "about:blank", 401, "Your token is invalid. Please log in again.")
+
+	ErrAccountSuspended = register("Account suspended", "/problems/account-suspended", 403, "Your account has been suspended. Please contact an administrator.")
+
+	ErrPasswordConfirmationRequired = register("Password confirmation required", "/problems/password-confirmation-required", 403, "Please confirm your password to continue.")
+
+	ErrPasswordExpired = register("Password expired", "/problems/password-expired", 403, "Your password has expired. Please choose a new one.")
+
+	ErrCSRFTokenMismatch = register("CSRF token mismatch", "/problems/csrf-token-mismatch", 403, "The CSRF token is missing or invalid. Please reload the page and try again.")
 )
 
 // AppError represents a structured error response for the application.
@@ -34,7 +45,8 @@ type AppError struct {
 	Instance string `json:"instance,omitempty"`
 	Errors   any    `json:"errors,omitempty"`
 
-	cause error
+	cause      error
+	retryAfter time.Duration
 }
 
 type AppErrorFunc func(details ...string) *AppError
@@ -99,15 +111,27 @@ func (e *AppError) WithInstance(instance string) *AppError {
 	return e
 }
 
+// WithRetryAfter sets how long the client should wait before retrying; it is sent as the Retry-After header.
+func (e *AppError) WithRetryAfter(d time.Duration) *AppError {
+	e.retryAfter = d
+	return e
+}
+
+// RetryAfter returns the delay set with WithRetryAfter, or zero.
+func (e *AppError) RetryAfter() time.Duration {
+	return e.retryAfter
+}
+
 func (e *AppError) Clone() *AppError {
 	return &AppError{
-		Type:     e.Type,
-		Title:    e.Title,
-		Status:   e.Status,
-		Detail:   e.Detail,
-		Instance: e.Instance,
-		Errors:   e.Errors,
-		cause:    e.cause,
+		Type:       e.Type,
+		Title:      e.Title,
+		Status:     e.Status,
+		Detail:     e.Detail,
+		Instance:   e.Instance,
+		Errors:     e.Errors,
+		cause:      e.cause,
+		retryAfter: e.retryAfter,
 	}
 }
 
@@ -0,0 +1,82 @@
+package aesgcm
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"strings"
+
+	"github.com/akfaiz/go-vue-starter-kit/internal/config"
+	"github.com/akfaiz/go-vue-starter-kit/internal/domain"
+	"github.com/cockroachdb/errors"
+)
+
+const base64KeyPrefix = "base64:"
+
+type aesgcmEncrypter struct {
+	aead cipher.AEAD
+}
+
+// NewEncrypter returns an AES-256-GCM encrypter keyed by the application key.
+// Keys prefixed with "base64:" must decode to exactly 32 bytes; any other key is
+// stretched to 32 bytes with SHA-256.
+func NewEncrypter(cfg config.App) (domain.Encrypter, error) {
+	key, err := deriveKey(cfg.Key)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create cipher")
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create GCM")
+	}
+	return &aesgcmEncrypter{aead: aead}, nil
+}
+
+func (e *aesgcmEncrypter) Encrypt(plaintext string) (string, error) {
+	nonce := make([]byte, e.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", errors.Wrap(err, "failed to generate nonce")
+	}
+	sealed := e.aead.Seal(nonce, nonce, []byte(plaintext), nil)
+	return base64.RawURLEncoding.EncodeToString(sealed), nil
+}
+
+func (e *aesgcmEncrypter) Decrypt(ciphertext string) (string, error) {
+	sealed, err := base64.RawURLEncoding.DecodeString(ciphertext)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to decode ciphertext")
+	}
+	nonceSize := e.aead.NonceSize()
+	if len(sealed) < nonceSize {
+		return "", errors.New("ciphertext too short")
+	}
+	plaintext, err := e.aead.Open(nil, sealed[:nonceSize], sealed[nonceSize:], nil)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to decrypt ciphertext")
+	}
+	return string(plaintext), nil
+}
+
+func deriveKey(appKey string) ([]byte, error) {
+	if appKey == "" {
+		return nil, errors.New("application key cannot be empty")
+	}
+	if encoded, ok := strings.CutPrefix(appKey, base64KeyPrefix); ok {
+		key, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to decode application key")
+		}
+		if len(key) != 32 {
+			return nil, errors.New("application key must be 32 bytes")
+		}
+		return key, nil
+	}
+	sum := sha256.Sum256([]byte(appKey))
+	return sum[:], nil
+}
@@ -0,0 +1,97 @@
+package aesgcm_test
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/akfaiz/go-vue-starter-kit/internal/config"
+	"github.com/akfaiz/go-vue-starter-kit/internal/hash/aesgcm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewEncrypter(t *testing.T) {
+	t.Run("should accept a plain application key", func(t *testing.T) {
+		_, err := aesgcm.NewEncrypter(config.App{Key: "some_random_key"})
+
+		require.NoError(t, err)
+	})
+
+	t.Run("should accept a base64 encoded 32 byte key", func(t *testing.T) {
+		key := "base64:" + base64.StdEncoding.EncodeToString([]byte(strings.Repeat("k", 32)))
+
+		_, err := aesgcm.NewEncrypter(config.App{Key: key})
+
+		require.NoError(t, err)
+	})
+
+	t.Run("should reject a base64 key with the wrong length", func(t *testing.T) {
+		key := "base64:" + base64.StdEncoding.EncodeToString([]byte("short"))
+
+		_, err := aesgcm.NewEncrypter(config.App{Key: key})
+
+		require.Error(t, err)
+	})
+
+	t.Run("should reject an empty key", func(t *testing.T) {
+		_, err := aesgcm.NewEncrypter(config.App{})
+
+		require.Error(t, err)
+	})
+}
+
+func TestEncryptDecrypt(t *testing.T) {
+	encrypter, err := aesgcm.NewEncrypter(config.App{Key: "some_random_key"})
+	require.NoError(t, err)
+
+	t.Run("should round trip plaintext", func(t *testing.T) {
+		ciphertext, err := encrypter.Encrypt("JBSWY3DPEHPK3PXP")
+		require.NoError(t, err)
+
+		plaintext, err := encrypter.Decrypt(ciphertext)
+
+		require.NoError(t, err)
+		assert.Equal(t, "JBSWY3DPEHPK3PXP", plaintext)
+	})
+
+	t.Run("should produce different ciphertexts for the same plaintext", func(t *testing.T) {
+		c1, err := encrypter.Encrypt("secret")
+		require.NoError(t, err)
+		c2, err := encrypter.Encrypt("secret")
+		require.NoError(t, err)
+
+		assert.NotEqual(t, c1, c2)
+	})
+
+	t.Run("should reject tampered ciphertext", func(t *testing.T) {
+		ciphertext, err := encrypter.Encrypt("secret")
+		require.NoError(t, err)
+		raw, err := base64.RawURLEncoding.DecodeString(ciphertext)
+		require.NoError(t, err)
+		raw[len(raw)-1] ^= 0xff
+
+		_, err = encrypter.Decrypt(base64.RawURLEncoding.EncodeToString(raw))
+
+		require.Error(t, err)
+	})
+
+	t.Run("should reject ciphertext from another key", func(t *testing.T) {
+		other, err := aesgcm.NewEncrypter(config.App{Key: "another_key"})
+		require.NoError(t, err)
+		ciphertext, err := other.Encrypt("secret")
+		require.NoError(t, err)
+
+		_, err = encrypter.Decrypt(ciphertext)
+
+		require.Error(t, err)
+	})
+
+	t.Run("should reject malformed ciphertext", func(t *testing.T) {
+		_, err := encrypter.Decrypt("not base64!")
+		require.Error(t, err)
+
+		_, err = encrypter.Decrypt("AAAA")
+		require.Error(t, err)
+	})
+}
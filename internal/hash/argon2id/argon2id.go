@@ -7,7 +7,9 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/akfaiz/go-vue-starter-kit/internal/config"
 	"github.com/akfaiz/go-vue-starter-kit/internal/domain"
+	"github.com/akfaiz/go-vue-starter-kit/internal/hash/legacy"
 	"github.com/cockroachdb/errors"
 	"golang.org/x/crypto/argon2"
 )
@@ -18,17 +20,15 @@ type argon2idHasher struct {
 	parallelism uint8
 	saltLength  uint32
 	keyLength   uint32
-	format      string
 }
 
-func NewHasher() domain.PasswordHasher {
+func NewHasher(cfg config.PasswordHash) domain.PasswordHasher {
 	return &argon2idHasher{
-		memory:      64 * 1024, // 64 MB
-		iteration:   3,         // 3 iterations
-		parallelism: 1,         // 1 parallel thread
-		saltLength:  16,        // 16 bytes salt
-		keyLength:   32,        // 32 bytes key length
-		format:      "$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		memory:      cfg.Memory,
+		iteration:   cfg.Iterations,
+		parallelism: cfg.Parallelism,
+		saltLength:  cfg.SaltLength,
+		keyLength:   cfg.KeyLength,
 	}
 }
 
@@ -52,45 +52,81 @@ func (h *argon2idHasher) Hash(password string) (string, error) {
 }
 
 func (h *argon2idHasher) Verify(password, passwordHashed string) (valid bool, err error) {
+	// Accounts created through a social login have no password to match.
+	if passwordHashed == "" {
+		return false, nil
+	}
+	if !strings.HasPrefix(passwordHashed, "$argon2id$") {
+		return legacy.Verify(password, passwordHashed)
+	}
+
+	params, salt, hash, err := decodeHash(passwordHashed)
+	if err != nil {
+		return false, err
+	}
+
+	hashedPassword := argon2.IDKey([]byte(password), salt, params.iteration, params.memory, params.parallelism, params.keyLength)
+
+	if subtle.ConstantTimeCompare(hashedPassword, hash) == 1 {
+		return true, nil
+	}
+
+	return false, nil
+}
+
+func (h *argon2idHasher) NeedsRehash(passwordHashed string) bool {
+	if passwordHashed == "" {
+		return false
+	}
+	if !strings.HasPrefix(passwordHashed, "$argon2id$") {
+		return true
+	}
+	params, salt, _, err := decodeHash(passwordHashed)
+	if err != nil {
+		return true
+	}
+	return params.memory != h.memory ||
+		params.iteration != h.iteration ||
+		params.parallelism != h.parallelism ||
+		params.keyLength != h.keyLength ||
+		uint32(len(salt)) != h.saltLength
+}
+
+// decodeHash reads the parameters, salt and key back from an encoded hash, in
+// the format Hash writes.
+func decodeHash(passwordHashed string) (params *argon2idHasher, salt, hash []byte, err error) {
 	parts := strings.Split(passwordHashed, "$")
 	if len(parts) != 6 {
-		return false, errors.New("invalid password hash format")
+		return nil, nil, nil, errors.New("invalid password hash format")
 	}
 
 	var version int
 	_, err = fmt.Sscanf(parts[2], "v=%d", &version)
 	if err != nil {
-		return
+		return nil, nil, nil, err
 	}
 	if version != argon2.Version {
-		return false, errors.New("unsupported argon2 version")
+		return nil, nil, nil, errors.New("unsupported argon2 version")
 	}
 
-	var memory, iteration uint32
-	var parallelism uint8
-	_, err = fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &memory, &iteration, &parallelism)
+	params = &argon2idHasher{}
+	_, err = fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &params.memory, &params.iteration, &params.parallelism)
 	if err != nil {
-		return false, errors.Wrap(err, "failed to parse memory, iteration, or parallelism")
+		return nil, nil, nil, errors.Wrap(err, "failed to parse memory, iteration, or parallelism")
 	}
 
-	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	salt, err = base64.RawStdEncoding.DecodeString(parts[4])
 	if err != nil {
-		return false, errors.Wrap(err, "failed to decode salt")
+		return nil, nil, nil, errors.Wrap(err, "failed to decode salt")
 	}
 
-	hash, err := base64.RawStdEncoding.DecodeString(parts[5])
+	hash, err = base64.RawStdEncoding.DecodeString(parts[5])
 	if err != nil {
-		return false, errors.Wrap(err, "failed to decode hash")
+		return nil, nil, nil, errors.Wrap(err, "failed to decode hash")
 	}
-	keyLength := uint32(len(hash))
+	params.keyLength = uint32(len(hash))
 
-	hashedPassword := argon2.IDKey([]byte(password), salt, iteration, memory, parallelism, keyLength)
-
-	if subtle.ConstantTimeCompare(hashedPassword, hash) == 1 {
-		return true, nil
-	}
-
-	return false, nil
+	return params, salt, hash, nil
 }
 
 func (h *argon2idHasher) generateSalt() ([]byte, error) {
@@ -4,13 +4,25 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/akfaiz/go-vue-starter-kit/internal/config"
 	"github.com/akfaiz/go-vue-starter-kit/internal/hash/argon2id"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
 )
 
+func hashConfig() config.PasswordHash {
+	return config.PasswordHash{
+		Memory:      64 * 1024,
+		Iterations:  3,
+		Parallelism: 1,
+		SaltLength:  16,
+		KeyLength:   32,
+	}
+}
+
 func TestHash(t *testing.T) {
-	hasher := argon2id.NewHasher()
+	hasher := argon2id.NewHasher(hashConfig())
 
 	t.Run("should hash password successfully", func(t *testing.T) {
 		password := "testpassword123"
@@ -74,7 +86,7 @@ func TestHash(t *testing.T) {
 }
 
 func TestVerify(t *testing.T) {
-	hasher := argon2id.NewHasher()
+	hasher := argon2id.NewHasher(hashConfig())
 
 	t.Run("should verify correct password successfully", func(t *testing.T) {
 		password := "testpassword123"
@@ -110,6 +122,13 @@ func TestVerify(t *testing.T) {
 		assert.True(t, valid)
 	})
 
+	t.Run("should not match an account without a password", func(t *testing.T) {
+		valid, err := hasher.Verify("", "")
+
+		require.NoError(t, err)
+		assert.False(t, valid)
+	})
+
 	t.Run("should reject invalid hash format - insufficient parts", func(t *testing.T) {
 		password := "testpassword"
 		invalidHash := "$argon2id$v=19$m=65536,t=3,p=1$salt"
@@ -208,3 +227,57 @@ func TestVerify(t *testing.T) {
 		assert.True(t, valid)
 	})
 }
+
+func TestVerifyLegacy(t *testing.T) {
+	hasher := argon2id.NewHasher(hashConfig())
+	bcryptHash, err := bcrypt.GenerateFromPassword([]byte("secret"), bcrypt.MinCost)
+	require.NoError(t, err)
+
+	t.Run("should verify imported hashes and rehash them", func(t *testing.T) {
+		valid, err := hasher.Verify("secret", string(bcryptHash))
+		require.NoError(t, err)
+		assert.True(t, valid)
+
+		valid, err = hasher.Verify("wrong", string(bcryptHash))
+		require.NoError(t, err)
+		assert.False(t, valid)
+
+		assert.True(t, hasher.NeedsRehash(string(bcryptHash)))
+	})
+}
+
+func TestNeedsRehash(t *testing.T) {
+	hasher := argon2id.NewHasher(hashConfig())
+
+	t.Run("should keep a hash made with the current parameters", func(t *testing.T) {
+		hash, err := hasher.Hash("secret")
+		require.NoError(t, err)
+
+		assert.False(t, hasher.NeedsRehash(hash))
+	})
+
+	t.Run("should rehash when the parameters were strengthened", func(t *testing.T) {
+		weaker := hashConfig()
+		weaker.Memory = 32 * 1024
+		hash, err := argon2id.NewHasher(weaker).Hash("secret")
+		require.NoError(t, err)
+
+		valid, err := hasher.Verify("secret", hash)
+		require.NoError(t, err)
+		assert.True(t, valid)
+		assert.True(t, hasher.NeedsRehash(hash))
+	})
+
+	t.Run("should rehash when the key length changed", func(t *testing.T) {
+		shorter := hashConfig()
+		shorter.KeyLength = 16
+		hash, err := argon2id.NewHasher(shorter).Hash("secret")
+		require.NoError(t, err)
+
+		assert.True(t, hasher.NeedsRehash(hash))
+	})
+
+	t.Run("should not rehash an account without a password", func(t *testing.T) {
+		assert.False(t, hasher.NeedsRehash(""))
+	})
+}
@@ -0,0 +1,145 @@
+package jwtmanager_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/akfaiz/go-vue-starter-kit/internal/config"
+	"github.com/akfaiz/go-vue-starter-kit/internal/domain"
+	"github.com/akfaiz/go-vue-starter-kit/internal/hash/jwtmanager"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func claimsConfig() config.JWT {
+	return config.JWT{
+		Issuer:         "https://api.example.com",
+		Audience:       "https://api.example.com",
+		Leeway:         30 * time.Second,
+		AccessSecret:   "shared-secret",
+		RefreshSecret:  "shared-secret",
+		AccessExpires:  time.Hour,
+		RefreshExpires: time.Hour * 24,
+	}
+}
+
+func TestJWTManager_StandardClaims(t *testing.T) {
+	jwtManager, err := jwtmanager.New(claimsConfig())
+	require.NoError(t, err)
+
+	t.Run("should populate the registered claims", func(t *testing.T) {
+		before := time.Now().Add(-time.Second)
+		claims := &domain.JWTClaims{ID: 42, SessionID: "session-1"}
+		pairToken, err := jwtManager.GeneratePairToken(claims)
+		require.NoError(t, err)
+
+		access, err := jwtManager.VerifyAccessToken(pairToken.AccessToken)
+		require.NoError(t, err)
+		assert.Equal(t, domain.JWTTypeAccess, access.Type)
+		assert.Equal(t, "https://api.example.com", access.Issuer)
+		assert.Equal(t, jwt.ClaimStrings{"https://api.example.com"}, access.Audience)
+		assert.Equal(t, "42", access.Subject)
+		assert.NotEmpty(t, access.RegisteredClaims.ID)
+		assert.True(t, access.IssuedAt.After(before))
+		assert.Equal(t, access.IssuedAt, access.NotBefore)
+
+		refresh, err := jwtManager.VerifyRefreshToken(pairToken.RefreshToken)
+		require.NoError(t, err)
+		assert.Equal(t, domain.JWTTypeRefresh, refresh.Type)
+		assert.Empty(t, claims.Type, "the caller's claims should not be modified")
+	})
+
+	t.Run("should keep a preset jti and a shorter expiry", func(t *testing.T) {
+		expiresAt := time.Now().Add(10 * time.Minute).Truncate(time.Second)
+		claims := &domain.JWTClaims{
+			ID:    42,
+			Actor: &domain.JWTActor{Subject: "1", ID: 1},
+			RegisteredClaims: jwt.RegisteredClaims{
+				ID:        "impersonation-1",
+				ExpiresAt: jwt.NewNumericDate(expiresAt),
+			},
+		}
+		token, err := jwtManager.GenerateAccessToken(claims)
+		require.NoError(t, err)
+
+		access, err := jwtManager.VerifyAccessToken(token)
+		require.NoError(t, err)
+		assert.Equal(t, "impersonation-1", access.RegisteredClaims.ID)
+		assert.True(t, access.ExpiresAt.Equal(expiresAt))
+		assert.True(t, access.IsImpersonated())
+		assert.Equal(t, int64(1), access.Actor.ID)
+	})
+
+	t.Run("should not extend the expiry past the configured one", func(t *testing.T) {
+		claims := &domain.JWTClaims{ID: 42}
+		claims.ExpiresAt = jwt.NewNumericDate(time.Now().Add(48 * time.Hour))
+		token, err := jwtManager.GenerateAccessToken(claims)
+		require.NoError(t, err)
+
+		access, err := jwtManager.VerifyAccessToken(token)
+		require.NoError(t, err)
+		assert.WithinDuration(t, time.Now().Add(time.Hour), access.ExpiresAt.Time, time.Minute)
+	})
+
+	t.Run("should reject a token used for the wrong purpose", func(t *testing.T) {
+		pairToken, err := jwtManager.GeneratePairToken(&domain.JWTClaims{ID: 1})
+		require.NoError(t, err)
+
+		_, err = jwtManager.VerifyAccessToken(pairToken.RefreshToken)
+		assert.Error(t, err)
+		_, err = jwtManager.VerifyRefreshToken(pairToken.AccessToken)
+		assert.Error(t, err)
+	})
+
+	t.Run("should reject tokens from another issuer or audience", func(t *testing.T) {
+		otherIssuer := claimsConfig()
+		otherIssuer.Issuer = "https://staging.example.com"
+		otherAudience := claimsConfig()
+		otherAudience.Audience = "https://reports.example.com"
+
+		for _, cfg := range []config.JWT{otherIssuer, otherAudience} {
+			otherManager, err := jwtmanager.New(cfg)
+			require.NoError(t, err)
+			token, err := otherManager.GenerateAccessToken(&domain.JWTClaims{ID: 1})
+			require.NoError(t, err)
+
+			_, err = jwtManager.VerifyAccessToken(token)
+			assert.Error(t, err)
+		}
+	})
+
+	t.Run("should tolerate clock skew within the leeway", func(t *testing.T) {
+		skewed := claimsConfig()
+		skewed.AccessExpires = -10 * time.Second
+		skewedManager, err := jwtmanager.New(skewed)
+		require.NoError(t, err)
+		token, err := skewedManager.GenerateAccessToken(&domain.JWTClaims{ID: 1})
+		require.NoError(t, err)
+
+		_, err = jwtManager.VerifyAccessToken(token)
+		require.NoError(t, err)
+
+		strict := claimsConfig()
+		strict.Leeway = 0
+		strictManager, err := jwtmanager.New(strict)
+		require.NoError(t, err)
+		_, err = strictManager.VerifyAccessToken(token)
+		assert.Error(t, err)
+	})
+
+	t.Run("should reject tokens without an expiry", func(t *testing.T) {
+		token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, &domain.JWTClaims{
+			RegisteredClaims: jwt.RegisteredClaims{
+				Issuer:   "https://api.example.com",
+				Audience: jwt.ClaimStrings{"https://api.example.com"},
+			},
+			Type: domain.JWTTypeAccess,
+			ID:   1,
+		}).SignedString([]byte("shared-secret"))
+		require.NoError(t, err)
+
+		_, err = jwtManager.VerifyAccessToken(token)
+		assert.Error(t, err)
+	})
+}
@@ -1,29 +1,116 @@
 package jwtmanager
 
 import (
+	"context"
+	"crypto"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"sync"
 	"time"
 
 	"github.com/akfaiz/go-vue-starter-kit/internal/config"
 	"github.com/akfaiz/go-vue-starter-kit/internal/domain"
 	"github.com/akfaiz/go-vue-starter-kit/internal/errdefs"
 	"github.com/cockroachdb/errors"
+	"github.com/go-jose/go-jose/v4"
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"go.uber.org/fx"
 )
 
+// reloadInterval is how often the key directory is re-read, which is how
+// instances pick up keys rotated by another instance. It also limits how often
+// an unknown kid makes the directory be re-read in between.
+const reloadInterval = 30 * time.Second
+
 type jwtManager struct {
+	issuer         string
+	audience       string
+	leeway         time.Duration
+	algorithm      string
+	keysDir        string
 	accessSecret   []byte
 	refreshSecret  []byte
 	accessExpires  time.Duration
 	refreshExpires time.Duration
+
+	mu         sync.RWMutex
+	keys       map[string]*signingKey
+	ordered    []*signingKey // oldest first
+	lastReload time.Time
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// NewWithLifecycle is New for the application: the key directory is watched
+// while the application runs.
+func NewWithLifecycle(lc fx.Lifecycle, cfg config.JWT) (domain.JWTManager, error) {
+	m, err := New(cfg)
+	if err != nil {
+		return nil, err
+	}
+	j := m.(*jwtManager)
+	lc.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			j.startWatch()
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			j.stopWatch()
+			return nil
+		},
+	})
+	return j, nil
 }
 
-func New(cfg config.JWT) domain.JWTManager {
-	return &jwtManager{
+func New(cfg config.JWT) (domain.JWTManager, error) {
+	j := &jwtManager{
+		issuer:         cfg.Issuer,
+		audience:       cfg.Audience,
+		leeway:         cfg.Leeway,
+		algorithm:      cfg.Algorithm,
 		accessSecret:   []byte(cfg.AccessSecret),
 		refreshSecret:  []byte(cfg.RefreshSecret),
 		accessExpires:  cfg.AccessExpires,
 		refreshExpires: cfg.RefreshExpires,
 	}
+	if len(j.refreshSecret) == 0 {
+		return nil, fmt.Errorf("JWT_REFRESH_SECRET is required")
+	}
+
+	switch cfg.Algorithm {
+	case "", "HS256":
+		j.algorithm = "HS256"
+		if len(j.accessSecret) == 0 {
+			return nil, fmt.Errorf("JWT_ACCESS_SECRET is required for HS256")
+		}
+		return j, nil
+	case "RS256", "ES256", "EdDSA":
+	default:
+		return nil, fmt.Errorf("unsupported JWT_ALGORITHM %q, use HS256, RS256, ES256 or EdDSA", cfg.Algorithm)
+	}
+
+	if cfg.PrivateKey != "" {
+		key, err := parseKey("", []byte(cfg.PrivateKey))
+		if err != nil {
+			return nil, fmt.Errorf("JWT_PRIVATE_KEY: %w", err)
+		}
+		j.setKeys([]*signingKey{key})
+	} else {
+		j.keysDir = cfg.KeysDir
+		keys, err := loadDir(cfg.KeysDir)
+		if err != nil || len(keys) == 0 {
+			return nil, fmt.Errorf("no JWT signing keys in %q, create one with `key rotate`: %w", cfg.KeysDir, err)
+		}
+		j.setKeys(keys)
+	}
+	if active := j.activeKey(time.Now()); active.method.Alg() != j.algorithm {
+		return nil, fmt.Errorf("signing JWT key %q is %s but JWT_ALGORITHM is %s", active.kid, active.method.Alg(), j.algorithm)
+	}
+	return j, nil
 }
 
 func (j *jwtManager) GeneratePairToken(claims *domain.JWTClaims) (*domain.PairToken, error) {
@@ -47,38 +134,114 @@ func (j *jwtManager) GenerateAccessToken(claims *domain.JWTClaims) (string, erro
 	if claims == nil {
 		return "", errors.WithStack(errdefs.ErrInternalServer("claims cannot be nil"))
 	}
-	return j.generateToken(claims, j.accessSecret, j.accessExpires)
+	if j.algorithm == "HS256" {
+		return j.generateToken(claims, domain.JWTTypeAccess, jwt.SigningMethodHS256, "", j.accessSecret, j.accessExpires)
+	}
+
+	key := j.activeKey(time.Now())
+	return j.generateToken(claims, domain.JWTTypeAccess, key.method, key.kid, key.signer, j.accessExpires)
 }
 
 func (j *jwtManager) GenerateRefreshToken(claims *domain.JWTClaims) (string, error) {
 	if claims == nil {
 		return "", errors.WithStack(errdefs.ErrInternalServer("claims cannot be nil"))
 	}
-	return j.generateToken(claims, j.refreshSecret, j.refreshExpires)
+	return j.generateToken(claims, domain.JWTTypeRefresh, jwt.SigningMethodHS256, "", j.refreshSecret, j.refreshExpires)
 }
 
 func (j *jwtManager) VerifyAccessToken(token string) (*domain.JWTClaims, error) {
-	return j.verifyToken(token, j.accessSecret)
+	if j.algorithm == "HS256" {
+		return j.verifyToken(token, domain.JWTTypeAccess, "HS256", func(*jwt.Token) (any, error) {
+			return j.accessSecret, nil
+		})
+	}
+	return j.verifyToken(token, domain.JWTTypeAccess, j.algorithm, func(t *jwt.Token) (any, error) {
+		kid, _ := t.Header["kid"].(string)
+		key := j.key(kid)
+		if key == nil {
+			return nil, fmt.Errorf("unknown signing key %q", kid)
+		}
+		// Keys may use another algorithm than the current one after a change of
+		// JWT_ALGORITHM, so the token must match the algorithm of its own key.
+		if t.Method.Alg() != key.method.Alg() {
+			return nil, fmt.Errorf("token algorithm %s does not match key %q", t.Method.Alg(), kid)
+		}
+		return key.signer.Public(), nil
+	})
 }
 
 func (j *jwtManager) VerifyRefreshToken(token string) (*domain.JWTClaims, error) {
-	return j.verifyToken(token, j.refreshSecret)
+	return j.verifyToken(token, domain.JWTTypeRefresh, "HS256", func(*jwt.Token) (any, error) {
+		return j.refreshSecret, nil
+	})
 }
 
-func (j *jwtManager) generateToken(claims *domain.JWTClaims, secret []byte, expiresIn time.Duration) (string, error) {
-	claims.ExpiresAt = jwt.NewNumericDate(time.Now().Add(expiresIn))
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	signedToken, err := token.SignedString(secret)
+// JWKS returns the public keys that verify access tokens. It is empty for HS256.
+func (j *jwtManager) JWKS() jose.JSONWebKeySet {
+	j.mu.RLock()
+	defer j.mu.RUnlock()
+	set := jose.JSONWebKeySet{Keys: []jose.JSONWebKey{}}
+	for _, kid := range slices.Sorted(maps.Keys(j.keys)) {
+		key := j.keys[kid]
+		set.Keys = append(set.Keys, *publicJWK(kid, key.method.Alg(), key.signer.Public()))
+	}
+	return set
+}
+
+// generateToken signs a copy of claims completed with the registered claims, so
+// the caller's claims can be reused for both tokens of a pair. A jti or an
+// earlier expiry set by the caller is kept, for tokens that are tracked
+// individually.
+func (j *jwtManager) generateToken(claims *domain.JWTClaims, typ string, method jwt.SigningMethod, kid string, key any, expiresIn time.Duration) (string, error) {
+	now := time.Now()
+	c := *claims
+	c.Type = typ
+	if c.RegisteredClaims.ID == "" {
+		c.RegisteredClaims.ID = uuid.NewString()
+	}
+	c.Issuer = j.issuer
+	if j.audience != "" {
+		c.Audience = jwt.ClaimStrings{j.audience}
+	}
+	if c.Subject == "" && c.ID != 0 {
+		c.Subject = strconv.FormatInt(c.ID, 10)
+	}
+	c.IssuedAt = jwt.NewNumericDate(now)
+	c.NotBefore = jwt.NewNumericDate(now)
+	expiresAt := now.Add(expiresIn)
+	if c.ExpiresAt != nil && c.ExpiresAt.Before(expiresAt) {
+		expiresAt = c.ExpiresAt.Time
+	}
+	c.ExpiresAt = jwt.NewNumericDate(expiresAt)
+	token := jwt.NewWithClaims(method, &c)
+	if kid != "" {
+		token.Header["kid"] = kid
+	}
+	signedToken, err := token.SignedString(key)
 	if err != nil {
 		return "", errors.WithStack(errdefs.ErrInternalServer().WithCause(err))
 	}
 	return signedToken, nil
 }
 
-func (j *jwtManager) verifyToken(token string, secret []byte) (*domain.JWTClaims, error) {
-	parsedToken, err := jwt.ParseWithClaims(token, &domain.JWTClaims{}, func(t *jwt.Token) (any, error) {
-		return secret, nil
-	})
+func (j *jwtManager) verifyToken(token, typ, alg string, keyFunc jwt.Keyfunc) (*domain.JWTClaims, error) {
+	validMethods := []string{alg}
+	if alg != "HS256" {
+		validMethods = []string{"RS256", "ES256", "EdDSA"}
+	}
+	opts := []jwt.ParserOption{
+		jwt.WithValidMethods(validMethods),
+		jwt.WithLeeway(j.leeway),
+		jwt.WithIssuedAt(),
+		jwt.WithExpirationRequired(),
+	}
+	if j.issuer != "" {
+		opts = append(opts, jwt.WithIssuer(j.issuer))
+	}
+	if j.audience != "" {
+		opts = append(opts, jwt.WithAudience(j.audience))
+	}
+	parsedToken, err := jwt.ParseWithClaims(token, &domain.JWTClaims{}, keyFunc, opts...)
 	if err != nil {
 		if errors.Is(err, jwt.ErrTokenMalformed) || errors.Is(err, jwt.ErrTokenSignatureInvalid) {
 			return nil, errors.WithStack(errdefs.ErrUnauthorized().WithCause(err))
@@ -89,7 +252,107 @@ func (j *jwtManager) verifyToken(token string, secret []byte) (*domain.JWTClaims
 		return nil, errors.WithStack(errdefs.ErrUnauthorized().WithCause(err))
 	}
 	if claims, ok := parsedToken.Claims.(*domain.JWTClaims); ok && parsedToken.Valid {
+		if claims.Type != typ {
+			return nil, errors.WithStack(errdefs.ErrUnauthorized("invalid token type"))
+		}
 		return claims, nil
 	}
 	return nil, errors.WithStack(errdefs.ErrUnauthorized("invalid token claims"))
 }
+
+// key looks up a verification key, re-reading the key directory when the kid
+// is unknown in case another instance rotated keys since the last reload.
+func (j *jwtManager) key(kid string) *signingKey {
+	j.mu.RLock()
+	key, ok := j.keys[kid]
+	canReload := j.keysDir != "" && time.Since(j.lastReload) > reloadInterval
+	j.mu.RUnlock()
+	if ok || !canReload {
+		return key
+	}
+
+	j.reload()
+
+	j.mu.RLock()
+	defer j.mu.RUnlock()
+	return j.keys[kid]
+}
+
+// startWatch re-reads the key directory every reloadInterval until stopWatch
+// is called. Keys given inline are never reloaded.
+func (j *jwtManager) startWatch() {
+	if j.keysDir == "" || j.stop != nil {
+		return
+	}
+	j.stop = make(chan struct{})
+	j.done = make(chan struct{})
+	go j.watch(j.stop, j.done)
+}
+
+// stopWatch stops the watcher and waits for it to return.
+func (j *jwtManager) stopWatch() {
+	if j.stop == nil {
+		return
+	}
+	close(j.stop)
+	<-j.done
+	j.stop, j.done = nil, nil
+}
+
+func (j *jwtManager) watch(stop <-chan struct{}, done chan<- struct{}) {
+	defer close(done)
+	ticker := time.NewTicker(reloadInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			j.reload()
+		}
+	}
+}
+
+// reload replaces the key set with the keys on disk. The current set is kept
+// when the directory cannot be read, so a bad rotation does not stop signing.
+func (j *jwtManager) reload() {
+	keys, err := loadDir(j.keysDir)
+	j.mu.Lock()
+	j.lastReload = time.Now()
+	j.mu.Unlock()
+	if err != nil || len(keys) == 0 {
+		return
+	}
+	j.setKeys(keys)
+}
+
+// setKeys replaces the key set, ordered oldest first.
+func (j *jwtManager) setKeys(keys []*signingKey) {
+	set := make(map[string]*signingKey, len(keys))
+	for _, key := range keys {
+		set[key.kid] = key
+	}
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.keys = set
+	j.ordered = keys
+}
+
+// activeKey returns the key that signs new tokens: the newest one that has been
+// on disk for at least reloadInterval, so every instance has loaded it and can
+// verify its tokens. A newer key only verifies until then.
+func (j *jwtManager) activeKey(now time.Time) *signingKey {
+	j.mu.RLock()
+	defer j.mu.RUnlock()
+	for i := len(j.ordered) - 1; i >= 0; i-- {
+		if isActive(j.ordered[i].kid, now) {
+			return j.ordered[i]
+		}
+	}
+	// Only keys too new to be active, such as the very first one.
+	return j.ordered[len(j.ordered)-1]
+}
+
+func publicJWK(kid, alg string, pub crypto.PublicKey) *jose.JSONWebKey {
+	return &jose.JSONWebKey{Key: pub, KeyID: kid, Algorithm: alg, Use: "sig"}
+}
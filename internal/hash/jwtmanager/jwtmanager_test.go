@@ -19,7 +19,8 @@ func TestJWTManager_GeneratePairToken(t *testing.T) {
 		AccessExpires:  time.Hour,
 		RefreshExpires: time.Hour * 24,
 	}
-	jwtManager := jwtmanager.New(cfg)
+	jwtManager, err := jwtmanager.New(cfg)
+	require.NoError(t, err)
 
 	t.Run("should generate pair token successfully", func(t *testing.T) {
 		claims := &domain.JWTClaims{
@@ -64,6 +65,26 @@ func TestJWTManager_GeneratePairToken(t *testing.T) {
 		require.NoError(t, err)
 		assert.Equal(t, claims.Subject, refreshClaims.Subject)
 	})
+
+	t.Run("should embed session id and a unique jti", func(t *testing.T) {
+		claims := &domain.JWTClaims{
+			ID:        1,
+			SessionID: "session-1",
+		}
+
+		pairToken, err := jwtManager.GeneratePairToken(claims)
+		require.NoError(t, err)
+
+		accessClaims, err := jwtManager.VerifyAccessToken(pairToken.AccessToken)
+		require.NoError(t, err)
+		refreshClaims, err := jwtManager.VerifyRefreshToken(pairToken.RefreshToken)
+		require.NoError(t, err)
+
+		assert.Equal(t, "session-1", accessClaims.SessionID)
+		assert.Equal(t, "session-1", refreshClaims.SessionID)
+		assert.NotEmpty(t, accessClaims.RegisteredClaims.ID)
+		assert.NotEqual(t, accessClaims.RegisteredClaims.ID, refreshClaims.RegisteredClaims.ID)
+	})
 }
 
 func TestJWTManager_VerifyAccessToken(t *testing.T) {
@@ -73,7 +94,8 @@ func TestJWTManager_VerifyAccessToken(t *testing.T) {
 		AccessExpires:  time.Hour,
 		RefreshExpires: time.Hour * 24,
 	}
-	jwtManager := jwtmanager.New(cfg)
+	jwtManager, err := jwtmanager.New(cfg)
+	require.NoError(t, err)
 
 	t.Run("should verify valid access token successfully", func(t *testing.T) {
 		claims := &domain.JWTClaims{
@@ -116,7 +138,8 @@ func TestJWTManager_VerifyAccessToken(t *testing.T) {
 			AccessExpires:  time.Hour,
 			RefreshExpires: time.Hour * 24,
 		}
-		wrongManager := jwtmanager.New(wrongCfg)
+		wrongManager, err := jwtmanager.New(wrongCfg)
+		require.NoError(t, err)
 
 		claims := &domain.JWTClaims{
 			RegisteredClaims: jwt.RegisteredClaims{
@@ -140,7 +163,8 @@ func TestJWTManager_VerifyAccessToken(t *testing.T) {
 			AccessExpires:  -time.Hour, // Expired token
 			RefreshExpires: time.Hour * 24,
 		}
-		expiredManager := jwtmanager.New(expiredCfg)
+		expiredManager, err := jwtmanager.New(expiredCfg)
+		require.NoError(t, err)
 
 		claims := &domain.JWTClaims{
 			RegisteredClaims: jwt.RegisteredClaims{
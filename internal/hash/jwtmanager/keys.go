@@ -0,0 +1,195 @@
+package jwtmanager
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const kidTimeFormat = "20060102T150405Z"
+
+// signingKey is an asymmetric key pair identified by its kid. Keys created by
+// Rotate have kids starting with their creation time, so sorting kids orders
+// keys from oldest to newest.
+type signingKey struct {
+	kid    string
+	method jwt.SigningMethod
+	signer crypto.Signer
+}
+
+// GenerateKey creates a private key for RS256, ES256 or EdDSA.
+func GenerateKey(alg string) (crypto.Signer, error) {
+	switch alg {
+	case "RS256":
+		return rsa.GenerateKey(rand.Reader, 2048)
+	case "ES256":
+		return ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	case "EdDSA":
+		_, key, err := ed25519.GenerateKey(rand.Reader)
+		return key, err
+	default:
+		return nil, fmt.Errorf("unsupported signing algorithm %q, use RS256, ES256 or EdDSA", alg)
+	}
+}
+
+// Rotate writes a new key to dir and removes keys whose tokens have all expired.
+// retireAfter is the lifetime of a token. A replaced key still signs until its
+// replacement becomes active and every instance has reloaded the directory,
+// which takes up to twice reloadInterval, so it is removed that much later.
+func Rotate(dir, alg string, retireAfter time.Duration, now time.Time) (kid string, removed []string, err error) {
+	key, err := GenerateKey(alg)
+	if err != nil {
+		return "", nil, err
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return "", nil, err
+	}
+	suffix := make([]byte, 4)
+	if _, err := rand.Read(suffix); err != nil {
+		return "", nil, err
+	}
+	kid = now.UTC().Format(kidTimeFormat) + "-" + hex.EncodeToString(suffix)
+
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return "", nil, err
+	}
+	block := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+	if err := os.WriteFile(filepath.Join(dir, kid+".pem"), block, 0o600); err != nil {
+		return "", nil, err
+	}
+
+	kids, err := listKids(dir)
+	if err != nil {
+		return "", nil, err
+	}
+	for i, old := range kids[:len(kids)-1] {
+		replacedAt, ok := kidTime(kids[i+1])
+		if !ok || now.Sub(replacedAt) < retireAfter+2*reloadInterval {
+			continue
+		}
+		if err := os.Remove(filepath.Join(dir, old+".pem")); err != nil {
+			return "", nil, err
+		}
+		removed = append(removed, old)
+	}
+	return kid, removed, nil
+}
+
+// loadDir reads every <kid>.pem private key in dir, oldest first.
+func loadDir(dir string) ([]*signingKey, error) {
+	kids, err := listKids(dir)
+	if err != nil {
+		return nil, err
+	}
+	keys := make([]*signingKey, 0, len(kids))
+	for _, kid := range kids {
+		data, err := os.ReadFile(filepath.Join(dir, kid+".pem"))
+		if err != nil {
+			return nil, err
+		}
+		key, err := parseKey(kid, data)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", kid, err)
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// parseKey decodes a PKCS#8, PKCS#1 or SEC 1 PEM private key. An empty kid is
+// replaced by the RFC 7638 thumbprint of the public key.
+func parseKey(kid string, data []byte) (*signingKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM block found")
+	}
+	var (
+		parsed any
+		err    error
+	)
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		parsed, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		parsed, err = x509.ParseECPrivateKey(block.Bytes)
+	default:
+		parsed, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	key := &signingKey{kid: kid}
+	switch k := parsed.(type) {
+	case *rsa.PrivateKey:
+		key.method, key.signer = jwt.SigningMethodRS256, k
+	case *ecdsa.PrivateKey:
+		if k.Curve != elliptic.P256() {
+			return nil, fmt.Errorf("only P-256 EC keys are supported")
+		}
+		key.method, key.signer = jwt.SigningMethodES256, k
+	case ed25519.PrivateKey:
+		key.method, key.signer = jwt.SigningMethodEdDSA, k
+	default:
+		return nil, fmt.Errorf("unsupported private key type %T", parsed)
+	}
+	if key.kid == "" {
+		key.kid, err = thumbprint(key.signer.Public())
+		if err != nil {
+			return nil, err
+		}
+	}
+	return key, nil
+}
+
+func thumbprint(pub crypto.PublicKey) (string, error) {
+	jwk, err := publicJWK("", "", pub).Thumbprint(crypto.SHA256)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(jwk), nil
+}
+
+func listKids(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var kids []string
+	for _, entry := range entries {
+		if name := entry.Name(); !entry.IsDir() && strings.HasSuffix(name, ".pem") {
+			kids = append(kids, strings.TrimSuffix(name, ".pem"))
+		}
+	}
+	slices.Sort(kids)
+	return kids, nil
+}
+
+// isActive reports whether the key may sign at now. Keys without a creation
+// time in their kid, such as JWT_PRIVATE_KEY, are always active.
+func isActive(kid string, now time.Time) bool {
+	created, ok := kidTime(kid)
+	return !ok || now.Sub(created) >= reloadInterval
+}
+
+func kidTime(kid string) (time.Time, bool) {
+	prefix, _, _ := strings.Cut(kid, "-")
+	t, err := time.Parse(kidTimeFormat, prefix)
+	return t, err == nil
+}
@@ -0,0 +1,245 @@
+package jwtmanager_test
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/akfaiz/go-vue-starter-kit/internal/config"
+	"github.com/akfaiz/go-vue-starter-kit/internal/domain"
+	"github.com/akfaiz/go-vue-starter-kit/internal/hash/jwtmanager"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/fx/fxtest"
+)
+
+func asymmetricConfig(alg, dir string) config.JWT {
+	return config.JWT{
+		Algorithm:      alg,
+		KeysDir:        dir,
+		RefreshSecret:  "refresh-secret",
+		AccessExpires:  time.Hour,
+		RefreshExpires: time.Hour * 24,
+	}
+}
+
+func tokenKid(t *testing.T, token string) string {
+	t.Helper()
+	parsed, _, err := jwt.NewParser().ParseUnverified(token, &domain.JWTClaims{})
+	require.NoError(t, err)
+	kid, _ := parsed.Header["kid"].(string)
+	return kid
+}
+
+func TestJWTManager_AsymmetricAlgorithms(t *testing.T) {
+	for _, alg := range []string{"RS256", "ES256", "EdDSA"} {
+		t.Run(alg, func(t *testing.T) {
+			dir := t.TempDir()
+			kid, _, err := jwtmanager.Rotate(dir, alg, time.Hour, time.Now())
+			require.NoError(t, err)
+
+			jwtManager, err := jwtmanager.New(asymmetricConfig(alg, dir))
+			require.NoError(t, err)
+
+			pairToken, err := jwtManager.GeneratePairToken(&domain.JWTClaims{ID: 1})
+			require.NoError(t, err)
+			assert.Equal(t, kid, tokenKid(t, pairToken.AccessToken))
+
+			claims, err := jwtManager.VerifyAccessToken(pairToken.AccessToken)
+			require.NoError(t, err)
+			assert.Equal(t, int64(1), claims.ID)
+
+			_, err = jwtManager.VerifyRefreshToken(pairToken.RefreshToken)
+			require.NoError(t, err)
+
+			jwks := jwtManager.JWKS()
+			require.Len(t, jwks.Keys, 1)
+			assert.Equal(t, kid, jwks.Keys[0].KeyID)
+			assert.Equal(t, alg, jwks.Keys[0].Algorithm)
+			assert.Equal(t, "sig", jwks.Keys[0].Use)
+			assert.True(t, jwks.Keys[0].IsPublic())
+		})
+	}
+}
+
+func TestJWTManager_Rotate(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Now()
+	oldKid, _, err := jwtmanager.Rotate(dir, "ES256", time.Hour, now.Add(-2*time.Hour))
+	require.NoError(t, err)
+
+	oldManager, err := jwtmanager.New(asymmetricConfig("ES256", dir))
+	require.NoError(t, err)
+	oldToken, err := oldManager.GenerateAccessToken(&domain.JWTClaims{ID: 1})
+	require.NoError(t, err)
+
+	t.Run("should keep the previous key valid until its tokens expire", func(t *testing.T) {
+		newKid, removed, err := jwtmanager.Rotate(dir, "ES256", time.Hour, now.Add(-30*time.Minute))
+		require.NoError(t, err)
+		assert.Empty(t, removed)
+
+		jwtManager, err := jwtmanager.New(asymmetricConfig("ES256", dir))
+		require.NoError(t, err)
+
+		newToken, err := jwtManager.GenerateAccessToken(&domain.JWTClaims{ID: 2})
+		require.NoError(t, err)
+		assert.Equal(t, newKid, tokenKid(t, newToken))
+
+		_, err = jwtManager.VerifyAccessToken(oldToken)
+		require.NoError(t, err)
+		assert.Len(t, jwtManager.JWKS().Keys, 2)
+	})
+
+	t.Run("should remove keys replaced longer ago than the token lifetime", func(t *testing.T) {
+		_, removed, err := jwtmanager.Rotate(dir, "ES256", time.Hour, now.Add(45*time.Minute))
+		require.NoError(t, err)
+		assert.Equal(t, []string{oldKid}, removed)
+
+		jwtManager, err := jwtmanager.New(asymmetricConfig("ES256", dir))
+		require.NoError(t, err)
+
+		_, err = jwtManager.VerifyAccessToken(oldToken)
+		assert.Error(t, err)
+		assert.Len(t, jwtManager.JWKS().Keys, 2)
+	})
+}
+
+func TestJWTManager_RotateActivation(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Now()
+	oldKid, _, err := jwtmanager.Rotate(dir, "ES256", time.Hour, now.Add(-2*time.Hour))
+	require.NoError(t, err)
+	_, _, err = jwtmanager.Rotate(dir, "ES256", time.Hour, now)
+	require.NoError(t, err)
+
+	jwtManager, err := jwtmanager.New(asymmetricConfig("ES256", dir))
+	require.NoError(t, err)
+
+	t.Run("should keep signing with the previous key until every instance has the new one", func(t *testing.T) {
+		token, err := jwtManager.GenerateAccessToken(&domain.JWTClaims{ID: 1})
+		require.NoError(t, err)
+		assert.Equal(t, oldKid, tokenKid(t, token))
+		assert.Len(t, jwtManager.JWKS().Keys, 2)
+	})
+
+	t.Run("should not retire the previous key before instances stopped signing with it", func(t *testing.T) {
+		_, removed, err := jwtmanager.Rotate(dir, "ES256", time.Hour, now.Add(time.Hour+30*time.Second))
+		require.NoError(t, err)
+		assert.Empty(t, removed)
+	})
+}
+
+func TestJWTManager_PrivateKeyFromEnv(t *testing.T) {
+	dir := t.TempDir()
+	_, _, err := jwtmanager.Rotate(dir, "RS256", time.Hour, time.Now())
+	require.NoError(t, err)
+	files, err := filepath.Glob(filepath.Join(dir, "*.pem"))
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	data, err := os.ReadFile(files[0])
+	require.NoError(t, err)
+
+	cfg := asymmetricConfig("RS256", "")
+	cfg.PrivateKey = string(data)
+	jwtManager, err := jwtmanager.New(cfg)
+	require.NoError(t, err)
+
+	token, err := jwtManager.GenerateAccessToken(&domain.JWTClaims{ID: 1})
+	require.NoError(t, err)
+	kid := tokenKid(t, token)
+	assert.NotEmpty(t, kid)
+	assert.NotContains(t, files[0], kid, "kid should be the key thumbprint, not the file name")
+
+	_, err = jwtManager.VerifyAccessToken(token)
+	require.NoError(t, err)
+}
+
+func TestJWTManager_AsymmetricRejections(t *testing.T) {
+	dir := t.TempDir()
+	_, _, err := jwtmanager.Rotate(dir, "RS256", time.Hour, time.Now())
+	require.NoError(t, err)
+	jwtManager, err := jwtmanager.New(asymmetricConfig("RS256", dir))
+	require.NoError(t, err)
+
+	t.Run("should reject HS256 tokens", func(t *testing.T) {
+		hsManager, err := jwtmanager.New(config.JWT{
+			AccessSecret:   "access-secret",
+			RefreshSecret:  "refresh-secret",
+			AccessExpires:  time.Hour,
+			RefreshExpires: time.Hour,
+		})
+		require.NoError(t, err)
+		token, err := hsManager.GenerateAccessToken(&domain.JWTClaims{ID: 1})
+		require.NoError(t, err)
+
+		_, err = jwtManager.VerifyAccessToken(token)
+		assert.Error(t, err)
+	})
+
+	t.Run("should reject tokens signed by an unknown key", func(t *testing.T) {
+		otherDir := t.TempDir()
+		_, _, err := jwtmanager.Rotate(otherDir, "RS256", time.Hour, time.Now())
+		require.NoError(t, err)
+		otherManager, err := jwtmanager.New(asymmetricConfig("RS256", otherDir))
+		require.NoError(t, err)
+		token, err := otherManager.GenerateAccessToken(&domain.JWTClaims{ID: 1})
+		require.NoError(t, err)
+
+		_, err = jwtManager.VerifyAccessToken(token)
+		assert.Error(t, err)
+	})
+
+	t.Run("should require a key to start", func(t *testing.T) {
+		_, err := jwtmanager.New(asymmetricConfig("ES256", t.TempDir()))
+		assert.ErrorContains(t, err, "key rotate")
+	})
+
+	t.Run("should reject an unparsable private key", func(t *testing.T) {
+		cfg := asymmetricConfig("ES256", "")
+		cfg.PrivateKey = string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: []byte("junk")}))
+		_, err := jwtmanager.New(cfg)
+		assert.Error(t, err)
+	})
+
+	t.Run("should write private keys readable only by the owner", func(t *testing.T) {
+		files, err := filepath.Glob(filepath.Join(dir, "*.pem"))
+		require.NoError(t, err)
+		info, err := os.Stat(files[0])
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+		data, err := os.ReadFile(files[0])
+		require.NoError(t, err)
+		block, _ := pem.Decode(data)
+		_, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+		require.NoError(t, err)
+		assert.True(t, strings.HasSuffix(files[0], ".pem"))
+	})
+}
+
+func TestJWTManager_WatchLifecycle(t *testing.T) {
+	dir := t.TempDir()
+	_, _, err := jwtmanager.Rotate(dir, "ES256", time.Hour, time.Now())
+	require.NoError(t, err)
+
+	lc := fxtest.NewLifecycle(t)
+	before := runtime.NumGoroutine()
+	_, err = jwtmanager.NewWithLifecycle(lc, asymmetricConfig("ES256", dir))
+	require.NoError(t, err)
+	assert.Equal(t, before, runtime.NumGoroutine(), "should not watch before the application starts")
+
+	lc.RequireStart()
+	assert.Greater(t, runtime.NumGoroutine(), before)
+
+	lc.RequireStop()
+	for i := 0; i < 100 && runtime.NumGoroutine() > before; i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+	assert.Equal(t, before, runtime.NumGoroutine(), "should stop watching when the application stops")
+}
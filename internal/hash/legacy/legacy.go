@@ -0,0 +1,193 @@
+// Package legacy verifies passwords of users imported from systems that hashed
+// with bcrypt, scrypt or PBKDF2, so they can sign in and be rehashed.
+package legacy
+
+import (
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/subtle"
+	"encoding/base64"
+	"hash"
+	"strconv"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+	"golang.org/x/crypto/bcrypt"
+	"golang.org/x/crypto/pbkdf2"
+	"golang.org/x/crypto/scrypt"
+)
+
+// Upper bounds for parameters read from a stored hash. scrypt needs 128·N·r
+// bytes and does p times that work, and PBKDF2 runs once per iteration, so a
+// crafted hash could otherwise make a single sign-in attempt allocate
+// gigabytes or run for minutes. Django, the most demanding of the supported
+// sources, defaults to around a million PBKDF2 iterations.
+const (
+	maxScryptLogN       = 20
+	maxScryptRP         = 1 << 30
+	maxScryptMemory     = 1 << 30
+	maxPBKDF2Iterations = 2_000_000
+)
+
+// Verify checks password against a hash in one of the accepted formats:
+//
+//	$2a$10$...                           bcrypt ($2b$ and $2y$ as well)
+//	$scrypt$ln=15,r=8,p=1$salt$hash      PHC string
+//	$pbkdf2-sha256$i=29000$salt$hash     PHC string, or passlib without "i="; also -sha512 and $pbkdf2$ for SHA-1
+//	pbkdf2_sha256$260000$salt$hash       Django, whose salt is not encoded
+func Verify(password, passwordHashed string) (bool, error) {
+	switch {
+	case strings.HasPrefix(passwordHashed, "$2a$"),
+		strings.HasPrefix(passwordHashed, "$2b$"),
+		strings.HasPrefix(passwordHashed, "$2y$"):
+		return verifyBcrypt(password, passwordHashed)
+	case strings.HasPrefix(passwordHashed, "$scrypt$"):
+		return verifyScrypt(password, passwordHashed)
+	case strings.HasPrefix(passwordHashed, "$pbkdf2"):
+		return verifyPBKDF2(password, passwordHashed)
+	case strings.HasPrefix(passwordHashed, "pbkdf2_"):
+		return verifyDjangoPBKDF2(password, passwordHashed)
+	}
+	return false, errors.New("unsupported password hash format")
+}
+
+func verifyBcrypt(password, passwordHashed string) (bool, error) {
+	err := bcrypt.CompareHashAndPassword([]byte(passwordHashed), []byte(password))
+	switch {
+	case err == nil:
+		return true, nil
+	case errors.Is(err, bcrypt.ErrMismatchedHashAndPassword), errors.Is(err, bcrypt.ErrPasswordTooLong):
+		return false, nil
+	}
+	return false, errors.Wrap(err, "failed to verify bcrypt hash")
+}
+
+func verifyScrypt(password, passwordHashed string) (bool, error) {
+	parts := strings.Split(passwordHashed, "$")
+	if len(parts) != 5 {
+		return false, errors.New("invalid password hash format")
+	}
+
+	var logN, r, p int
+	for _, param := range strings.Split(parts[2], ",") {
+		key, value, _ := strings.Cut(param, "=")
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return false, errors.Wrap(err, "failed to parse scrypt parameters")
+		}
+		switch key {
+		case "ln":
+			logN = n
+		case "r":
+			r = n
+		case "p":
+			p = n
+		}
+	}
+	if logN <= 0 || r <= 0 || p <= 0 {
+		return false, errors.New("invalid scrypt parameters")
+	}
+	if logN > maxScryptLogN || r >= maxScryptRP || p >= maxScryptRP || r*p >= maxScryptRP || 128*r<<logN > maxScryptMemory {
+		return false, errors.New("unsupported scrypt parameters")
+	}
+
+	salt, hash, err := decodeSaltAndHash(parts[3], parts[4])
+	if err != nil {
+		return false, err
+	}
+
+	hashedPassword, err := scrypt.Key([]byte(password), salt, 1<<logN, r, p, len(hash))
+	if err != nil {
+		return false, errors.Wrap(err, "failed to derive scrypt key")
+	}
+	return subtle.ConstantTimeCompare(hashedPassword, hash) == 1, nil
+}
+
+func verifyPBKDF2(password, passwordHashed string) (bool, error) {
+	parts := strings.Split(passwordHashed, "$")
+	if len(parts) != 5 {
+		return false, errors.New("invalid password hash format")
+	}
+
+	digest, err := pbkdf2Digest(strings.TrimPrefix(strings.TrimPrefix(parts[1], "pbkdf2"), "-"))
+	if err != nil {
+		return false, err
+	}
+	iterations, err := strconv.Atoi(strings.TrimPrefix(parts[2], "i="))
+	if err != nil || iterations <= 0 {
+		return false, errors.New("invalid pbkdf2 iterations")
+	}
+	if iterations > maxPBKDF2Iterations {
+		return false, errors.New("unsupported pbkdf2 iterations")
+	}
+
+	salt, hash, err := decodeSaltAndHash(parts[3], parts[4])
+	if err != nil {
+		return false, err
+	}
+
+	hashedPassword := pbkdf2.Key([]byte(password), salt, iterations, len(hash), digest)
+	return subtle.ConstantTimeCompare(hashedPassword, hash) == 1, nil
+}
+
+func verifyDjangoPBKDF2(password, passwordHashed string) (bool, error) {
+	parts := strings.Split(passwordHashed, "$")
+	if len(parts) != 4 {
+		return false, errors.New("invalid password hash format")
+	}
+
+	digest, err := pbkdf2Digest(strings.TrimPrefix(parts[0], "pbkdf2_"))
+	if err != nil {
+		return false, err
+	}
+	iterations, err := strconv.Atoi(parts[1])
+	if err != nil || iterations <= 0 {
+		return false, errors.New("invalid pbkdf2 iterations")
+	}
+	if iterations > maxPBKDF2Iterations {
+		return false, errors.New("unsupported pbkdf2 iterations")
+	}
+
+	hash, err := base64.StdEncoding.DecodeString(parts[3])
+	if err != nil {
+		return false, errors.Wrap(err, "failed to decode hash")
+	}
+
+	hashedPassword := pbkdf2.Key([]byte(password), []byte(parts[2]), iterations, len(hash), digest)
+	return subtle.ConstantTimeCompare(hashedPassword, hash) == 1, nil
+}
+
+func pbkdf2Digest(name string) (func() hash.Hash, error) {
+	switch name {
+	case "", "sha1":
+		return sha1.New, nil
+	case "sha256":
+		return sha256.New, nil
+	case "sha512":
+		return sha512.New, nil
+	}
+	return nil, errors.Newf("unsupported pbkdf2 digest %q", name)
+}
+
+func decodeSaltAndHash(saltEncoded, hashEncoded string) (salt, hash []byte, err error) {
+	salt, err = decodeBase64(saltEncoded)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to decode salt")
+	}
+	hash, err = decodeBase64(hashEncoded)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to decode hash")
+	}
+	if len(hash) == 0 {
+		return nil, nil, errors.New("invalid password hash format")
+	}
+	return salt, hash, nil
+}
+
+// decodeBase64 reads unpadded standard base64, and passlib's variant that
+// writes "." for "+".
+func decodeBase64(value string) ([]byte, error) {
+	value = strings.TrimRight(strings.ReplaceAll(value, ".", "+"), "=")
+	return base64.RawStdEncoding.DecodeString(value)
+}
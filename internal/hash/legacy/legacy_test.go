@@ -0,0 +1,93 @@
+package legacy_test
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/akfaiz/go-vue-starter-kit/internal/hash/legacy"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+	"golang.org/x/crypto/pbkdf2"
+	"golang.org/x/crypto/scrypt"
+)
+
+func TestVerify(t *testing.T) {
+	salt := []byte("0123456789abcdef")
+	b64 := base64.RawStdEncoding.EncodeToString
+
+	bcryptHash, err := bcrypt.GenerateFromPassword([]byte("secret"), bcrypt.MinCost)
+	require.NoError(t, err)
+	scryptKey, err := scrypt.Key([]byte("secret"), salt, 1<<10, 8, 1, 32)
+	require.NoError(t, err)
+	pbkdf2Key := pbkdf2.Key([]byte("secret"), salt, 1000, 32, sha256.New)
+	djangoKey := pbkdf2.Key([]byte("secret"), []byte("djangosalt"), 1000, 32, sha256.New)
+
+	hashes := map[string]string{
+		"bcrypt":         string(bcryptHash),
+		"bcrypt $2y$":    "$2y$" + string(bcryptHash)[4:],
+		"scrypt":         "$scrypt$ln=10,r=8,p=1$" + b64(salt) + "$" + b64(scryptKey),
+		"pbkdf2":         "$pbkdf2-sha256$i=1000$" + b64(salt) + "$" + b64(pbkdf2Key),
+		"pbkdf2 passlib": "$pbkdf2-sha256$1000$" + strings.ReplaceAll(b64(salt), "+", ".") + "$" + strings.ReplaceAll(b64(pbkdf2Key), "+", "."),
+		"pbkdf2 django":  "pbkdf2_sha256$1000$djangosalt$" + base64.StdEncoding.EncodeToString(djangoKey),
+	}
+	for name, hash := range hashes {
+		t.Run("should verify "+name+" hashes", func(t *testing.T) {
+			valid, err := legacy.Verify("secret", hash)
+			require.NoError(t, err)
+			assert.True(t, valid)
+
+			valid, err = legacy.Verify("wrong", hash)
+			require.NoError(t, err)
+			assert.False(t, valid)
+		})
+	}
+
+	t.Run("should reject an unknown format", func(t *testing.T) {
+		valid, err := legacy.Verify("secret", "$md5$salt$hash")
+
+		require.Error(t, err)
+		assert.False(t, valid)
+		assert.Contains(t, err.Error(), "unsupported password hash format")
+	})
+
+	t.Run("should reject an unknown pbkdf2 digest", func(t *testing.T) {
+		valid, err := legacy.Verify("secret", "$pbkdf2-md5$i=1000$"+b64(salt)+"$"+b64(pbkdf2Key))
+
+		require.Error(t, err)
+		assert.False(t, valid)
+	})
+
+	for name, hash := range map[string]string{
+		"pbkdf2":        "$pbkdf2-sha256$i=2000000000$" + b64(salt) + "$" + b64(pbkdf2Key),
+		"pbkdf2 django": "pbkdf2_sha256$2000001$djangosalt$" + base64.StdEncoding.EncodeToString(djangoKey),
+	} {
+		t.Run("should refuse "+name+" hashes with costly iterations", func(t *testing.T) {
+			valid, err := legacy.Verify("secret", hash)
+
+			require.Error(t, err)
+			assert.False(t, valid)
+			assert.Contains(t, err.Error(), "unsupported pbkdf2 iterations")
+		})
+	}
+
+	costly := map[string]string{
+		"N":     "ln=21,r=8,p=1",
+		"r·p":   "ln=10,r=32768,p=32768",
+		"N·r":   "ln=20,r=16,p=1",
+		"r":     "ln=1,r=1073741824,p=1",
+		"p":     "ln=1,r=1,p=4611686018427387904",
+		"large": "ln=20,r=4611686018427387904,p=4611686018427387904",
+	}
+	for name, params := range costly {
+		t.Run("should refuse scrypt hashes with a costly "+name, func(t *testing.T) {
+			valid, err := legacy.Verify("secret", "$scrypt$"+params+"$"+b64(salt)+"$"+b64(scryptKey))
+
+			require.Error(t, err)
+			assert.False(t, valid)
+			assert.Contains(t, err.Error(), "unsupported scrypt parameters")
+		})
+	}
+}
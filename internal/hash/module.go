@@ -1,6 +1,7 @@
 package hash
 
 import (
+	"github.com/akfaiz/go-vue-starter-kit/internal/hash/aesgcm"
 	"github.com/akfaiz/go-vue-starter-kit/internal/hash/argon2id"
 	"github.com/akfaiz/go-vue-starter-kit/internal/hash/jwtmanager"
 	"go.uber.org/fx"
@@ -9,6 +10,7 @@ import (
 var Module = fx.Module("hash",
 	fx.Provide(
 		argon2id.NewHasher,
-		jwtmanager.New,
+		jwtmanager.NewWithLifecycle,
+		aesgcm.NewEncrypter,
 	),
 )
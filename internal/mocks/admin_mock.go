@@ -0,0 +1,144 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: admin.go
+//
+// Generated by this command:
+//
+//	mockgen -source=admin.go -destination=../mocks/admin_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/akfaiz/go-vue-starter-kit/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockAdminUserService is a mock of AdminUserService interface.
+type MockAdminUserService struct {
+	ctrl     *gomock.Controller
+	recorder *MockAdminUserServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockAdminUserServiceMockRecorder is the mock recorder for MockAdminUserService.
+type MockAdminUserServiceMockRecorder struct {
+	mock *MockAdminUserService
+}
+
+// NewMockAdminUserService creates a new mock instance.
+func NewMockAdminUserService(ctrl *gomock.Controller) *MockAdminUserService {
+	mock := &MockAdminUserService{ctrl: ctrl}
+	mock.recorder = &MockAdminUserServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAdminUserService) EXPECT() *MockAdminUserServiceMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockAdminUserService) Delete(ctx context.Context, actorID, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, actorID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockAdminUserServiceMockRecorder) Delete(ctx, actorID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockAdminUserService)(nil).Delete), ctx, actorID, id)
+}
+
+// FindByID mocks base method.
+func (m *MockAdminUserService) FindByID(ctx context.Context, id int64) (*domain.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", ctx, id)
+	ret0, _ := ret[0].(*domain.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockAdminUserServiceMockRecorder) FindByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockAdminUserService)(nil).FindByID), ctx, id)
+}
+
+// List mocks base method.
+func (m *MockAdminUserService) List(ctx context.Context, filter domain.UserFilter) ([]*domain.User, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, filter)
+	ret0, _ := ret[0].([]*domain.User)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// List indicates an expected call of List.
+func (mr *MockAdminUserServiceMockRecorder) List(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockAdminUserService)(nil).List), ctx, filter)
+}
+
+// SendPasswordReset mocks base method.
+func (m *MockAdminUserService) SendPasswordReset(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendPasswordReset", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendPasswordReset indicates an expected call of SendPasswordReset.
+func (mr *MockAdminUserServiceMockRecorder) SendPasswordReset(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendPasswordReset", reflect.TypeOf((*MockAdminUserService)(nil).SendPasswordReset), ctx, id)
+}
+
+// Suspend mocks base method.
+func (m *MockAdminUserService) Suspend(ctx context.Context, actorID, id int64, reason string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Suspend", ctx, actorID, id, reason)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Suspend indicates an expected call of Suspend.
+func (mr *MockAdminUserServiceMockRecorder) Suspend(ctx, actorID, id, reason any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Suspend", reflect.TypeOf((*MockAdminUserService)(nil).Suspend), ctx, actorID, id, reason)
+}
+
+// Unsuspend mocks base method.
+func (m *MockAdminUserService) Unsuspend(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Unsuspend", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Unsuspend indicates an expected call of Unsuspend.
+func (mr *MockAdminUserServiceMockRecorder) Unsuspend(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unsuspend", reflect.TypeOf((*MockAdminUserService)(nil).Unsuspend), ctx, id)
+}
+
+// Update mocks base method.
+func (m *MockAdminUserService) Update(ctx context.Context, id int64, data *domain.AdminUserUpdate) (*domain.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, id, data)
+	ret0, _ := ret[0].(*domain.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockAdminUserServiceMockRecorder) Update(ctx, id, data any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockAdminUserService)(nil).Update), ctx, id, data)
+}
@@ -14,6 +14,7 @@ import (
 	reflect "reflect"
 
 	domain "github.com/akfaiz/go-vue-starter-kit/internal/domain"
+	jose "github.com/go-jose/go-jose/v4"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -41,11 +42,40 @@ func (m *MockAuthService) EXPECT() *MockAuthServiceMockRecorder {
 	return m.recorder
 }
 
+// ChangeExpiredPassword mocks base method.
+func (m *MockAuthService) ChangeExpiredPassword(ctx context.Context, email, currentPassword, newPassword string) (*domain.LoginResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangeExpiredPassword", ctx, email, currentPassword, newPassword)
+	ret0, _ := ret[0].(*domain.LoginResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ChangeExpiredPassword indicates an expected call of ChangeExpiredPassword.
+func (mr *MockAuthServiceMockRecorder) ChangeExpiredPassword(ctx, email, currentPassword, newPassword any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangeExpiredPassword", reflect.TypeOf((*MockAuthService)(nil).ChangeExpiredPassword), ctx, email, currentPassword, newPassword)
+}
+
+// ConfirmPassword mocks base method.
+func (m *MockAuthService) ConfirmPassword(ctx context.Context, userID int64, sessionID, password string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfirmPassword", ctx, userID, sessionID, password)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ConfirmPassword indicates an expected call of ConfirmPassword.
+func (mr *MockAuthServiceMockRecorder) ConfirmPassword(ctx, userID, sessionID, password any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmPassword", reflect.TypeOf((*MockAuthService)(nil).ConfirmPassword), ctx, userID, sessionID, password)
+}
+
 // Login mocks base method.
-func (m *MockAuthService) Login(ctx context.Context, email, password string) (*domain.PairToken, error) {
+func (m *MockAuthService) Login(ctx context.Context, email, password string) (*domain.LoginResult, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Login", ctx, email, password)
-	ret0, _ := ret[0].(*domain.PairToken)
+	ret0, _ := ret[0].(*domain.LoginResult)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -56,6 +86,34 @@ func (mr *MockAuthServiceMockRecorder) Login(ctx, email, password any) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Login", reflect.TypeOf((*MockAuthService)(nil).Login), ctx, email, password)
 }
 
+// Logout mocks base method.
+func (m *MockAuthService) Logout(ctx context.Context, userID int64, sessionID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Logout", ctx, userID, sessionID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Logout indicates an expected call of Logout.
+func (mr *MockAuthServiceMockRecorder) Logout(ctx, userID, sessionID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Logout", reflect.TypeOf((*MockAuthService)(nil).Logout), ctx, userID, sessionID)
+}
+
+// LogoutAll mocks base method.
+func (m *MockAuthService) LogoutAll(ctx context.Context, userID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LogoutAll", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LogoutAll indicates an expected call of LogoutAll.
+func (mr *MockAuthServiceMockRecorder) LogoutAll(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LogoutAll", reflect.TypeOf((*MockAuthService)(nil).LogoutAll), ctx, userID)
+}
+
 // RefreshToken mocks base method.
 func (m *MockAuthService) RefreshToken(ctx context.Context, refreshToken string) (*domain.PairToken, error) {
 	m.ctrl.T.Helper()
@@ -114,6 +172,20 @@ func (mr *MockAuthServiceMockRecorder) SendForgotPasswordEmail(ctx, email any) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendForgotPasswordEmail", reflect.TypeOf((*MockAuthService)(nil).SendForgotPasswordEmail), ctx, email)
 }
 
+// SendMagicLink mocks base method.
+func (m *MockAuthService) SendMagicLink(ctx context.Context, email string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendMagicLink", ctx, email)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendMagicLink indicates an expected call of SendMagicLink.
+func (mr *MockAuthServiceMockRecorder) SendMagicLink(ctx, email any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMagicLink", reflect.TypeOf((*MockAuthService)(nil).SendMagicLink), ctx, email)
+}
+
 // SendVerificationEmail mocks base method.
 func (m *MockAuthService) SendVerificationEmail(ctx context.Context, email string) error {
 	m.ctrl.T.Helper()
@@ -156,6 +228,36 @@ func (mr *MockAuthServiceMockRecorder) VerifyEmail(ctx, token, userID any) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyEmail", reflect.TypeOf((*MockAuthService)(nil).VerifyEmail), ctx, token, userID)
 }
 
+// VerifyMagicLink mocks base method.
+func (m *MockAuthService) VerifyMagicLink(ctx context.Context, token, email string) (*domain.LoginResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyMagicLink", ctx, token, email)
+	ret0, _ := ret[0].(*domain.LoginResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VerifyMagicLink indicates an expected call of VerifyMagicLink.
+func (mr *MockAuthServiceMockRecorder) VerifyMagicLink(ctx, token, email any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyMagicLink", reflect.TypeOf((*MockAuthService)(nil).VerifyMagicLink), ctx, token, email)
+}
+
+// VerifyTwoFactor mocks base method.
+func (m *MockAuthService) VerifyTwoFactor(ctx context.Context, challengeToken, code, recoveryCode string) (*domain.PairToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyTwoFactor", ctx, challengeToken, code, recoveryCode)
+	ret0, _ := ret[0].(*domain.PairToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VerifyTwoFactor indicates an expected call of VerifyTwoFactor.
+func (mr *MockAuthServiceMockRecorder) VerifyTwoFactor(ctx, challengeToken, code, recoveryCode any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyTwoFactor", reflect.TypeOf((*MockAuthService)(nil).VerifyTwoFactor), ctx, challengeToken, code, recoveryCode)
+}
+
 // MockPasswordHasher is a mock of PasswordHasher interface.
 type MockPasswordHasher struct {
 	ctrl     *gomock.Controller
@@ -195,6 +297,20 @@ func (mr *MockPasswordHasherMockRecorder) Hash(password any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Hash", reflect.TypeOf((*MockPasswordHasher)(nil).Hash), password)
 }
 
+// NeedsRehash mocks base method.
+func (m *MockPasswordHasher) NeedsRehash(hashed string) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NeedsRehash", hashed)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// NeedsRehash indicates an expected call of NeedsRehash.
+func (mr *MockPasswordHasherMockRecorder) NeedsRehash(hashed any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NeedsRehash", reflect.TypeOf((*MockPasswordHasher)(nil).NeedsRehash), hashed)
+}
+
 // Verify mocks base method.
 func (m *MockPasswordHasher) Verify(password, hashed string) (bool, error) {
 	m.ctrl.T.Helper()
@@ -279,6 +395,20 @@ func (mr *MockJWTManagerMockRecorder) GenerateRefreshToken(claims any) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateRefreshToken", reflect.TypeOf((*MockJWTManager)(nil).GenerateRefreshToken), claims)
 }
 
+// JWKS mocks base method.
+func (m *MockJWTManager) JWKS() jose.JSONWebKeySet {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "JWKS")
+	ret0, _ := ret[0].(jose.JSONWebKeySet)
+	return ret0
+}
+
+// JWKS indicates an expected call of JWKS.
+func (mr *MockJWTManagerMockRecorder) JWKS() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "JWKS", reflect.TypeOf((*MockJWTManager)(nil).JWKS))
+}
+
 // VerifyAccessToken mocks base method.
 func (m *MockJWTManager) VerifyAccessToken(token string) (*domain.JWTClaims, error) {
 	m.ctrl.T.Helper()
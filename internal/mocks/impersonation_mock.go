@@ -0,0 +1,124 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: impersonation.go
+//
+// Generated by this command:
+//
+//	mockgen -source=impersonation.go -destination=../mocks/impersonation_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	domain "github.com/akfaiz/go-vue-starter-kit/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockImpersonationRepository is a mock of ImpersonationRepository interface.
+type MockImpersonationRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockImpersonationRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockImpersonationRepositoryMockRecorder is the mock recorder for MockImpersonationRepository.
+type MockImpersonationRepositoryMockRecorder struct {
+	mock *MockImpersonationRepository
+}
+
+// NewMockImpersonationRepository creates a new mock instance.
+func NewMockImpersonationRepository(ctrl *gomock.Controller) *MockImpersonationRepository {
+	mock := &MockImpersonationRepository{ctrl: ctrl}
+	mock.recorder = &MockImpersonationRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockImpersonationRepository) EXPECT() *MockImpersonationRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockImpersonationRepository) Create(ctx context.Context, impersonation *domain.Impersonation) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, impersonation)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockImpersonationRepositoryMockRecorder) Create(ctx, impersonation any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockImpersonationRepository)(nil).Create), ctx, impersonation)
+}
+
+// End mocks base method.
+func (m *MockImpersonationRepository) End(ctx context.Context, tokenID string, endedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "End", ctx, tokenID, endedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// End indicates an expected call of End.
+func (mr *MockImpersonationRepositoryMockRecorder) End(ctx, tokenID, endedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "End", reflect.TypeOf((*MockImpersonationRepository)(nil).End), ctx, tokenID, endedAt)
+}
+
+// MockImpersonationService is a mock of ImpersonationService interface.
+type MockImpersonationService struct {
+	ctrl     *gomock.Controller
+	recorder *MockImpersonationServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockImpersonationServiceMockRecorder is the mock recorder for MockImpersonationService.
+type MockImpersonationServiceMockRecorder struct {
+	mock *MockImpersonationService
+}
+
+// NewMockImpersonationService creates a new mock instance.
+func NewMockImpersonationService(ctrl *gomock.Controller) *MockImpersonationService {
+	mock := &MockImpersonationService{ctrl: ctrl}
+	mock.recorder = &MockImpersonationServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockImpersonationService) EXPECT() *MockImpersonationServiceMockRecorder {
+	return m.recorder
+}
+
+// Start mocks base method.
+func (m *MockImpersonationService) Start(ctx context.Context, impersonatorID, userID int64) (*domain.ImpersonationToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Start", ctx, impersonatorID, userID)
+	ret0, _ := ret[0].(*domain.ImpersonationToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Start indicates an expected call of Start.
+func (mr *MockImpersonationServiceMockRecorder) Start(ctx, impersonatorID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Start", reflect.TypeOf((*MockImpersonationService)(nil).Start), ctx, impersonatorID, userID)
+}
+
+// Stop mocks base method.
+func (m *MockImpersonationService) Stop(ctx context.Context, claims *domain.JWTClaims) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Stop", ctx, claims)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Stop indicates an expected call of Stop.
+func (mr *MockImpersonationServiceMockRecorder) Stop(ctx, claims any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stop", reflect.TypeOf((*MockImpersonationService)(nil).Stop), ctx, claims)
+}
@@ -0,0 +1,280 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: passkey.go
+//
+// Generated by this command:
+//
+//	mockgen -source=passkey.go -destination=../mocks/passkey_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	json "encoding/json"
+	reflect "reflect"
+
+	domain "github.com/akfaiz/go-vue-starter-kit/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockPasskeyRepository is a mock of PasskeyRepository interface.
+type MockPasskeyRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockPasskeyRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockPasskeyRepositoryMockRecorder is the mock recorder for MockPasskeyRepository.
+type MockPasskeyRepositoryMockRecorder struct {
+	mock *MockPasskeyRepository
+}
+
+// NewMockPasskeyRepository creates a new mock instance.
+func NewMockPasskeyRepository(ctrl *gomock.Controller) *MockPasskeyRepository {
+	mock := &MockPasskeyRepository{ctrl: ctrl}
+	mock.recorder = &MockPasskeyRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPasskeyRepository) EXPECT() *MockPasskeyRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockPasskeyRepository) Create(ctx context.Context, passkey *domain.Passkey) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, passkey)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockPasskeyRepositoryMockRecorder) Create(ctx, passkey any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockPasskeyRepository)(nil).Create), ctx, passkey)
+}
+
+// Delete mocks base method.
+func (m *MockPasskeyRepository) Delete(ctx context.Context, userID, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, userID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockPasskeyRepositoryMockRecorder) Delete(ctx, userID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockPasskeyRepository)(nil).Delete), ctx, userID, id)
+}
+
+// FindByUserID mocks base method.
+func (m *MockPasskeyRepository) FindByUserID(ctx context.Context, userID int64) ([]*domain.Passkey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByUserID", ctx, userID)
+	ret0, _ := ret[0].([]*domain.Passkey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByUserID indicates an expected call of FindByUserID.
+func (mr *MockPasskeyRepositoryMockRecorder) FindByUserID(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByUserID", reflect.TypeOf((*MockPasskeyRepository)(nil).FindByUserID), ctx, userID)
+}
+
+// UpdateUsage mocks base method.
+func (m *MockPasskeyRepository) UpdateUsage(ctx context.Context, id int64, signCount uint32, backupState bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUsage", ctx, id, signCount, backupState)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateUsage indicates an expected call of UpdateUsage.
+func (mr *MockPasskeyRepositoryMockRecorder) UpdateUsage(ctx, id, signCount, backupState any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUsage", reflect.TypeOf((*MockPasskeyRepository)(nil).UpdateUsage), ctx, id, signCount, backupState)
+}
+
+// MockWebAuthnSessionRepository is a mock of WebAuthnSessionRepository interface.
+type MockWebAuthnSessionRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockWebAuthnSessionRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockWebAuthnSessionRepositoryMockRecorder is the mock recorder for MockWebAuthnSessionRepository.
+type MockWebAuthnSessionRepositoryMockRecorder struct {
+	mock *MockWebAuthnSessionRepository
+}
+
+// NewMockWebAuthnSessionRepository creates a new mock instance.
+func NewMockWebAuthnSessionRepository(ctrl *gomock.Controller) *MockWebAuthnSessionRepository {
+	mock := &MockWebAuthnSessionRepository{ctrl: ctrl}
+	mock.recorder = &MockWebAuthnSessionRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWebAuthnSessionRepository) EXPECT() *MockWebAuthnSessionRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Consume mocks base method.
+func (m *MockWebAuthnSessionRepository) Consume(ctx context.Context, id string) (*domain.WebAuthnSession, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Consume", ctx, id)
+	ret0, _ := ret[0].(*domain.WebAuthnSession)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Consume indicates an expected call of Consume.
+func (mr *MockWebAuthnSessionRepositoryMockRecorder) Consume(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Consume", reflect.TypeOf((*MockWebAuthnSessionRepository)(nil).Consume), ctx, id)
+}
+
+// Create mocks base method.
+func (m *MockWebAuthnSessionRepository) Create(ctx context.Context, session *domain.WebAuthnSession) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, session)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockWebAuthnSessionRepositoryMockRecorder) Create(ctx, session any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockWebAuthnSessionRepository)(nil).Create), ctx, session)
+}
+
+// DeleteExpired mocks base method.
+func (m *MockWebAuthnSessionRepository) DeleteExpired(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteExpired", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteExpired indicates an expected call of DeleteExpired.
+func (mr *MockWebAuthnSessionRepositoryMockRecorder) DeleteExpired(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpired", reflect.TypeOf((*MockWebAuthnSessionRepository)(nil).DeleteExpired), ctx)
+}
+
+// MockPasskeyService is a mock of PasskeyService interface.
+type MockPasskeyService struct {
+	ctrl     *gomock.Controller
+	recorder *MockPasskeyServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockPasskeyServiceMockRecorder is the mock recorder for MockPasskeyService.
+type MockPasskeyServiceMockRecorder struct {
+	mock *MockPasskeyService
+}
+
+// NewMockPasskeyService creates a new mock instance.
+func NewMockPasskeyService(ctrl *gomock.Controller) *MockPasskeyService {
+	mock := &MockPasskeyService{ctrl: ctrl}
+	mock.recorder = &MockPasskeyServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPasskeyService) EXPECT() *MockPasskeyServiceMockRecorder {
+	return m.recorder
+}
+
+// BeginLogin mocks base method.
+func (m *MockPasskeyService) BeginLogin(ctx context.Context) (*domain.PasskeyCeremony, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BeginLogin", ctx)
+	ret0, _ := ret[0].(*domain.PasskeyCeremony)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BeginLogin indicates an expected call of BeginLogin.
+func (mr *MockPasskeyServiceMockRecorder) BeginLogin(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BeginLogin", reflect.TypeOf((*MockPasskeyService)(nil).BeginLogin), ctx)
+}
+
+// BeginRegistration mocks base method.
+func (m *MockPasskeyService) BeginRegistration(ctx context.Context, userID int64) (*domain.PasskeyCeremony, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BeginRegistration", ctx, userID)
+	ret0, _ := ret[0].(*domain.PasskeyCeremony)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BeginRegistration indicates an expected call of BeginRegistration.
+func (mr *MockPasskeyServiceMockRecorder) BeginRegistration(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BeginRegistration", reflect.TypeOf((*MockPasskeyService)(nil).BeginRegistration), ctx, userID)
+}
+
+// Delete mocks base method.
+func (m *MockPasskeyService) Delete(ctx context.Context, userID, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, userID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockPasskeyServiceMockRecorder) Delete(ctx, userID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockPasskeyService)(nil).Delete), ctx, userID, id)
+}
+
+// FinishLogin mocks base method.
+func (m *MockPasskeyService) FinishLogin(ctx context.Context, ceremonyID string, credential json.RawMessage) (*domain.PairToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FinishLogin", ctx, ceremonyID, credential)
+	ret0, _ := ret[0].(*domain.PairToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FinishLogin indicates an expected call of FinishLogin.
+func (mr *MockPasskeyServiceMockRecorder) FinishLogin(ctx, ceremonyID, credential any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FinishLogin", reflect.TypeOf((*MockPasskeyService)(nil).FinishLogin), ctx, ceremonyID, credential)
+}
+
+// FinishRegistration mocks base method.
+func (m *MockPasskeyService) FinishRegistration(ctx context.Context, userID int64, ceremonyID, name string, credential json.RawMessage) (*domain.Passkey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FinishRegistration", ctx, userID, ceremonyID, name, credential)
+	ret0, _ := ret[0].(*domain.Passkey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FinishRegistration indicates an expected call of FinishRegistration.
+func (mr *MockPasskeyServiceMockRecorder) FinishRegistration(ctx, userID, ceremonyID, name, credential any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FinishRegistration", reflect.TypeOf((*MockPasskeyService)(nil).FinishRegistration), ctx, userID, ceremonyID, name, credential)
+}
+
+// List mocks base method.
+func (m *MockPasskeyService) List(ctx context.Context, userID int64) ([]*domain.Passkey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, userID)
+	ret0, _ := ret[0].([]*domain.Passkey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockPasskeyServiceMockRecorder) List(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockPasskeyService)(nil).List), ctx, userID)
+}
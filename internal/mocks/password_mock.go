@@ -0,0 +1,252 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: password.go
+//
+// Generated by this command:
+//
+//	mockgen -source=password.go -destination=../mocks/password_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/akfaiz/go-vue-starter-kit/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockPasswordPolicy is a mock of PasswordPolicy interface.
+type MockPasswordPolicy struct {
+	ctrl     *gomock.Controller
+	recorder *MockPasswordPolicyMockRecorder
+	isgomock struct{}
+}
+
+// MockPasswordPolicyMockRecorder is the mock recorder for MockPasswordPolicy.
+type MockPasswordPolicyMockRecorder struct {
+	mock *MockPasswordPolicy
+}
+
+// NewMockPasswordPolicy creates a new mock instance.
+func NewMockPasswordPolicy(ctrl *gomock.Controller) *MockPasswordPolicy {
+	mock := &MockPasswordPolicy{ctrl: ctrl}
+	mock.recorder = &MockPasswordPolicyMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPasswordPolicy) EXPECT() *MockPasswordPolicyMockRecorder {
+	return m.recorder
+}
+
+// Validate mocks base method.
+func (m *MockPasswordPolicy) Validate(ctx context.Context, field, password string, user *domain.User) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Validate", ctx, field, password, user)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Validate indicates an expected call of Validate.
+func (mr *MockPasswordPolicyMockRecorder) Validate(ctx, field, password, user any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Validate", reflect.TypeOf((*MockPasswordPolicy)(nil).Validate), ctx, field, password, user)
+}
+
+// MockPasswordRangeClient is a mock of PasswordRangeClient interface.
+type MockPasswordRangeClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockPasswordRangeClientMockRecorder
+	isgomock struct{}
+}
+
+// MockPasswordRangeClientMockRecorder is the mock recorder for MockPasswordRangeClient.
+type MockPasswordRangeClientMockRecorder struct {
+	mock *MockPasswordRangeClient
+}
+
+// NewMockPasswordRangeClient creates a new mock instance.
+func NewMockPasswordRangeClient(ctrl *gomock.Controller) *MockPasswordRangeClient {
+	mock := &MockPasswordRangeClient{ctrl: ctrl}
+	mock.recorder = &MockPasswordRangeClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPasswordRangeClient) EXPECT() *MockPasswordRangeClientMockRecorder {
+	return m.recorder
+}
+
+// Range mocks base method.
+func (m *MockPasswordRangeClient) Range(ctx context.Context, prefix string) (map[string]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Range", ctx, prefix)
+	ret0, _ := ret[0].(map[string]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Range indicates an expected call of Range.
+func (mr *MockPasswordRangeClientMockRecorder) Range(ctx, prefix any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Range", reflect.TypeOf((*MockPasswordRangeClient)(nil).Range), ctx, prefix)
+}
+
+// MockPasswordHistory is a mock of PasswordHistory interface.
+type MockPasswordHistory struct {
+	ctrl     *gomock.Controller
+	recorder *MockPasswordHistoryMockRecorder
+	isgomock struct{}
+}
+
+// MockPasswordHistoryMockRecorder is the mock recorder for MockPasswordHistory.
+type MockPasswordHistoryMockRecorder struct {
+	mock *MockPasswordHistory
+}
+
+// NewMockPasswordHistory creates a new mock instance.
+func NewMockPasswordHistory(ctrl *gomock.Controller) *MockPasswordHistory {
+	mock := &MockPasswordHistory{ctrl: ctrl}
+	mock.recorder = &MockPasswordHistoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPasswordHistory) EXPECT() *MockPasswordHistoryMockRecorder {
+	return m.recorder
+}
+
+// Check mocks base method.
+func (m *MockPasswordHistory) Check(ctx context.Context, field, password string, user *domain.User) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Check", ctx, field, password, user)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Check indicates an expected call of Check.
+func (mr *MockPasswordHistoryMockRecorder) Check(ctx, field, password, user any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Check", reflect.TypeOf((*MockPasswordHistory)(nil).Check), ctx, field, password, user)
+}
+
+// Record mocks base method.
+func (m *MockPasswordHistory) Record(ctx context.Context, userID int64, replacedHash string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Record", ctx, userID, replacedHash)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Record indicates an expected call of Record.
+func (mr *MockPasswordHistoryMockRecorder) Record(ctx, userID, replacedHash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Record", reflect.TypeOf((*MockPasswordHistory)(nil).Record), ctx, userID, replacedHash)
+}
+
+// MockPasswordChanger is a mock of PasswordChanger interface.
+type MockPasswordChanger struct {
+	ctrl     *gomock.Controller
+	recorder *MockPasswordChangerMockRecorder
+	isgomock struct{}
+}
+
+// MockPasswordChangerMockRecorder is the mock recorder for MockPasswordChanger.
+type MockPasswordChangerMockRecorder struct {
+	mock *MockPasswordChanger
+}
+
+// NewMockPasswordChanger creates a new mock instance.
+func NewMockPasswordChanger(ctrl *gomock.Controller) *MockPasswordChanger {
+	mock := &MockPasswordChanger{ctrl: ctrl}
+	mock.recorder = &MockPasswordChangerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPasswordChanger) EXPECT() *MockPasswordChangerMockRecorder {
+	return m.recorder
+}
+
+// Change mocks base method.
+func (m *MockPasswordChanger) Change(ctx context.Context, field string, user *domain.User, newPassword string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Change", ctx, field, user, newPassword)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Change indicates an expected call of Change.
+func (mr *MockPasswordChangerMockRecorder) Change(ctx, field, user, newPassword any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Change", reflect.TypeOf((*MockPasswordChanger)(nil).Change), ctx, field, user, newPassword)
+}
+
+// MockPasswordHistoryRepository is a mock of PasswordHistoryRepository interface.
+type MockPasswordHistoryRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockPasswordHistoryRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockPasswordHistoryRepositoryMockRecorder is the mock recorder for MockPasswordHistoryRepository.
+type MockPasswordHistoryRepositoryMockRecorder struct {
+	mock *MockPasswordHistoryRepository
+}
+
+// NewMockPasswordHistoryRepository creates a new mock instance.
+func NewMockPasswordHistoryRepository(ctrl *gomock.Controller) *MockPasswordHistoryRepository {
+	mock := &MockPasswordHistoryRepository{ctrl: ctrl}
+	mock.recorder = &MockPasswordHistoryRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPasswordHistoryRepository) EXPECT() *MockPasswordHistoryRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockPasswordHistoryRepository) Create(ctx context.Context, userID int64, passwordHash string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, userID, passwordHash)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockPasswordHistoryRepositoryMockRecorder) Create(ctx, userID, passwordHash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockPasswordHistoryRepository)(nil).Create), ctx, userID, passwordHash)
+}
+
+// ListRecent mocks base method.
+func (m *MockPasswordHistoryRepository) ListRecent(ctx context.Context, userID int64, limit int) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRecent", ctx, userID, limit)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRecent indicates an expected call of ListRecent.
+func (mr *MockPasswordHistoryRepositoryMockRecorder) ListRecent(ctx, userID, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRecent", reflect.TypeOf((*MockPasswordHistoryRepository)(nil).ListRecent), ctx, userID, limit)
+}
+
+// Prune mocks base method.
+func (m *MockPasswordHistoryRepository) Prune(ctx context.Context, userID int64, keep int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Prune", ctx, userID, keep)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Prune indicates an expected call of Prune.
+func (mr *MockPasswordHistoryRepositoryMockRecorder) Prune(ctx, userID, keep any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Prune", reflect.TypeOf((*MockPasswordHistoryRepository)(nil).Prune), ctx, userID, keep)
+}
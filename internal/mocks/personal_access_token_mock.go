@@ -0,0 +1,199 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: personal_access_token.go
+//
+// Generated by this command:
+//
+//	mockgen -source=personal_access_token.go -destination=../mocks/personal_access_token_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	domain "github.com/akfaiz/go-vue-starter-kit/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockPersonalAccessTokenRepository is a mock of PersonalAccessTokenRepository interface.
+type MockPersonalAccessTokenRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockPersonalAccessTokenRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockPersonalAccessTokenRepositoryMockRecorder is the mock recorder for MockPersonalAccessTokenRepository.
+type MockPersonalAccessTokenRepositoryMockRecorder struct {
+	mock *MockPersonalAccessTokenRepository
+}
+
+// NewMockPersonalAccessTokenRepository creates a new mock instance.
+func NewMockPersonalAccessTokenRepository(ctrl *gomock.Controller) *MockPersonalAccessTokenRepository {
+	mock := &MockPersonalAccessTokenRepository{ctrl: ctrl}
+	mock.recorder = &MockPersonalAccessTokenRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPersonalAccessTokenRepository) EXPECT() *MockPersonalAccessTokenRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockPersonalAccessTokenRepository) Create(ctx context.Context, token *domain.PersonalAccessToken) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, token)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockPersonalAccessTokenRepositoryMockRecorder) Create(ctx, token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockPersonalAccessTokenRepository)(nil).Create), ctx, token)
+}
+
+// Delete mocks base method.
+func (m *MockPersonalAccessTokenRepository) Delete(ctx context.Context, userID, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, userID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockPersonalAccessTokenRepositoryMockRecorder) Delete(ctx, userID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockPersonalAccessTokenRepository)(nil).Delete), ctx, userID, id)
+}
+
+// FindByPrefix mocks base method.
+func (m *MockPersonalAccessTokenRepository) FindByPrefix(ctx context.Context, prefix string) (*domain.PersonalAccessToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByPrefix", ctx, prefix)
+	ret0, _ := ret[0].(*domain.PersonalAccessToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByPrefix indicates an expected call of FindByPrefix.
+func (mr *MockPersonalAccessTokenRepositoryMockRecorder) FindByPrefix(ctx, prefix any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByPrefix", reflect.TypeOf((*MockPersonalAccessTokenRepository)(nil).FindByPrefix), ctx, prefix)
+}
+
+// FindByUserID mocks base method.
+func (m *MockPersonalAccessTokenRepository) FindByUserID(ctx context.Context, userID int64) ([]*domain.PersonalAccessToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByUserID", ctx, userID)
+	ret0, _ := ret[0].([]*domain.PersonalAccessToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByUserID indicates an expected call of FindByUserID.
+func (mr *MockPersonalAccessTokenRepositoryMockRecorder) FindByUserID(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByUserID", reflect.TypeOf((*MockPersonalAccessTokenRepository)(nil).FindByUserID), ctx, userID)
+}
+
+// TouchLastUsed mocks base method.
+func (m *MockPersonalAccessTokenRepository) TouchLastUsed(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TouchLastUsed", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TouchLastUsed indicates an expected call of TouchLastUsed.
+func (mr *MockPersonalAccessTokenRepositoryMockRecorder) TouchLastUsed(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TouchLastUsed", reflect.TypeOf((*MockPersonalAccessTokenRepository)(nil).TouchLastUsed), ctx, id)
+}
+
+// MockPersonalAccessTokenService is a mock of PersonalAccessTokenService interface.
+type MockPersonalAccessTokenService struct {
+	ctrl     *gomock.Controller
+	recorder *MockPersonalAccessTokenServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockPersonalAccessTokenServiceMockRecorder is the mock recorder for MockPersonalAccessTokenService.
+type MockPersonalAccessTokenServiceMockRecorder struct {
+	mock *MockPersonalAccessTokenService
+}
+
+// NewMockPersonalAccessTokenService creates a new mock instance.
+func NewMockPersonalAccessTokenService(ctrl *gomock.Controller) *MockPersonalAccessTokenService {
+	mock := &MockPersonalAccessTokenService{ctrl: ctrl}
+	mock.recorder = &MockPersonalAccessTokenServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPersonalAccessTokenService) EXPECT() *MockPersonalAccessTokenServiceMockRecorder {
+	return m.recorder
+}
+
+// Authenticate mocks base method.
+func (m *MockPersonalAccessTokenService) Authenticate(ctx context.Context, token string) (*domain.JWTClaims, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Authenticate", ctx, token)
+	ret0, _ := ret[0].(*domain.JWTClaims)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Authenticate indicates an expected call of Authenticate.
+func (mr *MockPersonalAccessTokenServiceMockRecorder) Authenticate(ctx, token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Authenticate", reflect.TypeOf((*MockPersonalAccessTokenService)(nil).Authenticate), ctx, token)
+}
+
+// Create mocks base method.
+func (m *MockPersonalAccessTokenService) Create(ctx context.Context, userID int64, name string, scopes []string, expiresAt *time.Time) (*domain.PersonalAccessToken, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, userID, name, scopes, expiresAt)
+	ret0, _ := ret[0].(*domain.PersonalAccessToken)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockPersonalAccessTokenServiceMockRecorder) Create(ctx, userID, name, scopes, expiresAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockPersonalAccessTokenService)(nil).Create), ctx, userID, name, scopes, expiresAt)
+}
+
+// Delete mocks base method.
+func (m *MockPersonalAccessTokenService) Delete(ctx context.Context, userID, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, userID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockPersonalAccessTokenServiceMockRecorder) Delete(ctx, userID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockPersonalAccessTokenService)(nil).Delete), ctx, userID, id)
+}
+
+// List mocks base method.
+func (m *MockPersonalAccessTokenService) List(ctx context.Context, userID int64) ([]*domain.PersonalAccessToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, userID)
+	ret0, _ := ret[0].([]*domain.PersonalAccessToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockPersonalAccessTokenServiceMockRecorder) List(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockPersonalAccessTokenService)(nil).List), ctx, userID)
+}
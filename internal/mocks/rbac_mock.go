@@ -0,0 +1,212 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: rbac.go
+//
+// Generated by this command:
+//
+//	mockgen -source=rbac.go -destination=../mocks/rbac_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/akfaiz/go-vue-starter-kit/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRoleRepository is a mock of RoleRepository interface.
+type MockRoleRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRoleRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockRoleRepositoryMockRecorder is the mock recorder for MockRoleRepository.
+type MockRoleRepositoryMockRecorder struct {
+	mock *MockRoleRepository
+}
+
+// NewMockRoleRepository creates a new mock instance.
+func NewMockRoleRepository(ctrl *gomock.Controller) *MockRoleRepository {
+	mock := &MockRoleRepository{ctrl: ctrl}
+	mock.recorder = &MockRoleRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRoleRepository) EXPECT() *MockRoleRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AssignToUser mocks base method.
+func (m *MockRoleRepository) AssignToUser(ctx context.Context, userID, roleID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AssignToUser", ctx, userID, roleID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AssignToUser indicates an expected call of AssignToUser.
+func (mr *MockRoleRepositoryMockRecorder) AssignToUser(ctx, userID, roleID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssignToUser", reflect.TypeOf((*MockRoleRepository)(nil).AssignToUser), ctx, userID, roleID)
+}
+
+// FindAll mocks base method.
+func (m *MockRoleRepository) FindAll(ctx context.Context) ([]*domain.Role, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", ctx)
+	ret0, _ := ret[0].([]*domain.Role)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockRoleRepositoryMockRecorder) FindAll(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockRoleRepository)(nil).FindAll), ctx)
+}
+
+// FindByName mocks base method.
+func (m *MockRoleRepository) FindByName(ctx context.Context, name string) (*domain.Role, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByName", ctx, name)
+	ret0, _ := ret[0].(*domain.Role)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByName indicates an expected call of FindByName.
+func (mr *MockRoleRepositoryMockRecorder) FindByName(ctx, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByName", reflect.TypeOf((*MockRoleRepository)(nil).FindByName), ctx, name)
+}
+
+// FindByUserID mocks base method.
+func (m *MockRoleRepository) FindByUserID(ctx context.Context, userID int64) ([]*domain.Role, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByUserID", ctx, userID)
+	ret0, _ := ret[0].([]*domain.Role)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByUserID indicates an expected call of FindByUserID.
+func (mr *MockRoleRepositoryMockRecorder) FindByUserID(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByUserID", reflect.TypeOf((*MockRoleRepository)(nil).FindByUserID), ctx, userID)
+}
+
+// RemoveFromUser mocks base method.
+func (m *MockRoleRepository) RemoveFromUser(ctx context.Context, userID, roleID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveFromUser", ctx, userID, roleID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveFromUser indicates an expected call of RemoveFromUser.
+func (mr *MockRoleRepositoryMockRecorder) RemoveFromUser(ctx, userID, roleID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveFromUser", reflect.TypeOf((*MockRoleRepository)(nil).RemoveFromUser), ctx, userID, roleID)
+}
+
+// MockRBACService is a mock of RBACService interface.
+type MockRBACService struct {
+	ctrl     *gomock.Controller
+	recorder *MockRBACServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockRBACServiceMockRecorder is the mock recorder for MockRBACService.
+type MockRBACServiceMockRecorder struct {
+	mock *MockRBACService
+}
+
+// NewMockRBACService creates a new mock instance.
+func NewMockRBACService(ctrl *gomock.Controller) *MockRBACService {
+	mock := &MockRBACService{ctrl: ctrl}
+	mock.recorder = &MockRBACServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRBACService) EXPECT() *MockRBACServiceMockRecorder {
+	return m.recorder
+}
+
+// AssignRole mocks base method.
+func (m *MockRBACService) AssignRole(ctx context.Context, userID int64, roleName string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AssignRole", ctx, userID, roleName)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AssignRole indicates an expected call of AssignRole.
+func (mr *MockRBACServiceMockRecorder) AssignRole(ctx, userID, roleName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssignRole", reflect.TypeOf((*MockRBACService)(nil).AssignRole), ctx, userID, roleName)
+}
+
+// Authorization mocks base method.
+func (m *MockRBACService) Authorization(ctx context.Context, userID int64) (*domain.Authorization, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Authorization", ctx, userID)
+	ret0, _ := ret[0].(*domain.Authorization)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Authorization indicates an expected call of Authorization.
+func (mr *MockRBACServiceMockRecorder) Authorization(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Authorization", reflect.TypeOf((*MockRBACService)(nil).Authorization), ctx, userID)
+}
+
+// HasPermission mocks base method.
+func (m *MockRBACService) HasPermission(ctx context.Context, userID int64, permission string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasPermission", ctx, userID, permission)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HasPermission indicates an expected call of HasPermission.
+func (mr *MockRBACServiceMockRecorder) HasPermission(ctx, userID, permission any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasPermission", reflect.TypeOf((*MockRBACService)(nil).HasPermission), ctx, userID, permission)
+}
+
+// ListRoles mocks base method.
+func (m *MockRBACService) ListRoles(ctx context.Context) ([]*domain.Role, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRoles", ctx)
+	ret0, _ := ret[0].([]*domain.Role)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRoles indicates an expected call of ListRoles.
+func (mr *MockRBACServiceMockRecorder) ListRoles(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRoles", reflect.TypeOf((*MockRBACService)(nil).ListRoles), ctx)
+}
+
+// RemoveRole mocks base method.
+func (m *MockRBACService) RemoveRole(ctx context.Context, userID int64, roleName string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveRole", ctx, userID, roleName)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveRole indicates an expected call of RemoveRole.
+func (mr *MockRBACServiceMockRecorder) RemoveRole(ctx, userID, roleName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveRole", reflect.TypeOf((*MockRBACService)(nil).RemoveRole), ctx, userID, roleName)
+}
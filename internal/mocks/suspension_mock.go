@@ -0,0 +1,84 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: suspension.go
+//
+// Generated by this command:
+//
+//	mockgen -source=suspension.go -destination=../mocks/suspension_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockSuspensionService is a mock of SuspensionService interface.
+type MockSuspensionService struct {
+	ctrl     *gomock.Controller
+	recorder *MockSuspensionServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockSuspensionServiceMockRecorder is the mock recorder for MockSuspensionService.
+type MockSuspensionServiceMockRecorder struct {
+	mock *MockSuspensionService
+}
+
+// NewMockSuspensionService creates a new mock instance.
+func NewMockSuspensionService(ctrl *gomock.Controller) *MockSuspensionService {
+	mock := &MockSuspensionService{ctrl: ctrl}
+	mock.recorder = &MockSuspensionServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSuspensionService) EXPECT() *MockSuspensionServiceMockRecorder {
+	return m.recorder
+}
+
+// IsSuspended mocks base method.
+func (m *MockSuspensionService) IsSuspended(ctx context.Context, userID int64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsSuspended", ctx, userID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsSuspended indicates an expected call of IsSuspended.
+func (mr *MockSuspensionServiceMockRecorder) IsSuspended(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsSuspended", reflect.TypeOf((*MockSuspensionService)(nil).IsSuspended), ctx, userID)
+}
+
+// Suspend mocks base method.
+func (m *MockSuspensionService) Suspend(ctx context.Context, userID int64, reason string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Suspend", ctx, userID, reason)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Suspend indicates an expected call of Suspend.
+func (mr *MockSuspensionServiceMockRecorder) Suspend(ctx, userID, reason any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Suspend", reflect.TypeOf((*MockSuspensionService)(nil).Suspend), ctx, userID, reason)
+}
+
+// Unsuspend mocks base method.
+func (m *MockSuspensionService) Unsuspend(ctx context.Context, userID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Unsuspend", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Unsuspend indicates an expected call of Unsuspend.
+func (mr *MockSuspensionServiceMockRecorder) Unsuspend(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unsuspend", reflect.TypeOf((*MockSuspensionService)(nil).Unsuspend), ctx, userID)
+}
@@ -0,0 +1,181 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: throttle.go
+//
+// Generated by this command:
+//
+//	mockgen -source=throttle.go -destination=../mocks/throttle_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	domain "github.com/akfaiz/go-vue-starter-kit/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockThrottleStore is a mock of ThrottleStore interface.
+type MockThrottleStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockThrottleStoreMockRecorder
+	isgomock struct{}
+}
+
+// MockThrottleStoreMockRecorder is the mock recorder for MockThrottleStore.
+type MockThrottleStoreMockRecorder struct {
+	mock *MockThrottleStore
+}
+
+// NewMockThrottleStore creates a new mock instance.
+func NewMockThrottleStore(ctrl *gomock.Controller) *MockThrottleStore {
+	mock := &MockThrottleStore{ctrl: ctrl}
+	mock.recorder = &MockThrottleStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockThrottleStore) EXPECT() *MockThrottleStoreMockRecorder {
+	return m.recorder
+}
+
+// Block mocks base method.
+func (m *MockThrottleStore) Block(ctx context.Context, key string, until time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Block", ctx, key, until)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Block indicates an expected call of Block.
+func (mr *MockThrottleStoreMockRecorder) Block(ctx, key, until any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Block", reflect.TypeOf((*MockThrottleStore)(nil).Block), ctx, key, until)
+}
+
+// DeleteExpired mocks base method.
+func (m *MockThrottleStore) DeleteExpired(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteExpired", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteExpired indicates an expected call of DeleteExpired.
+func (mr *MockThrottleStoreMockRecorder) DeleteExpired(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpired", reflect.TypeOf((*MockThrottleStore)(nil).DeleteExpired), ctx)
+}
+
+// Get mocks base method.
+func (m *MockThrottleStore) Get(ctx context.Context, key string) (*domain.ThrottleCounter, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, key)
+	ret0, _ := ret[0].(*domain.ThrottleCounter)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockThrottleStoreMockRecorder) Get(ctx, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockThrottleStore)(nil).Get), ctx, key)
+}
+
+// Hit mocks base method.
+func (m *MockThrottleStore) Hit(ctx context.Context, key string, ttl time.Duration) (*domain.ThrottleCounter, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Hit", ctx, key, ttl)
+	ret0, _ := ret[0].(*domain.ThrottleCounter)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Hit indicates an expected call of Hit.
+func (mr *MockThrottleStoreMockRecorder) Hit(ctx, key, ttl any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Hit", reflect.TypeOf((*MockThrottleStore)(nil).Hit), ctx, key, ttl)
+}
+
+// Reset mocks base method.
+func (m *MockThrottleStore) Reset(ctx context.Context, key string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reset", ctx, key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Reset indicates an expected call of Reset.
+func (mr *MockThrottleStoreMockRecorder) Reset(ctx, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reset", reflect.TypeOf((*MockThrottleStore)(nil).Reset), ctx, key)
+}
+
+// MockLoginThrottler is a mock of LoginThrottler interface.
+type MockLoginThrottler struct {
+	ctrl     *gomock.Controller
+	recorder *MockLoginThrottlerMockRecorder
+	isgomock struct{}
+}
+
+// MockLoginThrottlerMockRecorder is the mock recorder for MockLoginThrottler.
+type MockLoginThrottlerMockRecorder struct {
+	mock *MockLoginThrottler
+}
+
+// NewMockLoginThrottler creates a new mock instance.
+func NewMockLoginThrottler(ctrl *gomock.Controller) *MockLoginThrottler {
+	mock := &MockLoginThrottler{ctrl: ctrl}
+	mock.recorder = &MockLoginThrottlerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLoginThrottler) EXPECT() *MockLoginThrottlerMockRecorder {
+	return m.recorder
+}
+
+// Check mocks base method.
+func (m *MockLoginThrottler) Check(ctx context.Context, email string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Check", ctx, email)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Check indicates an expected call of Check.
+func (mr *MockLoginThrottlerMockRecorder) Check(ctx, email any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Check", reflect.TypeOf((*MockLoginThrottler)(nil).Check), ctx, email)
+}
+
+// Fail mocks base method.
+func (m *MockLoginThrottler) Fail(ctx context.Context, email string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Fail", ctx, email)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Fail indicates an expected call of Fail.
+func (mr *MockLoginThrottlerMockRecorder) Fail(ctx, email any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Fail", reflect.TypeOf((*MockLoginThrottler)(nil).Fail), ctx, email)
+}
+
+// Succeed mocks base method.
+func (m *MockLoginThrottler) Succeed(ctx context.Context, email string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Succeed", ctx, email)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Succeed indicates an expected call of Succeed.
+func (mr *MockLoginThrottlerMockRecorder) Succeed(ctx, email any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Succeed", reflect.TypeOf((*MockLoginThrottler)(nil).Succeed), ctx, email)
+}
@@ -0,0 +1,158 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: token_denylist.go
+//
+// Generated by this command:
+//
+//	mockgen -source=token_denylist.go -destination=../mocks/token_denylist_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	domain "github.com/akfaiz/go-vue-starter-kit/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRevokedTokenRepository is a mock of RevokedTokenRepository interface.
+type MockRevokedTokenRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRevokedTokenRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockRevokedTokenRepositoryMockRecorder is the mock recorder for MockRevokedTokenRepository.
+type MockRevokedTokenRepositoryMockRecorder struct {
+	mock *MockRevokedTokenRepository
+}
+
+// NewMockRevokedTokenRepository creates a new mock instance.
+func NewMockRevokedTokenRepository(ctrl *gomock.Controller) *MockRevokedTokenRepository {
+	mock := &MockRevokedTokenRepository{ctrl: ctrl}
+	mock.recorder = &MockRevokedTokenRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRevokedTokenRepository) EXPECT() *MockRevokedTokenRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockRevokedTokenRepository) Create(ctx context.Context, key string, expiresAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, key, expiresAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockRevokedTokenRepositoryMockRecorder) Create(ctx, key, expiresAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockRevokedTokenRepository)(nil).Create), ctx, key, expiresAt)
+}
+
+// DeleteExpired mocks base method.
+func (m *MockRevokedTokenRepository) DeleteExpired(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteExpired", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteExpired indicates an expected call of DeleteExpired.
+func (mr *MockRevokedTokenRepositoryMockRecorder) DeleteExpired(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpired", reflect.TypeOf((*MockRevokedTokenRepository)(nil).DeleteExpired), ctx)
+}
+
+// FindActive mocks base method.
+func (m *MockRevokedTokenRepository) FindActive(ctx context.Context, keys []string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindActive", ctx, keys)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindActive indicates an expected call of FindActive.
+func (mr *MockRevokedTokenRepositoryMockRecorder) FindActive(ctx, keys any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindActive", reflect.TypeOf((*MockRevokedTokenRepository)(nil).FindActive), ctx, keys)
+}
+
+// MockTokenDenylist is a mock of TokenDenylist interface.
+type MockTokenDenylist struct {
+	ctrl     *gomock.Controller
+	recorder *MockTokenDenylistMockRecorder
+	isgomock struct{}
+}
+
+// MockTokenDenylistMockRecorder is the mock recorder for MockTokenDenylist.
+type MockTokenDenylistMockRecorder struct {
+	mock *MockTokenDenylist
+}
+
+// NewMockTokenDenylist creates a new mock instance.
+func NewMockTokenDenylist(ctrl *gomock.Controller) *MockTokenDenylist {
+	mock := &MockTokenDenylist{ctrl: ctrl}
+	mock.recorder = &MockTokenDenylistMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTokenDenylist) EXPECT() *MockTokenDenylistMockRecorder {
+	return m.recorder
+}
+
+// IsRevoked mocks base method.
+func (m *MockTokenDenylist) IsRevoked(ctx context.Context, claims *domain.JWTClaims) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsRevoked", ctx, claims)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsRevoked indicates an expected call of IsRevoked.
+func (mr *MockTokenDenylistMockRecorder) IsRevoked(ctx, claims any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsRevoked", reflect.TypeOf((*MockTokenDenylist)(nil).IsRevoked), ctx, claims)
+}
+
+// Revoke mocks base method.
+func (m *MockTokenDenylist) Revoke(ctx context.Context, jti string, expiresAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Revoke", ctx, jti, expiresAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Revoke indicates an expected call of Revoke.
+func (mr *MockTokenDenylistMockRecorder) Revoke(ctx, jti, expiresAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Revoke", reflect.TypeOf((*MockTokenDenylist)(nil).Revoke), ctx, jti, expiresAt)
+}
+
+// RevokeSessions mocks base method.
+func (m *MockTokenDenylist) RevokeSessions(ctx context.Context, sessionIDs ...string) error {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx}
+	for _, a := range sessionIDs {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "RevokeSessions", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeSessions indicates an expected call of RevokeSessions.
+func (mr *MockTokenDenylistMockRecorder) RevokeSessions(ctx any, sessionIDs ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx}, sessionIDs...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeSessions", reflect.TypeOf((*MockTokenDenylist)(nil).RevokeSessions), varargs...)
+}
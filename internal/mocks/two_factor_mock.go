@@ -0,0 +1,189 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: two_factor.go
+//
+// Generated by this command:
+//
+//	mockgen -source=two_factor.go -destination=../mocks/two_factor_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/akfaiz/go-vue-starter-kit/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockTwoFactorService is a mock of TwoFactorService interface.
+type MockTwoFactorService struct {
+	ctrl     *gomock.Controller
+	recorder *MockTwoFactorServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockTwoFactorServiceMockRecorder is the mock recorder for MockTwoFactorService.
+type MockTwoFactorServiceMockRecorder struct {
+	mock *MockTwoFactorService
+}
+
+// NewMockTwoFactorService creates a new mock instance.
+func NewMockTwoFactorService(ctrl *gomock.Controller) *MockTwoFactorService {
+	mock := &MockTwoFactorService{ctrl: ctrl}
+	mock.recorder = &MockTwoFactorServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTwoFactorService) EXPECT() *MockTwoFactorServiceMockRecorder {
+	return m.recorder
+}
+
+// Confirm mocks base method.
+func (m *MockTwoFactorService) Confirm(ctx context.Context, userID int64, code string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Confirm", ctx, userID, code)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Confirm indicates an expected call of Confirm.
+func (mr *MockTwoFactorServiceMockRecorder) Confirm(ctx, userID, code any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Confirm", reflect.TypeOf((*MockTwoFactorService)(nil).Confirm), ctx, userID, code)
+}
+
+// ConsumeChallenge mocks base method.
+func (m *MockTwoFactorService) ConsumeChallenge(ctx context.Context, token string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConsumeChallenge", ctx, token)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ConsumeChallenge indicates an expected call of ConsumeChallenge.
+func (mr *MockTwoFactorServiceMockRecorder) ConsumeChallenge(ctx, token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConsumeChallenge", reflect.TypeOf((*MockTwoFactorService)(nil).ConsumeChallenge), ctx, token)
+}
+
+// CreateChallenge mocks base method.
+func (m *MockTwoFactorService) CreateChallenge(ctx context.Context, user *domain.User) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateChallenge", ctx, user)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateChallenge indicates an expected call of CreateChallenge.
+func (mr *MockTwoFactorServiceMockRecorder) CreateChallenge(ctx, user any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateChallenge", reflect.TypeOf((*MockTwoFactorService)(nil).CreateChallenge), ctx, user)
+}
+
+// Disable mocks base method.
+func (m *MockTwoFactorService) Disable(ctx context.Context, userID int64, password string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Disable", ctx, userID, password)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Disable indicates an expected call of Disable.
+func (mr *MockTwoFactorServiceMockRecorder) Disable(ctx, userID, password any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Disable", reflect.TypeOf((*MockTwoFactorService)(nil).Disable), ctx, userID, password)
+}
+
+// Enable mocks base method.
+func (m *MockTwoFactorService) Enable(ctx context.Context, userID int64) (*domain.TwoFactorSetup, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Enable", ctx, userID)
+	ret0, _ := ret[0].(*domain.TwoFactorSetup)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Enable indicates an expected call of Enable.
+func (mr *MockTwoFactorServiceMockRecorder) Enable(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Enable", reflect.TypeOf((*MockTwoFactorService)(nil).Enable), ctx, userID)
+}
+
+// FailChallenge mocks base method.
+func (m *MockTwoFactorService) FailChallenge(ctx context.Context, token string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FailChallenge", ctx, token)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FailChallenge indicates an expected call of FailChallenge.
+func (mr *MockTwoFactorServiceMockRecorder) FailChallenge(ctx, token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FailChallenge", reflect.TypeOf((*MockTwoFactorService)(nil).FailChallenge), ctx, token)
+}
+
+// ParseChallenge mocks base method.
+func (m *MockTwoFactorService) ParseChallenge(ctx context.Context, token string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ParseChallenge", ctx, token)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ParseChallenge indicates an expected call of ParseChallenge.
+func (mr *MockTwoFactorServiceMockRecorder) ParseChallenge(ctx, token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ParseChallenge", reflect.TypeOf((*MockTwoFactorService)(nil).ParseChallenge), ctx, token)
+}
+
+// RegenerateRecoveryCodes mocks base method.
+func (m *MockTwoFactorService) RegenerateRecoveryCodes(ctx context.Context, userID int64, password string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RegenerateRecoveryCodes", ctx, userID, password)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RegenerateRecoveryCodes indicates an expected call of RegenerateRecoveryCodes.
+func (mr *MockTwoFactorServiceMockRecorder) RegenerateRecoveryCodes(ctx, userID, password any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegenerateRecoveryCodes", reflect.TypeOf((*MockTwoFactorService)(nil).RegenerateRecoveryCodes), ctx, userID, password)
+}
+
+// UseRecoveryCode mocks base method.
+func (m *MockTwoFactorService) UseRecoveryCode(ctx context.Context, user *domain.User, code string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseRecoveryCode", ctx, user, code)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UseRecoveryCode indicates an expected call of UseRecoveryCode.
+func (mr *MockTwoFactorServiceMockRecorder) UseRecoveryCode(ctx, user, code any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseRecoveryCode", reflect.TypeOf((*MockTwoFactorService)(nil).UseRecoveryCode), ctx, user, code)
+}
+
+// Verify mocks base method.
+func (m *MockTwoFactorService) Verify(ctx context.Context, user *domain.User, code string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Verify", ctx, user, code)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Verify indicates an expected call of Verify.
+func (mr *MockTwoFactorServiceMockRecorder) Verify(ctx, user, code any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Verify", reflect.TypeOf((*MockTwoFactorService)(nil).Verify), ctx, user, code)
+}
@@ -41,6 +41,21 @@ func (m *MockUserRepository) EXPECT() *MockUserRepositoryMockRecorder {
 	return m.recorder
 }
 
+// Count mocks base method.
+func (m *MockUserRepository) Count(ctx context.Context, filter domain.UserFilter) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Count", ctx, filter)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Count indicates an expected call of Count.
+func (mr *MockUserRepositoryMockRecorder) Count(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Count", reflect.TypeOf((*MockUserRepository)(nil).Count), ctx, filter)
+}
+
 // Create mocks base method.
 func (m *MockUserRepository) Create(ctx context.Context, user *domain.User) error {
 	m.ctrl.T.Helper()
@@ -99,6 +114,21 @@ func (mr *MockUserRepositoryMockRecorder) FindByID(ctx, id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockUserRepository)(nil).FindByID), ctx, id)
 }
 
+// List mocks base method.
+func (m *MockUserRepository) List(ctx context.Context, filter domain.UserFilter) ([]*domain.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, filter)
+	ret0, _ := ret[0].([]*domain.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockUserRepositoryMockRecorder) List(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockUserRepository)(nil).List), ctx, filter)
+}
+
 // Update mocks base method.
 func (m *MockUserRepository) Update(ctx context.Context, id int64, user *domain.UserUpdate) error {
 	m.ctrl.T.Helper()
@@ -113,6 +143,36 @@ func (mr *MockUserRepositoryMockRecorder) Update(ctx, id, user any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockUserRepository)(nil).Update), ctx, id, user)
 }
 
+// UseTwoFactorRecoveryCode mocks base method.
+func (m *MockUserRepository) UseTwoFactorRecoveryCode(ctx context.Context, id int64, hashedCode string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseTwoFactorRecoveryCode", ctx, id, hashedCode)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UseTwoFactorRecoveryCode indicates an expected call of UseTwoFactorRecoveryCode.
+func (mr *MockUserRepositoryMockRecorder) UseTwoFactorRecoveryCode(ctx, id, hashedCode any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseTwoFactorRecoveryCode", reflect.TypeOf((*MockUserRepository)(nil).UseTwoFactorRecoveryCode), ctx, id, hashedCode)
+}
+
+// UseTwoFactorStep mocks base method.
+func (m *MockUserRepository) UseTwoFactorStep(ctx context.Context, id, step int64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseTwoFactorStep", ctx, id, step)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UseTwoFactorStep indicates an expected call of UseTwoFactorStep.
+func (mr *MockUserRepositoryMockRecorder) UseTwoFactorStep(ctx, id, step any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseTwoFactorStep", reflect.TypeOf((*MockUserRepository)(nil).UseTwoFactorStep), ctx, id, step)
+}
+
 // MockUserService is a mock of UserService interface.
 type MockUserService struct {
 	ctrl     *gomock.Controller
@@ -137,6 +197,20 @@ func (m *MockUserService) EXPECT() *MockUserServiceMockRecorder {
 	return m.recorder
 }
 
+// ChangeEmail mocks base method.
+func (m *MockUserService) ChangeEmail(ctx context.Context, id int64, newEmail string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangeEmail", ctx, id, newEmail)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ChangeEmail indicates an expected call of ChangeEmail.
+func (mr *MockUserServiceMockRecorder) ChangeEmail(ctx, id, newEmail any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangeEmail", reflect.TypeOf((*MockUserService)(nil).ChangeEmail), ctx, id, newEmail)
+}
+
 // ChangePassword mocks base method.
 func (m *MockUserService) ChangePassword(ctx context.Context, id int64, currentPassword, newPassword string) error {
 	m.ctrl.T.Helper()
@@ -151,6 +225,20 @@ func (mr *MockUserServiceMockRecorder) ChangePassword(ctx, id, currentPassword,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangePassword", reflect.TypeOf((*MockUserService)(nil).ChangePassword), ctx, id, currentPassword, newPassword)
 }
 
+// ConfirmEmailChange mocks base method.
+func (m *MockUserService) ConfirmEmailChange(ctx context.Context, id int64, token string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfirmEmailChange", ctx, id, token)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ConfirmEmailChange indicates an expected call of ConfirmEmailChange.
+func (mr *MockUserServiceMockRecorder) ConfirmEmailChange(ctx, id, token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmEmailChange", reflect.TypeOf((*MockUserService)(nil).ConfirmEmailChange), ctx, id, token)
+}
+
 // Delete mocks base method.
 func (m *MockUserService) Delete(ctx context.Context, id int64, password string) error {
 	m.ctrl.T.Helper()
@@ -180,6 +268,35 @@ func (mr *MockUserServiceMockRecorder) FindByID(ctx, id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockUserService)(nil).FindByID), ctx, id)
 }
 
+// PendingEmail mocks base method.
+func (m *MockUserService) PendingEmail(ctx context.Context, id int64) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PendingEmail", ctx, id)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PendingEmail indicates an expected call of PendingEmail.
+func (mr *MockUserServiceMockRecorder) PendingEmail(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PendingEmail", reflect.TypeOf((*MockUserService)(nil).PendingEmail), ctx, id)
+}
+
+// RevertEmailChange mocks base method.
+func (m *MockUserService) RevertEmailChange(ctx context.Context, id int64, token string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevertEmailChange", ctx, id, token)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevertEmailChange indicates an expected call of RevertEmailChange.
+func (mr *MockUserServiceMockRecorder) RevertEmailChange(ctx, id, token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevertEmailChange", reflect.TypeOf((*MockUserService)(nil).RevertEmailChange), ctx, id, token)
+}
+
 // UpdateProfile mocks base method.
 func (m *MockUserService) UpdateProfile(ctx context.Context, id int64, user *domain.User) error {
 	m.ctrl.T.Helper()
@@ -41,6 +41,20 @@ func (m *MockUserTokenRepository) EXPECT() *MockUserTokenRepositoryMockRecorder
 	return m.recorder
 }
 
+// Consume mocks base method.
+func (m *MockUserTokenRepository) Consume(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Consume", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Consume indicates an expected call of Consume.
+func (mr *MockUserTokenRepositoryMockRecorder) Consume(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Consume", reflect.TypeOf((*MockUserTokenRepository)(nil).Consume), ctx, id)
+}
+
 // Create mocks base method.
 func (m *MockUserTokenRepository) Create(ctx context.Context, token *domain.UserToken) error {
 	m.ctrl.T.Helper()
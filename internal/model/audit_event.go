@@ -0,0 +1,33 @@
+package model
+
+import (
+	"time"
+
+	"github.com/akfaiz/go-vue-starter-kit/internal/domain"
+)
+
+type AuditEvent struct {
+	ID        int64          `bun:"id,pk,autoincrement"`
+	Type      string         `bun:"type,notnull"`
+	ActorID   *int64         `bun:"actor_id"`
+	UserID    *int64         `bun:"user_id"`
+	IPAddress string         `bun:"ip_address,nullzero"`
+	UserAgent string         `bun:"user_agent,nullzero"`
+	RequestID string         `bun:"request_id,nullzero"`
+	Metadata  map[string]any `bun:"metadata,type:jsonb,nullzero"`
+	CreatedAt time.Time      `bun:"created_at,notnull,default:current_timestamp"`
+}
+
+func (e *AuditEvent) ToDomain() *domain.AuditEvent {
+	return &domain.AuditEvent{
+		ID:        e.ID,
+		Type:      domain.AuditEventType(e.Type),
+		ActorID:   e.ActorID,
+		UserID:    e.UserID,
+		IPAddress: e.IPAddress,
+		UserAgent: e.UserAgent,
+		RequestID: e.RequestID,
+		Metadata:  e.Metadata,
+		CreatedAt: e.CreatedAt,
+	}
+}
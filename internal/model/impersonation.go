@@ -0,0 +1,33 @@
+package model
+
+import (
+	"time"
+
+	"github.com/akfaiz/go-vue-starter-kit/internal/domain"
+)
+
+type Impersonation struct {
+	ID             int64      `bun:"id,pk,autoincrement"`
+	ImpersonatorID int64      `bun:"impersonator_id,notnull"`
+	UserID         int64      `bun:"user_id,notnull"`
+	TokenID        string     `bun:"token_id,notnull"`
+	UserAgent      string     `bun:"user_agent,nullzero"`
+	IPAddress      string     `bun:"ip_address,nullzero"`
+	ExpiresAt      time.Time  `bun:"expires_at,notnull"`
+	EndedAt        *time.Time `bun:"ended_at"`
+	CreatedAt      time.Time  `bun:"created_at,notnull,default:current_timestamp"`
+}
+
+func (i *Impersonation) ToDomain() *domain.Impersonation {
+	return &domain.Impersonation{
+		ID:             i.ID,
+		ImpersonatorID: i.ImpersonatorID,
+		UserID:         i.UserID,
+		TokenID:        i.TokenID,
+		UserAgent:      i.UserAgent,
+		IPAddress:      i.IPAddress,
+		ExpiresAt:      i.ExpiresAt,
+		EndedAt:        i.EndedAt,
+		CreatedAt:      i.CreatedAt,
+	}
+}
@@ -0,0 +1,16 @@
+package model
+
+import (
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+type PasswordHistory struct {
+	bun.BaseModel `bun:"table:password_history"`
+
+	ID           int64     `bun:"id,pk,autoincrement"`
+	UserID       int64     `bun:"user_id,notnull"`
+	PasswordHash string    `bun:"password_hash,notnull"`
+	CreatedAt    time.Time `bun:"created_at,notnull,default:current_timestamp"`
+}
@@ -0,0 +1,33 @@
+package model
+
+import (
+	"time"
+
+	"github.com/akfaiz/go-vue-starter-kit/internal/domain"
+)
+
+type PersonalAccessToken struct {
+	ID         int64      `bun:"id,pk,autoincrement"`
+	UserID     int64      `bun:"user_id,notnull"`
+	Name       string     `bun:"name,notnull"`
+	Prefix     string     `bun:"prefix,notnull"`
+	TokenHash  string     `bun:"token_hash,notnull"`
+	Scopes     []string   `bun:"scopes,type:jsonb,notnull"`
+	ExpiresAt  *time.Time `bun:"expires_at"`
+	LastUsedAt *time.Time `bun:"last_used_at"`
+	CreatedAt  time.Time  `bun:"created_at,notnull,default:current_timestamp"`
+}
+
+func (t *PersonalAccessToken) ToDomain() *domain.PersonalAccessToken {
+	return &domain.PersonalAccessToken{
+		ID:         t.ID,
+		UserID:     t.UserID,
+		Name:       t.Name,
+		Prefix:     t.Prefix,
+		TokenHash:  t.TokenHash,
+		Scopes:     t.Scopes,
+		ExpiresAt:  t.ExpiresAt,
+		LastUsedAt: t.LastUsedAt,
+		CreatedAt:  t.CreatedAt,
+	}
+}
@@ -0,0 +1,9 @@
+package model
+
+import "time"
+
+type RevokedToken struct {
+	Key       string    `bun:"key,pk"`
+	ExpiresAt time.Time `bun:"expires_at,notnull"`
+	CreatedAt time.Time `bun:"created_at,notnull,default:current_timestamp"`
+}
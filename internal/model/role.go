@@ -0,0 +1,32 @@
+package model
+
+import (
+	"time"
+
+	"github.com/akfaiz/go-vue-starter-kit/internal/domain"
+)
+
+type Role struct {
+	ID          int64     `bun:"id,pk,autoincrement"`
+	Name        string    `bun:"name,notnull"`
+	Description string    `bun:"description,notnull"`
+	CreatedAt   time.Time `bun:"created_at,notnull,default:current_timestamp"`
+}
+
+func (r *Role) ToDomain(permissions []string) *domain.Role {
+	if permissions == nil {
+		permissions = []string{}
+	}
+	return &domain.Role{
+		ID:          r.ID,
+		Name:        r.Name,
+		Description: r.Description,
+		Permissions: permissions,
+	}
+}
+
+type UserRole struct {
+	UserID    int64     `bun:"user_id,pk"`
+	RoleID    int64     `bun:"role_id,pk"`
+	CreatedAt time.Time `bun:"created_at,notnull,default:current_timestamp"`
+}
@@ -0,0 +1,35 @@
+package model
+
+import (
+	"time"
+
+	"github.com/akfaiz/go-vue-starter-kit/internal/domain"
+)
+
+type Session struct {
+	ID              string     `bun:"id,pk,type:uuid"`
+	UserID          int64      `bun:"user_id,notnull"`
+	TokenHash       string     `bun:"token_hash,notnull"`
+	UserAgent       string     `bun:"user_agent,nullzero"`
+	IPAddress       string     `bun:"ip_address,nullzero"`
+	ExpiresAt       time.Time  `bun:"expires_at,notnull"`
+	LastUsedAt      time.Time  `bun:"last_used_at,notnull,default:current_timestamp"`
+	AuthenticatedAt time.Time  `bun:"authenticated_at,notnull,default:current_timestamp"`
+	RevokedAt       *time.Time `bun:"revoked_at"`
+	CreatedAt       time.Time  `bun:"created_at,notnull,default:current_timestamp"`
+}
+
+func (s *Session) ToDomain() *domain.Session {
+	return &domain.Session{
+		ID:              s.ID,
+		UserID:          s.UserID,
+		TokenHash:       s.TokenHash,
+		UserAgent:       s.UserAgent,
+		IPAddress:       s.IPAddress,
+		ExpiresAt:       s.ExpiresAt,
+		LastUsedAt:      s.LastUsedAt,
+		AuthenticatedAt: s.AuthenticatedAt,
+		RevokedAt:       s.RevokedAt,
+		CreatedAt:       s.CreatedAt,
+	}
+}
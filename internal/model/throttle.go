@@ -0,0 +1,23 @@
+package model
+
+import (
+	"time"
+
+	"github.com/akfaiz/go-vue-starter-kit/internal/domain"
+)
+
+type Throttle struct {
+	Key          string     `bun:"key,pk"`
+	Hits         int        `bun:"hits,notnull"`
+	BlockedUntil *time.Time `bun:"blocked_until"`
+	ExpiresAt    time.Time  `bun:"expires_at,notnull"`
+}
+
+func (t *Throttle) ToDomain() *domain.ThrottleCounter {
+	return &domain.ThrottleCounter{
+		Key:          t.Key,
+		Hits:         t.Hits,
+		BlockedUntil: t.BlockedUntil,
+		ExpiresAt:    t.ExpiresAt,
+	}
+}
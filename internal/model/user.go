@@ -1,6 +1,7 @@
 package model
 
 import (
+	"encoding/json"
 	"time"
 
 	"github.com/akfaiz/go-vue-starter-kit/internal/domain"
@@ -8,24 +9,39 @@ import (
 )
 
 type User struct {
-	ID              int64      `bun:"id,pk,autoincrement"`
-	Name            string     `bun:"name,notnull"`
-	Email           string     `bun:"email,unique,notnull"`
-	Password        string     `bun:"password,notnull"`
-	EmailVerifiedAt *time.Time `bun:"email_verified_at"`
-	CreatedAt       time.Time  `bun:"created_at,notnull,default:current_timestamp"`
-	UpdatedAt       time.Time  `bun:"updated_at,notnull,default:current_timestamp"`
+	ID                     int64      `bun:"id,pk,autoincrement"`
+	Name                   string     `bun:"name,notnull"`
+	Email                  string     `bun:"email,unique,notnull"`
+	Password               string     `bun:"password,notnull"`
+	EmailVerifiedAt        *time.Time `bun:"email_verified_at"`
+	TwoFactorSecret        *string    `bun:"two_factor_secret"`
+	TwoFactorRecoveryCodes []string   `bun:"two_factor_recovery_codes,type:jsonb"`
+	TwoFactorConfirmedAt   *time.Time `bun:"two_factor_confirmed_at"`
+	TwoFactorLastStep      *int64     `bun:"two_factor_last_step"`
+	Status                 string     `bun:"status,notnull,nullzero,default:'active'"`
+	SuspendedAt            *time.Time `bun:"suspended_at"`
+	SuspensionReason       *string    `bun:"suspension_reason"`
+	PasswordChangedAt      time.Time  `bun:"password_changed_at,notnull,default:current_timestamp"`
+	CreatedAt              time.Time  `bun:"created_at,notnull,default:current_timestamp"`
+	UpdatedAt              time.Time  `bun:"updated_at,notnull,default:current_timestamp"`
 }
 
 func (u *User) ToDomain() *domain.User {
 	return &domain.User{
-		ID:              u.ID,
-		Name:            u.Name,
-		Email:           u.Email,
-		Password:        u.Password,
-		EmailVerifiedAt: u.EmailVerifiedAt,
-		CreatedAt:       u.CreatedAt,
-		UpdatedAt:       u.UpdatedAt,
+		ID:                     u.ID,
+		Name:                   u.Name,
+		Email:                  u.Email,
+		Password:               u.Password,
+		EmailVerifiedAt:        u.EmailVerifiedAt,
+		TwoFactorSecret:        u.TwoFactorSecret,
+		TwoFactorRecoveryCodes: u.TwoFactorRecoveryCodes,
+		TwoFactorConfirmedAt:   u.TwoFactorConfirmedAt,
+		Status:                 domain.UserStatus(u.Status),
+		SuspendedAt:            u.SuspendedAt,
+		SuspensionReason:       u.SuspensionReason,
+		PasswordChangedAt:      u.PasswordChangedAt,
+		CreatedAt:              u.CreatedAt,
+		UpdatedAt:              u.UpdatedAt,
 	}
 }
 
@@ -38,8 +54,12 @@ func ApplyUserUpdate(query *bun.UpdateQuery, update *domain.UserUpdate) *bun.Upd
 	}
 	if update.Password.IsValue() {
 		query = query.Set("password = ?", update.Password.MustGet())
+		query = query.Set("password_changed_at = NOW()")
 	}
-	if update.EmailVerifiedAt.IsValue() {
+	if update.PasswordRehash.IsValue() {
+		query = query.Set("password = ?", update.PasswordRehash.MustGet())
+	}
+	if !update.EmailVerifiedAt.IsUnset() {
 		if update.EmailVerifiedAt.IsNull() {
 			query = query.Set("email_verified_at = NULL")
 		} else {
@@ -47,5 +67,44 @@ func ApplyUserUpdate(query *bun.UpdateQuery, update *domain.UserUpdate) *bun.Upd
 			query = query.Set("email_verified_at = ?", t)
 		}
 	}
+	if !update.TwoFactorSecret.IsUnset() {
+		if update.TwoFactorSecret.IsNull() {
+			query = query.Set("two_factor_secret = NULL")
+		} else {
+			query = query.Set("two_factor_secret = ?", update.TwoFactorSecret.MustGet())
+		}
+	}
+	if !update.TwoFactorRecoveryCodes.IsUnset() {
+		if update.TwoFactorRecoveryCodes.IsNull() {
+			query = query.Set("two_factor_recovery_codes = NULL")
+		} else {
+			codes, _ := json.Marshal(update.TwoFactorRecoveryCodes.MustGet())
+			query = query.Set("two_factor_recovery_codes = ?::jsonb", string(codes))
+		}
+	}
+	if !update.TwoFactorConfirmedAt.IsUnset() {
+		if update.TwoFactorConfirmedAt.IsNull() {
+			query = query.Set("two_factor_confirmed_at = NULL")
+		} else {
+			query = query.Set("two_factor_confirmed_at = ?", update.TwoFactorConfirmedAt.MustGet())
+		}
+	}
+	if update.Status.IsValue() {
+		query = query.Set("status = ?", string(update.Status.MustGet()))
+	}
+	if !update.SuspendedAt.IsUnset() {
+		if update.SuspendedAt.IsNull() {
+			query = query.Set("suspended_at = NULL")
+		} else {
+			query = query.Set("suspended_at = ?", update.SuspendedAt.MustGet())
+		}
+	}
+	if !update.SuspensionReason.IsUnset() {
+		if update.SuspensionReason.IsNull() {
+			query = query.Set("suspension_reason = NULL")
+		} else {
+			query = query.Set("suspension_reason = ?", update.SuspensionReason.MustGet())
+		}
+	}
 	return query.Set("updated_at = NOW()")
 }
@@ -0,0 +1,27 @@
+package model
+
+import (
+	"time"
+
+	"github.com/akfaiz/go-vue-starter-kit/internal/domain"
+)
+
+type UserIdentity struct {
+	ID        int64     `bun:"id,pk,autoincrement"`
+	UserID    int64     `bun:"user_id,notnull"`
+	Provider  string    `bun:"provider,notnull"`
+	Subject   string    `bun:"subject,notnull"`
+	Email     string    `bun:"email,nullzero"`
+	CreatedAt time.Time `bun:"created_at,notnull,default:current_timestamp"`
+}
+
+func (i *UserIdentity) ToDomain() *domain.UserIdentity {
+	return &domain.UserIdentity{
+		ID:        i.ID,
+		UserID:    i.UserID,
+		Provider:  i.Provider,
+		Subject:   i.Subject,
+		Email:     i.Email,
+		CreatedAt: i.CreatedAt,
+	}
+}
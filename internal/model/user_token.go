@@ -11,6 +11,7 @@ type UserToken struct {
 	UserID    int64     `bun:"user_id,notnull"`
 	Token     string    `bun:"token,notnull"`
 	TokenType string    `bun:"token_type,notnull"`
+	Email     string    `bun:"email,nullzero"`
 	ExpiresAt time.Time `bun:"expires_at,notnull"`
 	CreatedAt time.Time `bun:"created_at,notnull,default:current_timestamp"`
 }
@@ -21,6 +22,7 @@ func (ut *UserToken) ToDomain() *domain.UserToken {
 		UserID:    ut.UserID,
 		Token:     ut.Token,
 		TokenType: domain.TokenType(ut.TokenType),
+		Email:     ut.Email,
 		ExpiresAt: ut.ExpiresAt,
 		CreatedAt: ut.CreatedAt,
 	}
@@ -0,0 +1,60 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/akfaiz/go-vue-starter-kit/internal/domain"
+)
+
+type WebauthnCredential struct {
+	ID              int64      `bun:"id,pk,autoincrement"`
+	UserID          int64      `bun:"user_id,notnull"`
+	Name            string     `bun:"name,notnull"`
+	CredentialID    []byte     `bun:"credential_id,notnull"`
+	PublicKey       []byte     `bun:"public_key,notnull"`
+	AttestationType string     `bun:"attestation_type,notnull"`
+	Transports      []string   `bun:"transports,type:jsonb"`
+	AAGUID          []byte     `bun:"aaguid"`
+	SignCount       uint32     `bun:"sign_count,notnull"`
+	BackupEligible  bool       `bun:"backup_eligible,notnull"`
+	BackupState     bool       `bun:"backup_state,notnull"`
+	LastUsedAt      *time.Time `bun:"last_used_at"`
+	CreatedAt       time.Time  `bun:"created_at,notnull,default:current_timestamp"`
+}
+
+func (p *WebauthnCredential) ToDomain() *domain.Passkey {
+	return &domain.Passkey{
+		ID:              p.ID,
+		UserID:          p.UserID,
+		Name:            p.Name,
+		CredentialID:    p.CredentialID,
+		PublicKey:       p.PublicKey,
+		AttestationType: p.AttestationType,
+		Transports:      p.Transports,
+		AAGUID:          p.AAGUID,
+		SignCount:       p.SignCount,
+		BackupEligible:  p.BackupEligible,
+		BackupState:     p.BackupState,
+		LastUsedAt:      p.LastUsedAt,
+		CreatedAt:       p.CreatedAt,
+	}
+}
+
+type WebauthnSession struct {
+	ID        string          `bun:"id,pk,type:uuid"`
+	UserID    *int64          `bun:"user_id"`
+	Data      json.RawMessage `bun:"data,type:jsonb,notnull"`
+	ExpiresAt time.Time       `bun:"expires_at,notnull"`
+	CreatedAt time.Time       `bun:"created_at,notnull,default:current_timestamp"`
+}
+
+func (s *WebauthnSession) ToDomain() *domain.WebAuthnSession {
+	return &domain.WebAuthnSession{
+		ID:        s.ID,
+		UserID:    s.UserID,
+		Data:      s.Data,
+		ExpiresAt: s.ExpiresAt,
+		CreatedAt: s.CreatedAt,
+	}
+}
@@ -0,0 +1,81 @@
+package audit
+
+import (
+	"context"
+
+	"github.com/akfaiz/go-vue-starter-kit/internal/domain"
+	"github.com/akfaiz/go-vue-starter-kit/internal/model"
+	"github.com/uptrace/bun"
+)
+
+type repository struct {
+	db *bun.DB
+}
+
+func NewRepository(db *bun.DB) domain.AuditRepository {
+	return &repository{db: db}
+}
+
+func (r *repository) Create(ctx context.Context, event *domain.AuditEvent) error {
+	m := &model.AuditEvent{
+		Type:      string(event.Type),
+		ActorID:   event.ActorID,
+		UserID:    event.UserID,
+		IPAddress: event.IPAddress,
+		UserAgent: event.UserAgent,
+		RequestID: event.RequestID,
+		Metadata:  event.Metadata,
+	}
+	_, err := r.db.NewInsert().Model(m).Returning("id, created_at").Exec(ctx)
+	if err != nil {
+		return err
+	}
+	event.ID = m.ID
+	event.CreatedAt = m.CreatedAt
+	return nil
+}
+
+func (r *repository) List(ctx context.Context, filter domain.AuditFilter) ([]*domain.AuditEvent, error) {
+	var events []*model.AuditEvent
+	query := r.db.NewSelect().Model(&events).OrderExpr("created_at DESC, id DESC")
+	query = applyFilter(query, filter)
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit)
+	}
+	if filter.Offset > 0 {
+		query = query.Offset(filter.Offset)
+	}
+	if err := query.Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	res := make([]*domain.AuditEvent, len(events))
+	for i, event := range events {
+		res[i] = event.ToDomain()
+	}
+	return res, nil
+}
+
+func (r *repository) Count(ctx context.Context, filter domain.AuditFilter) (int, error) {
+	query := r.db.NewSelect().Model((*model.AuditEvent)(nil))
+	return applyFilter(query, filter).Count(ctx)
+}
+
+func applyFilter(query *bun.SelectQuery, filter domain.AuditFilter) *bun.SelectQuery {
+	if filter.UserID != 0 {
+		query = query.Where("user_id = ?", filter.UserID)
+	}
+	if filter.ActorID != 0 {
+		query = query.Where("actor_id = ?", filter.ActorID)
+	}
+	if filter.Type != "" {
+		query = query.Where("type = ?", string(filter.Type))
+	}
+	if filter.From != nil {
+		query = query.Where("created_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("created_at <= ?", *filter.To)
+	}
+	return query
+}
@@ -0,0 +1,54 @@
+package impersonation
+
+import (
+	"context"
+	"time"
+
+	"github.com/akfaiz/go-vue-starter-kit/internal/domain"
+	"github.com/akfaiz/go-vue-starter-kit/internal/model"
+	"github.com/uptrace/bun"
+)
+
+type repository struct {
+	db *bun.DB
+}
+
+func NewRepository(db *bun.DB) domain.ImpersonationRepository {
+	return &repository{db: db}
+}
+
+func (r *repository) Create(ctx context.Context, impersonation *domain.Impersonation) error {
+	m := &model.Impersonation{
+		ImpersonatorID: impersonation.ImpersonatorID,
+		UserID:         impersonation.UserID,
+		TokenID:        impersonation.TokenID,
+		UserAgent:      impersonation.UserAgent,
+		IPAddress:      impersonation.IPAddress,
+		ExpiresAt:      impersonation.ExpiresAt,
+	}
+	_, err := r.db.NewInsert().Model(m).Returning("*").Exec(ctx)
+	if err != nil {
+		return err
+	}
+	impersonation.ID = m.ID
+	impersonation.CreatedAt = m.CreatedAt
+	return nil
+}
+
+func (r *repository) End(ctx context.Context, tokenID string, endedAt time.Time) error {
+	res, err := r.db.NewUpdate().Model((*model.Impersonation)(nil)).
+		Set("ended_at = ?", endedAt).
+		Where("token_id = ? AND ended_at IS NULL", tokenID).
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return domain.ErrResourceNotFound
+	}
+	return nil
+}
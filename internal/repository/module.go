@@ -1,8 +1,19 @@
 package repository
 
 import (
+	"github.com/akfaiz/go-vue-starter-kit/internal/repository/audit"
+	"github.com/akfaiz/go-vue-starter-kit/internal/repository/impersonation"
+	"github.com/akfaiz/go-vue-starter-kit/internal/repository/passkey"
+	"github.com/akfaiz/go-vue-starter-kit/internal/repository/passwordhistory"
+	"github.com/akfaiz/go-vue-starter-kit/internal/repository/personalaccesstoken"
+	"github.com/akfaiz/go-vue-starter-kit/internal/repository/revokedtoken"
+	"github.com/akfaiz/go-vue-starter-kit/internal/repository/role"
+	"github.com/akfaiz/go-vue-starter-kit/internal/repository/session"
+	"github.com/akfaiz/go-vue-starter-kit/internal/repository/throttle"
 	"github.com/akfaiz/go-vue-starter-kit/internal/repository/user"
+	"github.com/akfaiz/go-vue-starter-kit/internal/repository/useridentity"
 	"github.com/akfaiz/go-vue-starter-kit/internal/repository/usertoken"
+	"github.com/akfaiz/go-vue-starter-kit/internal/repository/webauthnsession"
 	"go.uber.org/fx"
 )
 
@@ -10,5 +21,16 @@ var Module = fx.Module("repository",
 	fx.Provide(
 		user.NewRepository,
 		usertoken.NewRepository,
+		session.NewRepository,
+		passkey.NewRepository,
+		webauthnsession.NewRepository,
+		useridentity.NewRepository,
+		throttle.NewRepository,
+		revokedtoken.NewRepository,
+		personalaccesstoken.NewRepository,
+		role.NewRepository,
+		impersonation.NewRepository,
+		audit.NewRepository,
+		passwordhistory.NewRepository,
 	),
 )
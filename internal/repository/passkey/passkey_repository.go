@@ -0,0 +1,82 @@
+package passkey
+
+import (
+	"context"
+
+	"github.com/akfaiz/go-vue-starter-kit/internal/domain"
+	"github.com/akfaiz/go-vue-starter-kit/internal/model"
+	"github.com/uptrace/bun"
+)
+
+type repository struct {
+	db *bun.DB
+}
+
+func NewRepository(db *bun.DB) domain.PasskeyRepository {
+	return &repository{db: db}
+}
+
+func (r *repository) Create(ctx context.Context, passkey *domain.Passkey) error {
+	m := &model.WebauthnCredential{
+		UserID:          passkey.UserID,
+		Name:            passkey.Name,
+		CredentialID:    passkey.CredentialID,
+		PublicKey:       passkey.PublicKey,
+		AttestationType: passkey.AttestationType,
+		Transports:      passkey.Transports,
+		AAGUID:          passkey.AAGUID,
+		SignCount:       passkey.SignCount,
+		BackupEligible:  passkey.BackupEligible,
+		BackupState:     passkey.BackupState,
+	}
+	_, err := r.db.NewInsert().Model(m).Returning("*").Exec(ctx)
+	if err != nil {
+		return err
+	}
+	passkey.ID = m.ID
+	passkey.CreatedAt = m.CreatedAt
+	return nil
+}
+
+func (r *repository) FindByUserID(ctx context.Context, userID int64) ([]*domain.Passkey, error) {
+	var models []model.WebauthnCredential
+	err := r.db.NewSelect().Model(&models).
+		Where("user_id = ?", userID).
+		Order("created_at ASC").
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	passkeys := make([]*domain.Passkey, len(models))
+	for i := range models {
+		passkeys[i] = models[i].ToDomain()
+	}
+	return passkeys, nil
+}
+
+func (r *repository) UpdateUsage(ctx context.Context, id int64, signCount uint32, backupState bool) error {
+	_, err := r.db.NewUpdate().Model((*model.WebauthnCredential)(nil)).
+		Set("sign_count = ?", signCount).
+		Set("backup_state = ?", backupState).
+		Set("last_used_at = NOW()").
+		Where("id = ?", id).
+		Exec(ctx)
+	return err
+}
+
+func (r *repository) Delete(ctx context.Context, userID, id int64) error {
+	res, err := r.db.NewDelete().Model((*model.WebauthnCredential)(nil)).
+		Where("id = ? AND user_id = ?", id, userID).
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return domain.ErrResourceNotFound
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+package passwordhistory
+
+import (
+	"context"
+
+	"github.com/akfaiz/go-vue-starter-kit/internal/domain"
+	"github.com/akfaiz/go-vue-starter-kit/internal/model"
+	"github.com/uptrace/bun"
+)
+
+type repository struct {
+	db *bun.DB
+}
+
+func NewRepository(db *bun.DB) domain.PasswordHistoryRepository {
+	return &repository{db: db}
+}
+
+func (r *repository) Create(ctx context.Context, userID int64, passwordHash string) error {
+	m := &model.PasswordHistory{
+		UserID:       userID,
+		PasswordHash: passwordHash,
+	}
+	_, err := r.db.NewInsert().Model(m).Exec(ctx)
+	return err
+}
+
+func (r *repository) ListRecent(ctx context.Context, userID int64, limit int) ([]string, error) {
+	var hashes []string
+	err := r.db.NewSelect().Model((*model.PasswordHistory)(nil)).
+		Column("password_hash").
+		Where("user_id = ?", userID).
+		OrderExpr("created_at DESC, id DESC").
+		Limit(limit).
+		Scan(ctx, &hashes)
+	if err != nil {
+		return nil, err
+	}
+	return hashes, nil
+}
+
+func (r *repository) Prune(ctx context.Context, userID int64, keep int) error {
+	query := r.db.NewDelete().Model((*model.PasswordHistory)(nil)).Where("user_id = ?", userID)
+	if keep > 0 {
+		newest := r.db.NewSelect().Model((*model.PasswordHistory)(nil)).
+			Column("id").
+			Where("user_id = ?", userID).
+			OrderExpr("created_at DESC, id DESC").
+			Limit(keep)
+		query = query.Where("id NOT IN (?)", newest)
+	}
+	_, err := query.Exec(ctx)
+	return err
+}
@@ -0,0 +1,90 @@
+package personalaccesstoken
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/akfaiz/go-vue-starter-kit/internal/domain"
+	"github.com/akfaiz/go-vue-starter-kit/internal/model"
+	"github.com/uptrace/bun"
+)
+
+type repository struct {
+	db *bun.DB
+}
+
+func NewRepository(db *bun.DB) domain.PersonalAccessTokenRepository {
+	return &repository{db: db}
+}
+
+func (r *repository) Create(ctx context.Context, token *domain.PersonalAccessToken) error {
+	m := &model.PersonalAccessToken{
+		UserID:    token.UserID,
+		Name:      token.Name,
+		Prefix:    token.Prefix,
+		TokenHash: token.TokenHash,
+		Scopes:    token.Scopes,
+		ExpiresAt: token.ExpiresAt,
+	}
+	_, err := r.db.NewInsert().Model(m).Returning("*").Exec(ctx)
+	if err != nil {
+		return err
+	}
+	token.ID = m.ID
+	token.CreatedAt = m.CreatedAt
+	return nil
+}
+
+func (r *repository) FindByPrefix(ctx context.Context, prefix string) (*domain.PersonalAccessToken, error) {
+	m := new(model.PersonalAccessToken)
+	err := r.db.NewSelect().Model(m).Where("prefix = ?", prefix).Scan(ctx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrResourceNotFound
+		}
+		return nil, err
+	}
+	return m.ToDomain(), nil
+}
+
+func (r *repository) FindByUserID(ctx context.Context, userID int64) ([]*domain.PersonalAccessToken, error) {
+	var models []model.PersonalAccessToken
+	err := r.db.NewSelect().Model(&models).
+		Where("user_id = ?", userID).
+		Order("created_at DESC").
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tokens := make([]*domain.PersonalAccessToken, len(models))
+	for i := range models {
+		tokens[i] = models[i].ToDomain()
+	}
+	return tokens, nil
+}
+
+func (r *repository) TouchLastUsed(ctx context.Context, id int64) error {
+	_, err := r.db.NewUpdate().Model((*model.PersonalAccessToken)(nil)).
+		Set("last_used_at = NOW()").
+		Where("id = ?", id).
+		Exec(ctx)
+	return err
+}
+
+func (r *repository) Delete(ctx context.Context, userID, id int64) error {
+	res, err := r.db.NewDelete().Model((*model.PersonalAccessToken)(nil)).
+		Where("id = ? AND user_id = ?", id, userID).
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return domain.ErrResourceNotFound
+	}
+	return nil
+}
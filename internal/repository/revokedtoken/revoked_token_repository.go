@@ -0,0 +1,52 @@
+package revokedtoken
+
+import (
+	"context"
+	"time"
+
+	"github.com/akfaiz/go-vue-starter-kit/internal/domain"
+	"github.com/akfaiz/go-vue-starter-kit/internal/model"
+	"github.com/uptrace/bun"
+)
+
+type repository struct {
+	db *bun.DB
+}
+
+func NewRepository(db *bun.DB) domain.RevokedTokenRepository {
+	return &repository{db: db}
+}
+
+func (r *repository) Create(ctx context.Context, key string, expiresAt time.Time) error {
+	m := &model.RevokedToken{
+		Key:       key,
+		ExpiresAt: expiresAt,
+	}
+	_, err := r.db.NewInsert().Model(m).
+		On("CONFLICT (key) DO UPDATE").
+		Set("expires_at = GREATEST(?TableAlias.expires_at, EXCLUDED.expires_at)").
+		Exec(ctx)
+	return err
+}
+
+func (r *repository) FindActive(ctx context.Context, keys []string) ([]string, error) {
+	var active []string
+	if len(keys) == 0 {
+		return active, nil
+	}
+	err := r.db.NewSelect().Model((*model.RevokedToken)(nil)).
+		Column("key").
+		Where("key IN (?) AND expires_at > NOW()", bun.In(keys)).
+		Scan(ctx, &active)
+	if err != nil {
+		return nil, err
+	}
+	return active, nil
+}
+
+func (r *repository) DeleteExpired(ctx context.Context) error {
+	_, err := r.db.NewDelete().Model((*model.RevokedToken)(nil)).
+		Where("expires_at <= NOW()").
+		Exec(ctx)
+	return err
+}
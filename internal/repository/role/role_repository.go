@@ -0,0 +1,114 @@
+package role
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/akfaiz/go-vue-starter-kit/internal/domain"
+	"github.com/akfaiz/go-vue-starter-kit/internal/model"
+	"github.com/uptrace/bun"
+)
+
+type repository struct {
+	db *bun.DB
+}
+
+func NewRepository(db *bun.DB) domain.RoleRepository {
+	return &repository{db: db}
+}
+
+func (r *repository) FindAll(ctx context.Context) ([]*domain.Role, error) {
+	var models []model.Role
+	if err := r.db.NewSelect().Model(&models).Order("name ASC").Scan(ctx); err != nil {
+		return nil, err
+	}
+	return r.withPermissions(ctx, models)
+}
+
+func (r *repository) FindByName(ctx context.Context, name string) (*domain.Role, error) {
+	m := new(model.Role)
+	if err := r.db.NewSelect().Model(m).Where("name = ?", name).Scan(ctx); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrResourceNotFound
+		}
+		return nil, err
+	}
+	roles, err := r.withPermissions(ctx, []model.Role{*m})
+	if err != nil {
+		return nil, err
+	}
+	return roles[0], nil
+}
+
+func (r *repository) FindByUserID(ctx context.Context, userID int64) ([]*domain.Role, error) {
+	var models []model.Role
+	err := r.db.NewSelect().Model(&models).
+		Join("JOIN user_roles AS ur ON ur.role_id = role.id").
+		Where("ur.user_id = ?", userID).
+		Order("role.name ASC").
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return r.withPermissions(ctx, models)
+}
+
+func (r *repository) AssignToUser(ctx context.Context, userID, roleID int64) error {
+	_, err := r.db.NewInsert().Model(&model.UserRole{UserID: userID, RoleID: roleID}).
+		On("CONFLICT DO NOTHING").
+		Exec(ctx)
+	return err
+}
+
+func (r *repository) RemoveFromUser(ctx context.Context, userID, roleID int64) error {
+	res, err := r.db.NewDelete().Model((*model.UserRole)(nil)).
+		Where("user_id = ? AND role_id = ?", userID, roleID).
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return domain.ErrResourceNotFound
+	}
+	return nil
+}
+
+// withPermissions loads the permission names of the roles in one query.
+func (r *repository) withPermissions(ctx context.Context, models []model.Role) ([]*domain.Role, error) {
+	roles := make([]*domain.Role, len(models))
+	if len(models) == 0 {
+		return roles, nil
+	}
+	ids := make([]int64, len(models))
+	for i := range models {
+		ids[i] = models[i].ID
+	}
+
+	var rows []struct {
+		RoleID int64  `bun:"role_id"`
+		Name   string `bun:"name"`
+	}
+	err := r.db.NewSelect().
+		TableExpr("role_permissions AS rp").
+		Join("JOIN permissions AS p ON p.id = rp.permission_id").
+		ColumnExpr("rp.role_id, p.name").
+		Where("rp.role_id IN (?)", bun.In(ids)).
+		Order("p.name ASC").
+		Scan(ctx, &rows)
+	if err != nil {
+		return nil, err
+	}
+	permissions := make(map[int64][]string, len(models))
+	for _, row := range rows {
+		permissions[row.RoleID] = append(permissions[row.RoleID], row.Name)
+	}
+	for i := range models {
+		roles[i] = models[i].ToDomain(permissions[models[i].ID])
+	}
+	return roles, nil
+}
@@ -0,0 +1,128 @@
+package session
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/akfaiz/go-vue-starter-kit/internal/domain"
+	"github.com/akfaiz/go-vue-starter-kit/internal/model"
+	"github.com/uptrace/bun"
+)
+
+type repository struct {
+	db *bun.DB
+}
+
+func NewRepository(db *bun.DB) domain.SessionRepository {
+	return &repository{db: db}
+}
+
+func (r *repository) Create(ctx context.Context, session *domain.Session) error {
+	m := &model.Session{
+		ID:        session.ID,
+		UserID:    session.UserID,
+		TokenHash: session.TokenHash,
+		UserAgent: session.UserAgent,
+		IPAddress: session.IPAddress,
+		ExpiresAt: session.ExpiresAt,
+	}
+	_, err := r.db.NewInsert().Model(m).Returning("*").Exec(ctx)
+	if err != nil {
+		return err
+	}
+	session.LastUsedAt = m.LastUsedAt
+	session.AuthenticatedAt = m.AuthenticatedAt
+	session.CreatedAt = m.CreatedAt
+	return nil
+}
+
+func (r *repository) FindByID(ctx context.Context, id string) (*domain.Session, error) {
+	m := new(model.Session)
+	err := r.db.NewSelect().Model(m).Where("id = ?", id).Scan(ctx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrResourceNotFound
+		}
+		return nil, err
+	}
+	return m.ToDomain(), nil
+}
+
+func (r *repository) FindActiveByUserID(ctx context.Context, userID int64) ([]*domain.Session, error) {
+	var models []model.Session
+	err := r.db.NewSelect().Model(&models).
+		Where("user_id = ? AND revoked_at IS NULL AND expires_at > NOW()", userID).
+		Order("last_used_at DESC").
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sessions := make([]*domain.Session, len(models))
+	for i := range models {
+		sessions[i] = models[i].ToDomain()
+	}
+	return sessions, nil
+}
+
+func (r *repository) Rotate(ctx context.Context, id, currentHash, newHash string, expiresAt time.Time) error {
+	res, err := r.db.NewUpdate().Model((*model.Session)(nil)).
+		Set("token_hash = ?", newHash).
+		Set("expires_at = ?", expiresAt).
+		Set("last_used_at = NOW()").
+		Where("id = ? AND token_hash = ? AND revoked_at IS NULL", id, currentHash).
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return domain.ErrResourceNotFound
+	}
+	return nil
+}
+
+func (r *repository) Revoke(ctx context.Context, id string) error {
+	_, err := r.db.NewUpdate().Model((*model.Session)(nil)).
+		Set("revoked_at = NOW()").
+		Where("id = ? AND revoked_at IS NULL", id).
+		Exec(ctx)
+	return err
+}
+
+func (r *repository) MarkAuthenticated(ctx context.Context, id string, at time.Time) error {
+	res, err := r.db.NewUpdate().Model((*model.Session)(nil)).
+		Set("authenticated_at = ?", at).
+		Where("id = ? AND revoked_at IS NULL AND expires_at > NOW()", id).
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return domain.ErrResourceNotFound
+	}
+	return nil
+}
+
+func (r *repository) RevokeByUserID(ctx context.Context, userID int64, exceptID string) ([]string, error) {
+	var ids []string
+	query := r.db.NewUpdate().Model((*model.Session)(nil)).
+		Set("revoked_at = NOW()").
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Returning("id")
+	if exceptID != "" {
+		query = query.Where("id <> ?", exceptID)
+	}
+	if err := query.Scan(ctx, &ids); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
@@ -0,0 +1,103 @@
+package throttle
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/akfaiz/go-vue-starter-kit/internal/domain"
+)
+
+// memoryStore keeps counters in process. It suits a single instance and tests.
+type memoryStore struct {
+	mu       sync.Mutex
+	counters map[string]*domain.ThrottleCounter
+}
+
+func NewMemoryStore() domain.ThrottleStore {
+	return &memoryStore{counters: make(map[string]*domain.ThrottleCounter)}
+}
+
+func (s *memoryStore) Hit(_ context.Context, key string, ttl time.Duration) (*domain.ThrottleCounter, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	counter := s.active(key, now)
+	if counter == nil {
+		counter = &domain.ThrottleCounter{Key: key, ExpiresAt: now.Add(ttl)}
+		s.counters[key] = counter
+	}
+	counter.Hits++
+	return copyCounter(counter), nil
+}
+
+func (s *memoryStore) Get(_ context.Context, key string) (*domain.ThrottleCounter, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	counter := s.active(key, time.Now())
+	if counter == nil {
+		return nil, domain.ErrResourceNotFound
+	}
+	return copyCounter(counter), nil
+}
+
+func (s *memoryStore) Block(_ context.Context, key string, until time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	counter := s.active(key, time.Now())
+	if counter == nil {
+		counter = &domain.ThrottleCounter{Key: key, ExpiresAt: until}
+		s.counters[key] = counter
+	}
+	counter.BlockedUntil = &until
+	if until.After(counter.ExpiresAt) {
+		counter.ExpiresAt = until
+	}
+	return nil
+}
+
+func (s *memoryStore) Reset(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.counters, key)
+	return nil
+}
+
+func (s *memoryStore) DeleteExpired(_ context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	for key, counter := range s.counters {
+		if !counter.ExpiresAt.After(now) {
+			delete(s.counters, key)
+		}
+	}
+	return nil
+}
+
+// active returns the unexpired counter for key, dropping an expired one.
+func (s *memoryStore) active(key string, now time.Time) *domain.ThrottleCounter {
+	counter, ok := s.counters[key]
+	if !ok {
+		return nil
+	}
+	if !counter.ExpiresAt.After(now) {
+		delete(s.counters, key)
+		return nil
+	}
+	return counter
+}
+
+func copyCounter(counter *domain.ThrottleCounter) *domain.ThrottleCounter {
+	c := *counter
+	if counter.BlockedUntil != nil {
+		until := *counter.BlockedUntil
+		c.BlockedUntil = &until
+	}
+	return &c
+}
@@ -0,0 +1,83 @@
+package throttle
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/akfaiz/go-vue-starter-kit/internal/domain"
+	"github.com/akfaiz/go-vue-starter-kit/internal/model"
+	"github.com/uptrace/bun"
+)
+
+type postgresStore struct {
+	db *bun.DB
+}
+
+func NewPostgresStore(db *bun.DB) domain.ThrottleStore {
+	return &postgresStore{db: db}
+}
+
+// Hit is a single upsert so concurrent instances never lose an increment.
+func (s *postgresStore) Hit(ctx context.Context, key string, ttl time.Duration) (*domain.ThrottleCounter, error) {
+	now := time.Now()
+	m := &model.Throttle{
+		Key:       key,
+		Hits:      1,
+		ExpiresAt: now.Add(ttl),
+	}
+	_, err := s.db.NewInsert().Model(m).
+		On("CONFLICT (key) DO UPDATE").
+		Set("hits = CASE WHEN ?TableAlias.expires_at <= ? THEN 1 ELSE ?TableAlias.hits + 1 END", now).
+		Set("blocked_until = CASE WHEN ?TableAlias.expires_at <= ? THEN NULL ELSE ?TableAlias.blocked_until END", now).
+		Set("expires_at = CASE WHEN ?TableAlias.expires_at <= ? THEN EXCLUDED.expires_at ELSE ?TableAlias.expires_at END", now).
+		Returning("*").
+		Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return m.ToDomain(), nil
+}
+
+func (s *postgresStore) Get(ctx context.Context, key string) (*domain.ThrottleCounter, error) {
+	m := new(model.Throttle)
+	err := s.db.NewSelect().Model(m).
+		Where("key = ? AND expires_at > ?", key, time.Now()).
+		Scan(ctx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrResourceNotFound
+		}
+		return nil, err
+	}
+	return m.ToDomain(), nil
+}
+
+func (s *postgresStore) Block(ctx context.Context, key string, until time.Time) error {
+	m := &model.Throttle{
+		Key:          key,
+		BlockedUntil: &until,
+		ExpiresAt:    until,
+	}
+	_, err := s.db.NewInsert().Model(m).
+		On("CONFLICT (key) DO UPDATE").
+		Set("blocked_until = EXCLUDED.blocked_until").
+		Set("expires_at = GREATEST(?TableAlias.expires_at, EXCLUDED.expires_at)").
+		Exec(ctx)
+	return err
+}
+
+func (s *postgresStore) Reset(ctx context.Context, key string) error {
+	_, err := s.db.NewDelete().Model((*model.Throttle)(nil)).
+		Where("key = ?", key).
+		Exec(ctx)
+	return err
+}
+
+func (s *postgresStore) DeleteExpired(ctx context.Context) error {
+	_, err := s.db.NewDelete().Model((*model.Throttle)(nil)).
+		Where("expires_at <= ?", time.Now()).
+		Exec(ctx)
+	return err
+}
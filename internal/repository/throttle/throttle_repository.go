@@ -0,0 +1,21 @@
+package throttle
+
+import (
+	"fmt"
+
+	"github.com/akfaiz/go-vue-starter-kit/internal/config"
+	"github.com/akfaiz/go-vue-starter-kit/internal/domain"
+	"github.com/uptrace/bun"
+)
+
+// NewRepository returns the throttle store selected by THROTTLE_STORE.
+func NewRepository(cfg config.Config, db *bun.DB) (domain.ThrottleStore, error) {
+	switch cfg.Throttle.Store {
+	case "", "postgres":
+		return NewPostgresStore(db), nil
+	case "memory":
+		return NewMemoryStore(), nil
+	default:
+		return nil, fmt.Errorf("unknown throttle store %q", cfg.Throttle.Store)
+	}
+}
@@ -22,9 +22,11 @@ func NewRepository(db *bun.DB) domain.UserRepository {
 
 func (r *repository) Create(ctx context.Context, user *domain.User) error {
 	m := &model.User{
-		Name:     user.Name,
-		Email:    user.Email,
-		Password: user.Password,
+		Name:            user.Name,
+		Email:           user.Email,
+		Password:        user.Password,
+		EmailVerifiedAt: user.EmailVerifiedAt,
+		Status:          string(user.Status),
 	}
 	_, err := r.db.NewInsert().Model(m).Exec(ctx)
 	if err != nil {
@@ -37,6 +39,7 @@ func (r *repository) Create(ctx context.Context, user *domain.User) error {
 		return err
 	}
 	user.ID = m.ID
+	user.Status = domain.UserStatus(m.Status)
 	user.CreatedAt = m.CreatedAt
 	user.UpdatedAt = m.UpdatedAt
 	return nil
@@ -83,7 +86,90 @@ func (r *repository) Update(ctx context.Context, id int64, data *domain.UserUpda
 	return nil
 }
 
+func (r *repository) UseTwoFactorStep(ctx context.Context, id int64, step int64) (bool, error) {
+	res, err := r.db.NewUpdate().Model((*model.User)(nil)).
+		Set("two_factor_last_step = ?", step).
+		Where("id = ?", id).
+		Where("two_factor_last_step IS NULL OR two_factor_last_step < ?", step).
+		Exec(ctx)
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	return n > 0, err
+}
+
+func (r *repository) UseTwoFactorRecoveryCode(ctx context.Context, id int64, hashedCode string) (bool, error) {
+	res, err := r.db.NewUpdate().Model((*model.User)(nil)).
+		Set("two_factor_recovery_codes = two_factor_recovery_codes - ?::text", hashedCode).
+		Where("id = ?", id).
+		Where("two_factor_recovery_codes @> jsonb_build_array(?::text)", hashedCode).
+		Exec(ctx)
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	return n > 0, err
+}
+
 func (r *repository) Delete(ctx context.Context, id int64) error {
 	_, err := r.db.NewDelete().Model(&model.User{ID: id}).WherePK().Exec(ctx)
 	return err
 }
+
+func (r *repository) List(ctx context.Context, filter domain.UserFilter) ([]*domain.User, error) {
+	var users []*model.User
+	query := r.db.NewSelect().Model(&users).OrderExpr("created_at DESC, id DESC")
+	query = applyFilter(query, filter)
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit)
+	}
+	if filter.Offset > 0 {
+		query = query.Offset(filter.Offset)
+	}
+	if err := query.Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	res := make([]*domain.User, len(users))
+	for i, user := range users {
+		res[i] = user.ToDomain()
+	}
+	return res, nil
+}
+
+func (r *repository) Count(ctx context.Context, filter domain.UserFilter) (int, error) {
+	query := r.db.NewSelect().Model((*model.User)(nil))
+	return applyFilter(query, filter).Count(ctx)
+}
+
+func applyFilter(query *bun.SelectQuery, filter domain.UserFilter) *bun.SelectQuery {
+	if filter.Search != "" {
+		pattern := "%" + escapeLike(filter.Search) + "%"
+		query = query.WhereGroup(" AND ", func(q *bun.SelectQuery) *bun.SelectQuery {
+			return q.Where("name ILIKE ?", pattern).WhereOr("email ILIKE ?", pattern)
+		})
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", string(filter.Status))
+	}
+	if filter.Verified != nil {
+		if *filter.Verified {
+			query = query.Where("email_verified_at IS NOT NULL")
+		} else {
+			query = query.Where("email_verified_at IS NULL")
+		}
+	}
+	if filter.CreatedFrom != nil {
+		query = query.Where("created_at >= ?", *filter.CreatedFrom)
+	}
+	if filter.CreatedTo != nil {
+		query = query.Where("created_at <= ?", *filter.CreatedTo)
+	}
+	return query
+}
+
+// escapeLike makes the wildcards in user input match literally.
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
+}
@@ -0,0 +1,82 @@
+package useridentity
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/akfaiz/go-vue-starter-kit/internal/domain"
+	"github.com/akfaiz/go-vue-starter-kit/internal/model"
+	"github.com/uptrace/bun"
+)
+
+type repository struct {
+	db *bun.DB
+}
+
+func NewRepository(db *bun.DB) domain.UserIdentityRepository {
+	return &repository{db: db}
+}
+
+func (r *repository) Create(ctx context.Context, identity *domain.UserIdentity) error {
+	m := &model.UserIdentity{
+		UserID:   identity.UserID,
+		Provider: identity.Provider,
+		Subject:  identity.Subject,
+		Email:    identity.Email,
+	}
+	_, err := r.db.NewInsert().Model(m).Returning("*").Exec(ctx)
+	if err != nil {
+		return err
+	}
+	identity.ID = m.ID
+	identity.CreatedAt = m.CreatedAt
+	return nil
+}
+
+func (r *repository) FindByProviderSubject(ctx context.Context, provider, subject string) (*domain.UserIdentity, error) {
+	m := new(model.UserIdentity)
+	err := r.db.NewSelect().Model(m).
+		Where("provider = ? AND subject = ?", provider, subject).
+		Scan(ctx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrResourceNotFound
+		}
+		return nil, err
+	}
+	return m.ToDomain(), nil
+}
+
+func (r *repository) FindByUserID(ctx context.Context, userID int64) ([]*domain.UserIdentity, error) {
+	var models []model.UserIdentity
+	err := r.db.NewSelect().Model(&models).
+		Where("user_id = ?", userID).
+		Order("created_at ASC").
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	identities := make([]*domain.UserIdentity, len(models))
+	for i := range models {
+		identities[i] = models[i].ToDomain()
+	}
+	return identities, nil
+}
+
+func (r *repository) Delete(ctx context.Context, userID int64, provider string) error {
+	res, err := r.db.NewDelete().Model((*model.UserIdentity)(nil)).
+		Where("user_id = ? AND provider = ?", userID, provider).
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return domain.ErrResourceNotFound
+	}
+	return nil
+}
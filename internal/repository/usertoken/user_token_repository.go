@@ -23,10 +23,11 @@ func (r *repository) Create(ctx context.Context, token *domain.UserToken) error
 		UserID:    token.UserID,
 		Token:     token.Token,
 		TokenType: string(token.TokenType),
+		Email:     token.Email,
 		ExpiresAt: token.ExpiresAt,
 	}
 	_, err := r.db.NewInsert().Model(m).
-		On("CONFLICT (user_id, token_type) DO UPDATE SET token = EXCLUDED.token, expires_at = EXCLUDED.expires_at").
+		On("CONFLICT (user_id, token_type) DO UPDATE SET token = EXCLUDED.token, email = EXCLUDED.email, expires_at = EXCLUDED.expires_at").
 		Exec(ctx)
 	if err != nil {
 		return err
@@ -54,3 +55,20 @@ func (r *repository) Delete(ctx context.Context, userID int64, tokenType domain.
 		Exec(ctx)
 	return err
 }
+
+func (r *repository) Consume(ctx context.Context, id int64) error {
+	res, err := r.db.NewDelete().Model((*model.UserToken)(nil)).
+		Where("id = ?", id).
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return domain.ErrResourceNotFound
+	}
+	return nil
+}
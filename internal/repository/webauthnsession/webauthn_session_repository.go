@@ -0,0 +1,56 @@
+package webauthnsession
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/akfaiz/go-vue-starter-kit/internal/domain"
+	"github.com/akfaiz/go-vue-starter-kit/internal/model"
+	"github.com/uptrace/bun"
+)
+
+type repository struct {
+	db *bun.DB
+}
+
+func NewRepository(db *bun.DB) domain.WebAuthnSessionRepository {
+	return &repository{db: db}
+}
+
+func (r *repository) Create(ctx context.Context, session *domain.WebAuthnSession) error {
+	m := &model.WebauthnSession{
+		ID:        session.ID,
+		UserID:    session.UserID,
+		Data:      session.Data,
+		ExpiresAt: session.ExpiresAt,
+	}
+	_, err := r.db.NewInsert().Model(m).Returning("*").Exec(ctx)
+	if err != nil {
+		return err
+	}
+	session.CreatedAt = m.CreatedAt
+	return nil
+}
+
+func (r *repository) Consume(ctx context.Context, id string) (*domain.WebAuthnSession, error) {
+	m := new(model.WebauthnSession)
+	err := r.db.NewDelete().Model(m).
+		Where("id = ? AND expires_at > NOW()", id).
+		Returning("*").
+		Scan(ctx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrResourceNotFound
+		}
+		return nil, err
+	}
+	return m.ToDomain(), nil
+}
+
+func (r *repository) DeleteExpired(ctx context.Context) error {
+	_, err := r.db.NewDelete().Model((*model.WebauthnSession)(nil)).
+		Where("expires_at <= NOW()").
+		Exec(ctx)
+	return err
+}
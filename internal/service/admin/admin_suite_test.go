@@ -0,0 +1,13 @@
+package admin_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestAdminService(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Admin Service Suite")
+}
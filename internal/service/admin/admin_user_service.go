@@ -0,0 +1,141 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/aarondl/opt/omit"
+	"github.com/aarondl/opt/omitnull"
+	"github.com/akfaiz/go-vue-starter-kit/internal/domain"
+	"github.com/akfaiz/go-vue-starter-kit/internal/errdefs"
+	"github.com/akfaiz/go-vue-starter-kit/internal/validator"
+	"github.com/invopop/ctxi18n/i18n"
+)
+
+type userService struct {
+	userRepo          domain.UserRepository
+	sessionService    domain.SessionService
+	authService       domain.AuthService
+	suspensionService domain.SuspensionService
+	auditLogger       domain.AuditLogger
+}
+
+func NewUserService(
+	userRepo domain.UserRepository,
+	sessionService domain.SessionService,
+	authService domain.AuthService,
+	suspensionService domain.SuspensionService,
+	auditLogger domain.AuditLogger,
+) domain.AdminUserService {
+	return &userService{
+		userRepo:          userRepo,
+		sessionService:    sessionService,
+		authService:       authService,
+		suspensionService: suspensionService,
+		auditLogger:       auditLogger,
+	}
+}
+
+func (s *userService) List(ctx context.Context, filter domain.UserFilter) ([]*domain.User, int, error) {
+	if filter.Status != "" && !filter.Status.IsValid() {
+		return nil, 0, validator.NewError("status", "The selected status is invalid.")
+	}
+	total, err := s.userRepo.Count(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+	if total == 0 {
+		return []*domain.User{}, 0, nil
+	}
+	users, err := s.userRepo.List(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+	return users, total, nil
+}
+
+func (s *userService) FindByID(ctx context.Context, id int64) (*domain.User, error) {
+	return s.userRepo.FindByID(ctx, id)
+}
+
+func (s *userService) Update(ctx context.Context, id int64, data *domain.AdminUserUpdate) (*domain.User, error) {
+	user, err := s.userRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	update := &domain.UserUpdate{}
+	changes := map[string]any{}
+	if user.Name != data.Name {
+		update.Name = omit.From(data.Name)
+		changes["name"] = map[string]any{"old": user.Name, "new": data.Name}
+		user.Name = data.Name
+	}
+	if user.Email != data.Email {
+		update.Email = omit.From(data.Email)
+		changes["email"] = map[string]any{"old": user.Email, "new": data.Email}
+		user.Email = data.Email
+	}
+	if user.IsVerified() != data.Verified {
+		changes["verified"] = map[string]any{"old": user.IsVerified(), "new": data.Verified}
+		if data.Verified {
+			now := time.Now()
+			update.EmailVerifiedAt = omitnull.From(now)
+			user.EmailVerifiedAt = &now
+		} else {
+			update.EmailVerifiedAt = omitnull.FromPtr[time.Time](nil)
+			user.EmailVerifiedAt = nil
+		}
+	}
+	if update.IsEmpty() {
+		return user, nil
+	}
+
+	if err := s.userRepo.Update(ctx, id, update); err != nil {
+		if errors.Is(err, domain.ErrEmailAlreadyExists) {
+			return nil, validator.NewError("email", "Email already exists")
+		}
+		return nil, err
+	}
+	s.auditLogger.Log(ctx, domain.AuditUserUpdated, id, map[string]any{"changes": changes})
+	return user, nil
+}
+
+func (s *userService) SendPasswordReset(ctx context.Context, id int64) error {
+	user, err := s.userRepo.FindByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	return s.authService.SendForgotPasswordEmail(ctx, user.Email)
+}
+
+func (s *userService) Suspend(ctx context.Context, actorID, id int64, reason string) error {
+	if actorID == id {
+		return errdefs.ErrForbidden(i18n.T(ctx, "admin.self_suspend"))
+	}
+	return s.suspensionService.Suspend(ctx, id, reason)
+}
+
+func (s *userService) Unsuspend(ctx context.Context, id int64) error {
+	return s.suspensionService.Unsuspend(ctx, id)
+}
+
+func (s *userService) Delete(ctx context.Context, actorID, id int64) error {
+	if actorID == id {
+		return errdefs.ErrForbidden(i18n.T(ctx, "admin.self_delete"))
+	}
+	user, err := s.userRepo.FindByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	// Revoke first so the access tokens still in circulation stop working too.
+	if err := s.sessionService.RevokeAll(ctx, id, ""); err != nil {
+		return err
+	}
+	if err := s.userRepo.Delete(ctx, id); err != nil {
+		return err
+	}
+	s.auditLogger.Log(ctx, domain.AuditUserDeleted, id, map[string]any{"email": user.Email})
+	return nil
+}
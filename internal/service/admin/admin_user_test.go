@@ -0,0 +1,184 @@
+package admin_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/akfaiz/go-vue-starter-kit/internal/domain"
+	"github.com/akfaiz/go-vue-starter-kit/internal/errdefs"
+	"github.com/akfaiz/go-vue-starter-kit/internal/mocks"
+	"github.com/akfaiz/go-vue-starter-kit/internal/service/admin"
+	"github.com/akfaiz/go-vue-starter-kit/internal/validator"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+)
+
+var _ = Describe("Admin User Service", Label("unit", "usecase"), func() {
+	var (
+		userRepoMock      *mocks.MockUserRepository
+		sessionSvcMock    *mocks.MockSessionService
+		authSvcMock       *mocks.MockAuthService
+		suspensionSvcMock *mocks.MockSuspensionService
+		auditLoggerMock   *mocks.MockAuditLogger
+		svc               domain.AdminUserService
+
+		ctx  context.Context
+		user *domain.User
+	)
+	BeforeEach(func() {
+		ctrl := gomock.NewController(GinkgoT())
+		userRepoMock = mocks.NewMockUserRepository(ctrl)
+		sessionSvcMock = mocks.NewMockSessionService(ctrl)
+		authSvcMock = mocks.NewMockAuthService(ctrl)
+		suspensionSvcMock = mocks.NewMockSuspensionService(ctrl)
+		auditLoggerMock = mocks.NewMockAuditLogger(ctrl)
+		auditLoggerMock.EXPECT().Log(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+		svc = admin.NewUserService(userRepoMock, sessionSvcMock, authSvcMock, suspensionSvcMock, auditLoggerMock)
+
+		ctx = context.Background()
+		user = &domain.User{ID: 2, Name: "Jane Doe", Email: "jane@example.com"}
+
+		DeferCleanup(func() {
+			ctrl.Finish()
+		})
+	})
+
+	expectForbidden := func(err error) {
+		var appErr *errdefs.AppError
+		Expect(errors.As(err, &appErr)).To(BeTrue())
+		Expect(appErr.Status).To(Equal(403))
+	}
+
+	Describe("List", func() {
+		var filter domain.UserFilter
+		BeforeEach(func() {
+			filter = domain.UserFilter{Search: "jane", Limit: 20}
+		})
+		It("should return the page and the total", func() {
+			userRepoMock.EXPECT().Count(ctx, filter).Return(21, nil)
+			userRepoMock.EXPECT().List(ctx, filter).Return([]*domain.User{user}, nil)
+
+			users, total, err := svc.List(ctx, filter)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(total).To(Equal(21))
+			Expect(users).To(Equal([]*domain.User{user}))
+		})
+		It("should reject an unknown status", func() {
+			filter.Status = "banned"
+
+			_, _, err := svc.List(ctx, filter)
+
+			var validationErr *validator.ValidationError
+			Expect(errors.As(err, &validationErr)).To(BeTrue())
+			Expect(validationErr.First().Field).To(Equal("status"))
+		})
+		It("should filter by every status", func() {
+			for _, status := range []domain.UserStatus{domain.UserStatusActive, domain.UserStatusSuspended, domain.UserStatusPending} {
+				filter.Status = status
+				userRepoMock.EXPECT().Count(ctx, filter).Return(0, nil)
+
+				_, _, err := svc.List(ctx, filter)
+
+				Expect(err).NotTo(HaveOccurred())
+			}
+		})
+		It("should skip the list query when nothing matches", func() {
+			userRepoMock.EXPECT().Count(ctx, filter).Return(0, nil)
+
+			users, total, err := svc.List(ctx, filter)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(total).To(BeZero())
+			Expect(users).To(BeEmpty())
+		})
+	})
+
+	Describe("Update", func() {
+		It("should only write the changed fields", func() {
+			userRepoMock.EXPECT().FindByID(ctx, int64(2)).Return(user, nil)
+			userRepoMock.EXPECT().Update(ctx, int64(2), gomock.Any()).DoAndReturn(func(_ context.Context, _ int64, update *domain.UserUpdate) error {
+				Expect(update.Name.IsUnset()).To(BeTrue())
+				Expect(update.Email.MustGet()).To(Equal("jane.doe@example.com"))
+				Expect(update.EmailVerifiedAt.IsValue()).To(BeTrue())
+				return nil
+			})
+
+			updated, err := svc.Update(ctx, 2, &domain.AdminUserUpdate{
+				Name:     "Jane Doe",
+				Email:    "jane.doe@example.com",
+				Verified: true,
+			})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(updated.Email).To(Equal("jane.doe@example.com"))
+			Expect(updated.IsVerified()).To(BeTrue())
+		})
+		It("should clear the verification", func() {
+			verifiedAt := time.Now()
+			user.EmailVerifiedAt = &verifiedAt
+			userRepoMock.EXPECT().FindByID(ctx, int64(2)).Return(user, nil)
+			userRepoMock.EXPECT().Update(ctx, int64(2), gomock.Any()).DoAndReturn(func(_ context.Context, _ int64, update *domain.UserUpdate) error {
+				Expect(update.EmailVerifiedAt.IsNull()).To(BeTrue())
+				return nil
+			})
+
+			updated, err := svc.Update(ctx, 2, &domain.AdminUserUpdate{Name: user.Name, Email: user.Email})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(updated.IsVerified()).To(BeFalse())
+		})
+		It("should report a taken email on the email field", func() {
+			userRepoMock.EXPECT().FindByID(ctx, int64(2)).Return(user, nil)
+			userRepoMock.EXPECT().Update(ctx, int64(2), gomock.Any()).Return(domain.ErrEmailAlreadyExists)
+
+			_, err := svc.Update(ctx, 2, &domain.AdminUserUpdate{Name: user.Name, Email: "taken@example.com"})
+
+			var validationErr *validator.ValidationError
+			Expect(errors.As(err, &validationErr)).To(BeTrue())
+			Expect(validationErr.First().Field).To(Equal("email"))
+		})
+	})
+
+	Describe("SendPasswordReset", func() {
+		It("should send the reset email to the user's address", func() {
+			userRepoMock.EXPECT().FindByID(ctx, int64(2)).Return(user, nil)
+			authSvcMock.EXPECT().SendForgotPasswordEmail(ctx, "jane@example.com").Return(nil)
+
+			Expect(svc.SendPasswordReset(ctx, 2)).To(Succeed())
+		})
+	})
+
+	Describe("Suspend", func() {
+		It("should suspend the user with the reason", func() {
+			suspensionSvcMock.EXPECT().Suspend(ctx, int64(2), "Spam").Return(nil)
+
+			Expect(svc.Suspend(ctx, 1, 2, "Spam")).To(Succeed())
+		})
+		It("should refuse to suspend the acting administrator", func() {
+			expectForbidden(svc.Suspend(ctx, 2, 2, ""))
+		})
+	})
+
+	Describe("Delete", func() {
+		It("should revoke the sessions before deleting", func() {
+			gomock.InOrder(
+				userRepoMock.EXPECT().FindByID(ctx, int64(2)).Return(user, nil),
+				sessionSvcMock.EXPECT().RevokeAll(ctx, int64(2), "").Return(nil),
+				userRepoMock.EXPECT().Delete(ctx, int64(2)).Return(nil),
+			)
+
+			Expect(svc.Delete(ctx, 1, 2)).To(Succeed())
+		})
+		It("should report a missing user", func() {
+			userRepoMock.EXPECT().FindByID(ctx, int64(3)).Return(nil, domain.ErrResourceNotFound)
+
+			Expect(svc.Delete(ctx, 1, 3)).To(MatchError(domain.ErrResourceNotFound))
+		})
+		It("should refuse to delete the acting administrator", func() {
+			expectForbidden(svc.Delete(ctx, 1, 1))
+		})
+	})
+})
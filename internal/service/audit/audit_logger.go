@@ -0,0 +1,56 @@
+package audit
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/akfaiz/go-vue-starter-kit/internal/domain"
+)
+
+type logger struct {
+	auditRepo domain.AuditRepository
+}
+
+func NewLogger(auditRepo domain.AuditRepository) domain.AuditLogger {
+	return &logger{auditRepo: auditRepo}
+}
+
+func (l *logger) Log(ctx context.Context, eventType domain.AuditEventType, userID int64, metadata map[string]any) {
+	client := domain.ClientInfoFromContext(ctx)
+	event := &domain.AuditEvent{
+		Type:      eventType,
+		ActorID:   actorID(ctx),
+		IPAddress: client.IPAddress,
+		UserAgent: client.UserAgent,
+		RequestID: client.RequestID,
+		Metadata:  metadata,
+	}
+	if userID != 0 {
+		event.UserID = &userID
+	}
+
+	if err := l.auditRepo.Create(ctx, event); err != nil {
+		slog.ErrorContext(ctx, "failed to write audit event",
+			slog.String("type", string(eventType)),
+			slog.Int64("user_id", userID),
+			slog.Any("error", err),
+		)
+	}
+}
+
+// actorID returns the signed in user performing the request. While
+// impersonating, that is the impersonator rather than the token subject.
+func actorID(ctx context.Context) *int64 {
+	claims := domain.ClaimsFromContext(ctx)
+	if claims == nil {
+		return nil
+	}
+	id := claims.ID
+	if claims.IsImpersonated() {
+		id = claims.Actor.ID
+	}
+	if id == 0 {
+		return nil
+	}
+	return &id
+}
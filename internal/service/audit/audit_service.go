@@ -0,0 +1,30 @@
+package audit
+
+import (
+	"context"
+
+	"github.com/akfaiz/go-vue-starter-kit/internal/domain"
+)
+
+type service struct {
+	auditRepo domain.AuditRepository
+}
+
+func NewService(auditRepo domain.AuditRepository) domain.AuditService {
+	return &service{auditRepo: auditRepo}
+}
+
+func (s *service) List(ctx context.Context, filter domain.AuditFilter) ([]*domain.AuditEvent, int, error) {
+	total, err := s.auditRepo.Count(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+	if total == 0 {
+		return []*domain.AuditEvent{}, 0, nil
+	}
+	events, err := s.auditRepo.List(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+	return events, total, nil
+}
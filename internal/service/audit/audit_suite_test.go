@@ -0,0 +1,13 @@
+package audit_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestAuditService(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Audit Service Suite")
+}
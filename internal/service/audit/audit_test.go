@@ -0,0 +1,104 @@
+package audit_test
+
+import (
+	"context"
+	"errors"
+
+	"github.com/akfaiz/go-vue-starter-kit/internal/domain"
+	"github.com/akfaiz/go-vue-starter-kit/internal/mocks"
+	"github.com/akfaiz/go-vue-starter-kit/internal/service/audit"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+)
+
+var _ = Describe("Audit", Label("unit", "usecase"), func() {
+	var (
+		auditRepoMock *mocks.MockAuditRepository
+		logger        domain.AuditLogger
+		svc           domain.AuditService
+
+		ctx context.Context
+	)
+	BeforeEach(func() {
+		ctrl := gomock.NewController(GinkgoT())
+		auditRepoMock = mocks.NewMockAuditRepository(ctrl)
+		logger = audit.NewLogger(auditRepoMock)
+		svc = audit.NewService(auditRepoMock)
+
+		ctx = domain.ContextWithClientInfo(context.Background(), domain.ClientInfo{
+			IPAddress: "203.0.113.7",
+			UserAgent: "Mozilla/5.0",
+			RequestID: "req-1",
+		})
+
+		DeferCleanup(func() {
+			ctrl.Finish()
+		})
+	})
+
+	Describe("Log", func() {
+		It("should record the client and the signed in actor", func() {
+			ctx = domain.ContextWithClaims(ctx, &domain.JWTClaims{ID: 1})
+			auditRepoMock.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, event *domain.AuditEvent) error {
+				Expect(event.Type).To(Equal(domain.AuditPasswordChanged))
+				Expect(*event.ActorID).To(Equal(int64(1)))
+				Expect(*event.UserID).To(Equal(int64(1)))
+				Expect(event.IPAddress).To(Equal("203.0.113.7"))
+				Expect(event.UserAgent).To(Equal("Mozilla/5.0"))
+				Expect(event.RequestID).To(Equal("req-1"))
+				return nil
+			})
+
+			logger.Log(ctx, domain.AuditPasswordChanged, 1, nil)
+		})
+		It("should attribute an impersonated request to the impersonator", func() {
+			ctx = domain.ContextWithClaims(ctx, &domain.JWTClaims{ID: 2, Actor: &domain.JWTActor{ID: 1}})
+			auditRepoMock.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, event *domain.AuditEvent) error {
+				Expect(*event.ActorID).To(Equal(int64(1)))
+				Expect(*event.UserID).To(Equal(int64(2)))
+				return nil
+			})
+
+			logger.Log(ctx, domain.AuditEmailChanged, 2, map[string]any{"new_email": "jane@example.com"})
+		})
+		It("should leave the actor and user empty when unknown", func() {
+			auditRepoMock.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, event *domain.AuditEvent) error {
+				Expect(event.ActorID).To(BeNil())
+				Expect(event.UserID).To(BeNil())
+				Expect(event.Metadata).To(HaveKeyWithValue("email", "nobody@example.com"))
+				return nil
+			})
+
+			logger.Log(ctx, domain.AuditLoginFailed, 0, map[string]any{"email": "nobody@example.com"})
+		})
+		It("should swallow write errors", func() {
+			auditRepoMock.EXPECT().Create(ctx, gomock.Any()).Return(errors.New("db down"))
+
+			Expect(func() { logger.Log(ctx, domain.AuditLogin, 1, nil) }).NotTo(Panic())
+		})
+	})
+
+	Describe("List", func() {
+		It("should return the events and total", func() {
+			filter := domain.AuditFilter{UserID: 1, Limit: 20}
+			events := []*domain.AuditEvent{{ID: 2}, {ID: 1}}
+			auditRepoMock.EXPECT().Count(ctx, filter).Return(2, nil)
+			auditRepoMock.EXPECT().List(ctx, filter).Return(events, nil)
+
+			res, total, err := svc.List(ctx, filter)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(total).To(Equal(2))
+			Expect(res).To(Equal(events))
+		})
+		It("should skip the query when nothing matches", func() {
+			filter := domain.AuditFilter{Type: domain.AuditLogin}
+			auditRepoMock.EXPECT().Count(ctx, filter).Return(0, nil)
+
+			res, total, err := svc.List(ctx, filter)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(total).To(BeZero())
+			Expect(res).To(BeEmpty())
+		})
+	})
+})
@@ -4,8 +4,11 @@ import (
 	"context"
 	"crypto/rand"
 	"errors"
+	"log/slog"
 	"math/big"
+	"net/url"
 	"strconv"
+	"sync"
 	"time"
 
 	"github.com/aarondl/opt/omit"
@@ -19,12 +22,18 @@ import (
 )
 
 type service struct {
-	cfg            config.Config
-	userRepo       domain.UserRepository
-	userTokenRepo  domain.UserTokenRepository
-	passwordHasher domain.PasswordHasher
-	jwtManager     domain.JWTManager
-	mailer         domain.Mailer
+	cfg              config.Config
+	userRepo         domain.UserRepository
+	userTokenRepo    domain.UserTokenRepository
+	passwordHasher   domain.PasswordHasher
+	passwordPolicy   domain.PasswordPolicy
+	passwordChanger  domain.PasswordChanger
+	sessionService   domain.SessionService
+	twoFactorService domain.TwoFactorService
+	loginThrottler   domain.LoginThrottler
+	mailer           domain.Mailer
+	auditLogger      domain.AuditLogger
+	dummyHash        func() (string, error)
 }
 
 func NewService(
@@ -32,20 +41,36 @@ func NewService(
 	userRepo domain.UserRepository,
 	userTokenRepo domain.UserTokenRepository,
 	passwordHasher domain.PasswordHasher,
-	jwtManager domain.JWTManager,
+	passwordPolicy domain.PasswordPolicy,
+	passwordChanger domain.PasswordChanger,
+	sessionService domain.SessionService,
+	twoFactorService domain.TwoFactorService,
+	loginThrottler domain.LoginThrottler,
 	mailer domain.Mailer,
+	auditLogger domain.AuditLogger,
 ) domain.AuthService {
 	return &service{
-		cfg:            cfg,
-		userRepo:       userRepo,
-		userTokenRepo:  userTokenRepo,
-		passwordHasher: passwordHasher,
-		jwtManager:     jwtManager,
-		mailer:         mailer,
+		cfg:              cfg,
+		userRepo:         userRepo,
+		userTokenRepo:    userTokenRepo,
+		passwordHasher:   passwordHasher,
+		passwordPolicy:   passwordPolicy,
+		passwordChanger:  passwordChanger,
+		sessionService:   sessionService,
+		twoFactorService: twoFactorService,
+		loginThrottler:   loginThrottler,
+		mailer:           mailer,
+		auditLogger:      auditLogger,
+		dummyHash: sync.OnceValues(func() (string, error) {
+			return passwordHasher.Hash(rand.Text())
+		}),
 	}
 }
 
 func (s *service) Register(ctx context.Context, user *domain.User) (*domain.PairToken, error) {
+	if err := s.passwordPolicy.Validate(ctx, "password", user.Password, user); err != nil {
+		return nil, err
+	}
 	hashedPassword, err := s.passwordHasher.Hash(user.Password)
 	if err != nil {
 		return nil, err
@@ -53,22 +78,94 @@ func (s *service) Register(ctx context.Context, user *domain.User) (*domain.Pair
 	user.Password = hashedPassword
 	if err := s.userRepo.Create(ctx, user); err != nil {
 		if errors.Is(err, domain.ErrEmailAlreadyExists) {
+			if s.cfg.Auth.Stealth {
+				return nil, s.notifyRegistrationAttempt(ctx, user.Email)
+			}
 			return nil, validator.NewError("email", "Email already registered")
 		}
 		return nil, err
 	}
+	s.auditLogger.Log(ctx, domain.AuditRegistered, user.ID, nil)
 
-	return s.jwtManager.GeneratePairToken(&domain.JWTClaims{
-		ID:    user.ID,
-		Name:  user.Name,
-		Email: user.Email,
-	})
+	if s.cfg.Auth.Stealth {
+		// Signing in right away would tell a new email apart from a taken one.
+		return nil, s.sendVerificationEmail(ctx, user)
+	}
+	return s.sessionService.Create(ctx, user)
 }
 
-func (s *service) Login(ctx context.Context, email, password string) (*domain.PairToken, error) {
+func (s *service) notifyRegistrationAttempt(ctx context.Context, email string) error {
 	user, err := s.userRepo.FindByEmail(ctx, email)
 	if err != nil {
-		return nil, validator.NewError("email", i18n.T(ctx, "auth.failed"))
+		return err
+	}
+	return s.mailer.Send(ctx, s.buildEmailRegistrationAttempt(user))
+}
+
+func (s *service) Login(ctx context.Context, email, password string) (*domain.LoginResult, error) {
+	user, err := s.authenticate(ctx, email, password)
+	if err != nil {
+		return nil, err
+	}
+	if user.PasswordExpired(s.cfg.Auth.PasswordPolicy.MaxAge) {
+		s.auditLogger.Log(ctx, domain.AuditLoginFailed, user.ID, map[string]any{
+			"email":  email,
+			"reason": "password_expired",
+		})
+		return nil, errdefs.ErrPasswordExpired(i18n.T(ctx, "passwords.expired"))
+	}
+	s.rehashPassword(ctx, user, password)
+	return s.passwordLogin(ctx, user)
+}
+
+// rehashPassword stores the password hashed with the current algorithm and
+// parameters when the stored hash predates them. The login goes ahead if this
+// fails; it is tried again next time.
+func (s *service) rehashPassword(ctx context.Context, user *domain.User, password string) {
+	if !s.passwordHasher.NeedsRehash(user.Password) {
+		return
+	}
+	hashedPassword, err := s.passwordHasher.Hash(password)
+	if err == nil {
+		err = s.userRepo.Update(ctx, user.ID, &domain.UserUpdate{PasswordRehash: omit.From(hashedPassword)})
+	}
+	if err != nil {
+		slog.WarnContext(ctx, "failed to rehash password", slog.Int64("user_id", user.ID), slog.Any("error", err))
+		return
+	}
+	user.Password = hashedPassword
+}
+
+// ChangeExpiredPassword asks for the credentials again rather than a token from
+// Login, so it works the same whether or not the password has expired yet.
+func (s *service) ChangeExpiredPassword(ctx context.Context, email, currentPassword, newPassword string) (*domain.LoginResult, error) {
+	user, err := s.authenticate(ctx, email, currentPassword)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.passwordChanger.Change(ctx, "password", user, newPassword); err != nil {
+		return nil, err
+	}
+	s.auditLogger.Log(ctx, domain.AuditPasswordChanged, user.ID, map[string]any{"reason": "expired"})
+	if err := s.sessionService.RevokeAll(ctx, user.ID, ""); err != nil {
+		return nil, err
+	}
+	return s.passwordLogin(ctx, user)
+}
+
+// authenticate checks a password login, counting failures against the throttle.
+func (s *service) authenticate(ctx context.Context, email, password string) (*domain.User, error) {
+	if err := s.loginThrottler.Check(ctx, email); err != nil {
+		return nil, err
+	}
+
+	user, err := s.userRepo.FindByEmail(ctx, email)
+	if err != nil {
+		s.verifyDummyHash(password)
+		return nil, s.loginFailed(ctx, email, 0)
+	}
+	if user.Password == "" {
+		s.verifyDummyHash(password)
 	}
 
 	match, err := s.passwordHasher.Verify(password, user.Password)
@@ -76,37 +173,185 @@ func (s *service) Login(ctx context.Context, email, password string) (*domain.Pa
 		return nil, err
 	}
 	if !match {
-		return nil, validator.NewError("email", i18n.T(ctx, "auth.failed"))
+		return nil, s.loginFailed(ctx, email, user.ID)
+	}
+	if err := s.loginThrottler.Succeed(ctx, email); err != nil {
+		return nil, err
 	}
-	claims := &domain.JWTClaims{
-		ID:    user.ID,
-		Name:  user.Name,
-		Email: user.Email,
+	if user.IsSuspended() {
+		s.auditLogger.Log(ctx, domain.AuditLoginFailed, user.ID, map[string]any{
+			"email":  email,
+			"reason": "suspended",
+		})
+		return nil, errdefs.ErrAccountSuspended(i18n.T(ctx, "auth.suspended"))
 	}
-	return s.jwtManager.GeneratePairToken(claims)
+	return user, nil
 }
 
-func (s *service) RefreshToken(ctx context.Context, refreshToken string) (*domain.PairToken, error) {
-	claims, err := s.jwtManager.VerifyRefreshToken(refreshToken)
+// twoFactorFailed counts a wrong second factor against the user and the
+// challenge, then returns failure.
+func (s *service) twoFactorFailed(ctx context.Context, user *domain.User, challengeToken string, failure error) error {
+	if err := s.loginThrottler.Fail(ctx, user.Email); err != nil {
+		return err
+	}
+	if err := s.twoFactorService.FailChallenge(ctx, challengeToken); err != nil {
+		return err
+	}
+	return failure
+}
+
+// verifyDummyHash takes as long as checking a password does, so in stealth mode
+// logins for emails without a password fail as slowly as wrong passwords.
+func (s *service) verifyDummyHash(password string) {
+	if !s.cfg.Auth.Stealth {
+		return
+	}
+	if hash, err := s.dummyHash(); err == nil {
+		_, _ = s.passwordHasher.Verify(password, hash)
+	}
+}
+
+// passwordLogin finishes a password login with a session, or a two-factor
+// challenge when the user has it enabled.
+func (s *service) passwordLogin(ctx context.Context, user *domain.User) (*domain.LoginResult, error) {
+	if user.HasTwoFactorEnabled() {
+		challengeToken, err := s.twoFactorService.CreateChallenge(ctx, user)
+		if err != nil {
+			return nil, err
+		}
+		return &domain.LoginResult{ChallengeToken: challengeToken}, nil
+	}
+
+	token, err := s.sessionService.Create(ctx, user)
 	if err != nil {
 		return nil, err
 	}
-	user, err := s.userRepo.FindByID(ctx, claims.ID)
+	s.auditLogger.Log(ctx, domain.AuditLogin, user.ID, map[string]any{"method": "password"})
+	return &domain.LoginResult{Token: token}, nil
+}
+
+func (s *service) VerifyTwoFactor(ctx context.Context, challengeToken, code, recoveryCode string) (*domain.PairToken, error) {
+	userID, err := s.twoFactorService.ParseChallenge(ctx, challengeToken)
+	if err != nil {
+		return nil, err
+	}
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, domain.ErrResourceNotFound) {
+			return nil, errdefs.ErrTokenInvalid(i18n.T(ctx, "two_factor.challenge"))
+		}
+		return nil, err
+	}
+	if !user.HasTwoFactorEnabled() {
+		return nil, errdefs.ErrTokenInvalid(i18n.T(ctx, "two_factor.challenge"))
+	}
+	// Wrong codes count like wrong passwords, towards backoff and lockout.
+	if err := s.loginThrottler.Check(ctx, user.Email); err != nil {
+		return nil, err
+	}
+
+	var valid bool
+	if recoveryCode != "" {
+		valid, err = s.twoFactorService.UseRecoveryCode(ctx, user, recoveryCode)
+		if err != nil {
+			return nil, err
+		}
+		if !valid {
+			s.auditLogger.Log(ctx, domain.AuditLoginFailed, user.ID, map[string]any{"reason": "invalid_recovery_code"})
+			return nil, s.twoFactorFailed(ctx, user, challengeToken, validator.NewError("recovery_code", i18n.T(ctx, "two_factor.invalid_recovery_code")))
+		}
+	} else {
+		valid, err = s.twoFactorService.Verify(ctx, user, code)
+		if err != nil {
+			return nil, err
+		}
+		if !valid {
+			s.auditLogger.Log(ctx, domain.AuditLoginFailed, user.ID, map[string]any{"reason": "invalid_two_factor_code"})
+			return nil, s.twoFactorFailed(ctx, user, challengeToken, validator.NewError("code", i18n.T(ctx, "two_factor.invalid_code")))
+		}
+	}
+	if err := s.twoFactorService.ConsumeChallenge(ctx, challengeToken); err != nil {
+		return nil, err
+	}
+	if err := s.loginThrottler.Succeed(ctx, user.Email); err != nil {
+		return nil, err
+	}
+
+	token, err := s.sessionService.Create(ctx, user)
 	if err != nil {
 		return nil, err
 	}
-	claims = &domain.JWTClaims{
-		ID:    user.ID,
-		Name:  user.Name,
-		Email: user.Email,
+	s.auditLogger.Log(ctx, domain.AuditLogin, user.ID, map[string]any{"method": "two_factor"})
+	return token, nil
+}
+
+func (s *service) RefreshToken(ctx context.Context, refreshToken string) (*domain.PairToken, error) {
+	return s.sessionService.Refresh(ctx, refreshToken)
+}
+
+func (s *service) Logout(ctx context.Context, userID int64, sessionID string) error {
+	if sessionID == "" {
+		return nil
+	}
+	err := s.sessionService.Revoke(ctx, userID, sessionID)
+	if errors.Is(err, domain.ErrResourceNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	s.auditLogger.Log(ctx, domain.AuditLogout, userID, map[string]any{"session_id": sessionID})
+	return nil
+}
+
+func (s *service) LogoutAll(ctx context.Context, userID int64) error {
+	if err := s.sessionService.RevokeAll(ctx, userID, ""); err != nil {
+		return err
+	}
+	s.auditLogger.Log(ctx, domain.AuditLogoutAll, userID, nil)
+	return nil
+}
+
+func (s *service) ConfirmPassword(ctx context.Context, userID int64, sessionID, password string) error {
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	// Shares the login lockout, so a hijacked session cannot guess the password.
+	if err := s.loginThrottler.Check(ctx, user.Email); err != nil {
+		return err
+	}
+	match, err := s.passwordHasher.Verify(password, user.Password)
+	if err != nil {
+		return err
+	}
+	if !match {
+		if err := s.loginThrottler.Fail(ctx, user.Email); err != nil {
+			return err
+		}
+		return validator.NewError("password", i18n.T(ctx, "auth.password"))
+	}
+	if err := s.loginThrottler.Succeed(ctx, user.Email); err != nil {
+		return err
 	}
-	return s.jwtManager.GeneratePairToken(claims)
+
+	if err := s.sessionService.Reauthenticate(ctx, userID, sessionID); err != nil {
+		if errors.Is(err, domain.ErrResourceNotFound) {
+			return errdefs.ErrTokenInvalid()
+		}
+		return err
+	}
+	s.auditLogger.Log(ctx, domain.AuditPasswordConfirmed, userID, map[string]any{"session_id": sessionID})
+	return nil
 }
 
 func (s *service) SendForgotPasswordEmail(ctx context.Context, email string) error {
 	user, err := s.userRepo.FindByEmail(ctx, email)
 	if err != nil {
 		if errors.Is(err, domain.ErrResourceNotFound) {
+			if s.cfg.Auth.Stealth {
+				return nil
+			}
 			return validator.NewError("email", i18n.T(ctx, "passwords.user"))
 		}
 		return err
@@ -132,6 +377,7 @@ func (s *service) SendForgotPasswordEmail(ctx context.Context, email string) err
 	if err := s.mailer.Send(ctx, msg); err != nil {
 		return err
 	}
+	s.auditLogger.Log(ctx, domain.AuditPasswordResetRequested, user.ID, nil)
 
 	return nil
 }
@@ -146,20 +392,16 @@ func (s *service) ResetPassword(ctx context.Context, token, email, newPassword s
 	if err != nil {
 		return err
 	}
-
-	hashedPassword, err := s.passwordHasher.Hash(newPassword)
-	if err != nil {
+	if err := s.passwordChanger.Change(ctx, "password", user, newPassword); err != nil {
 		return err
 	}
-	user.Password = hashedPassword
-	if err := s.userRepo.Update(ctx, user.ID, &domain.UserUpdate{
-		Password: omit.From(hashedPassword),
-	}); err != nil {
+	_ = s.userTokenRepo.Delete(ctx, user.ID, domain.TokenTypeResetPassword)
+	if err := s.loginThrottler.Succeed(ctx, user.Email); err != nil {
 		return err
 	}
-	_ = s.userTokenRepo.Delete(ctx, user.ID, domain.TokenTypeResetPassword)
+	s.auditLogger.Log(ctx, domain.AuditPasswordReset, user.ID, nil)
 
-	return nil
+	return s.sessionService.RevokeAll(ctx, user.ID, "")
 }
 
 func (s *service) SendVerificationEmail(ctx context.Context, email string) error {
@@ -167,6 +409,10 @@ func (s *service) SendVerificationEmail(ctx context.Context, email string) error
 	if err != nil {
 		return err
 	}
+	return s.sendVerificationEmail(ctx, user)
+}
+
+func (s *service) sendVerificationEmail(ctx context.Context, user *domain.User) error {
 	token := s.generateRandomString(32)
 	hashedToken, err := s.passwordHasher.Hash(token)
 	if err != nil {
@@ -227,10 +473,130 @@ func (s *service) VerifyEmail(ctx context.Context, token string, userID int64) e
 	return nil
 }
 
+func (s *service) SendMagicLink(ctx context.Context, email string) error {
+	if !s.cfg.Auth.MagicLinkEnabled {
+		return errdefs.ErrNotFound(i18n.T(ctx, "magic_link.disabled"))
+	}
+	user, err := s.userRepo.FindByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, domain.ErrResourceNotFound) {
+			if s.cfg.Auth.Stealth {
+				return nil
+			}
+			return validator.NewError("email", i18n.T(ctx, "passwords.user"))
+		}
+		return err
+	}
+
+	token := s.generateRandomString(32)
+	hashedToken, err := s.passwordHasher.Hash(token)
+	if err != nil {
+		return err
+	}
+	magicLinkToken := &domain.UserToken{
+		UserID:    user.ID,
+		Token:     hashedToken,
+		ExpiresAt: time.Now().Add(s.cfg.Auth.MagicLinkExpiration),
+		TokenType: domain.TokenTypeMagicLink,
+	}
+	if err := s.userTokenRepo.Create(ctx, magicLinkToken); err != nil {
+		return err
+	}
+
+	msg := s.buildEmailMagicLink(user, token)
+	if err := s.mailer.Send(ctx, msg); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *service) VerifyMagicLink(ctx context.Context, token, email string) (*domain.LoginResult, error) {
+	if !s.cfg.Auth.MagicLinkEnabled {
+		return nil, errdefs.ErrNotFound(i18n.T(ctx, "magic_link.disabled"))
+	}
+	user, err := s.userRepo.FindByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, domain.ErrResourceNotFound) {
+			return nil, errdefs.ErrBadRequest(i18n.T(ctx, "magic_link.token"))
+		}
+		return nil, err
+	}
+
+	mlt, err := s.userTokenRepo.FindOne(ctx, user.ID, domain.TokenTypeMagicLink)
+	if err != nil {
+		if errors.Is(err, domain.ErrResourceNotFound) {
+			return nil, errdefs.ErrBadRequest(i18n.T(ctx, "magic_link.token"))
+		}
+		return nil, err
+	}
+	if time.Now().After(mlt.ExpiresAt) {
+		return nil, errdefs.ErrBadRequest(i18n.T(ctx, "magic_link.token"))
+	}
+	match, err := s.passwordHasher.Verify(token, mlt.Token)
+	if err != nil {
+		return nil, err
+	}
+	if !match {
+		return nil, errdefs.ErrBadRequest(i18n.T(ctx, "magic_link.token"))
+	}
+
+	// The link is single use, so burn it before handing out anything. Of
+	// concurrent requests with the same link only one deletes the token.
+	if err := s.userTokenRepo.Consume(ctx, mlt.ID); err != nil {
+		if errors.Is(err, domain.ErrResourceNotFound) {
+			return nil, errdefs.ErrTokenInvalid(i18n.T(ctx, "magic_link.token"))
+		}
+		return nil, err
+	}
+
+	// Following the link proves the user owns the inbox.
+	if !user.IsVerified() {
+		now := time.Now()
+		if err := s.userRepo.Update(ctx, user.ID, &domain.UserUpdate{
+			EmailVerifiedAt: omitnull.From(now),
+		}); err != nil {
+			return nil, err
+		}
+		user.EmailVerifiedAt = &now
+	}
+
+	if user.HasTwoFactorEnabled() {
+		challengeToken, err := s.twoFactorService.CreateChallenge(ctx, user)
+		if err != nil {
+			return nil, err
+		}
+		return &domain.LoginResult{ChallengeToken: challengeToken}, nil
+	}
+
+	pairToken, err := s.sessionService.Create(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+	s.auditLogger.Log(ctx, domain.AuditLogin, user.ID, map[string]any{"method": "magic_link"})
+	return &domain.LoginResult{Token: pairToken}, nil
+}
+
+// loginFailed records a failed password attempt and returns the error for it.
+// userID is zero when no account exists for email.
+func (s *service) loginFailed(ctx context.Context, email string, userID int64) error {
+	s.auditLogger.Log(ctx, domain.AuditLoginFailed, userID, map[string]any{
+		"email":  email,
+		"reason": "invalid_credentials",
+	})
+	if err := s.loginThrottler.Fail(ctx, email); err != nil {
+		return err
+	}
+	return validator.NewError("email", i18n.T(ctx, "auth.failed"))
+}
+
 func (s *service) validateResetPassword(ctx context.Context, token, email string) (*domain.User, error) {
 	user, err := s.userRepo.FindByEmail(ctx, email)
 	if err != nil {
 		if errors.Is(err, domain.ErrResourceNotFound) {
+			if s.cfg.Auth.Stealth {
+				return nil, errdefs.ErrBadRequest(i18n.T(ctx, "passwords.token"))
+			}
 			return nil, errdefs.ErrBadRequest(i18n.T(ctx, "passwords.user"))
 		}
 		return nil, err
@@ -282,6 +648,28 @@ func (s *service) buildEmailVerification(user *domain.User, token string) *mailg
 		Line("If you did not create an account, no further action is required.")
 }
 
+func (s *service) buildEmailRegistrationAttempt(user *domain.User) *mailgen.Builder {
+	return mailgen.New().
+		To(user.Email).
+		Subject("Sign-up Attempt").
+		Name(user.Name).
+		Line("Someone tried to create an account with your email address, which already has one.").
+		Action("Reset Password", s.cfg.App.FrontendBaseURL+"/forgot-password").
+		Line("If this was you, sign in instead or reset your password if you have forgotten it. Otherwise, no further action is required.")
+}
+
+func (s *service) buildEmailMagicLink(user *domain.User, token string) *mailgen.Builder {
+	link := s.cfg.App.FrontendBaseURL + "/magic-link?token=" + token + "&email=" + url.QueryEscape(user.Email)
+	return mailgen.New().
+		To(user.Email).
+		Subject("Your Sign-in Link").
+		Name(user.Name).
+		Line("Click the button below to sign in to your account. The link can only be used once.").
+		Action("Sign In", link).
+		Linef("This sign-in link will expire in %d minutes.", int(s.cfg.Auth.MagicLinkExpiration.Minutes())).
+		Line("If you did not request this link, no further action is required.")
+}
+
 func (s *service) generateRandomString(length int) string {
 	const charset = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
 	var max = big.NewInt(int64(len(charset)))
@@ -3,7 +3,9 @@ package auth_test
 import (
 	"context"
 	"errors"
+	"time"
 
+	"github.com/aarondl/opt/omit"
 	"github.com/invopop/ctxi18n"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -11,8 +13,10 @@ import (
 
 	"github.com/akfaiz/go-vue-starter-kit/internal/config"
 	"github.com/akfaiz/go-vue-starter-kit/internal/domain"
+	"github.com/akfaiz/go-vue-starter-kit/internal/errdefs"
 	"github.com/akfaiz/go-vue-starter-kit/internal/mocks"
 	"github.com/akfaiz/go-vue-starter-kit/internal/service/auth"
+	"github.com/akfaiz/go-vue-starter-kit/internal/service/password"
 	"github.com/akfaiz/go-vue-starter-kit/internal/validator"
 )
 
@@ -21,22 +25,33 @@ var _ = Describe("Auth", Label("unit", "usecase"), func() {
 		userRepoMock      *mocks.MockUserRepository
 		userTokenRepoMock *mocks.MockUserTokenRepository
 		hasherMock        *mocks.MockPasswordHasher
-		jwtManagerMock    *mocks.MockJWTManager
+		policyMock        *mocks.MockPasswordPolicy
+		historyMock       *mocks.MockPasswordHistory
+		sessionSvcMock    *mocks.MockSessionService
+		twoFactorSvcMock  *mocks.MockTwoFactorService
+		throttlerMock     *mocks.MockLoginThrottler
 		mailerMock        *mocks.MockMailer
 		cfg               config.Config
+		auditLoggerMock   *mocks.MockAuditLogger
 		svc               domain.AuthService
 
-		ctx    context.Context
+		ctx context.Context
 	)
 	BeforeEach(func() {
 		ctrl := gomock.NewController(GinkgoT())
 		userRepoMock = mocks.NewMockUserRepository(ctrl)
 		userTokenRepoMock = mocks.NewMockUserTokenRepository(ctrl)
 		hasherMock = mocks.NewMockPasswordHasher(ctrl)
-		jwtManagerMock = mocks.NewMockJWTManager(ctrl)
+		policyMock = mocks.NewMockPasswordPolicy(ctrl)
+		historyMock = mocks.NewMockPasswordHistory(ctrl)
+		sessionSvcMock = mocks.NewMockSessionService(ctrl)
+		twoFactorSvcMock = mocks.NewMockTwoFactorService(ctrl)
+		throttlerMock = mocks.NewMockLoginThrottler(ctrl)
 		mailerMock = mocks.NewMockMailer(ctrl)
 		cfg = config.Config{}
-		svc = auth.NewService(cfg, userRepoMock, userTokenRepoMock, hasherMock, jwtManagerMock, mailerMock)
+		auditLoggerMock = mocks.NewMockAuditLogger(ctrl)
+		auditLoggerMock.EXPECT().Log(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+		svc = auth.NewService(cfg, userRepoMock, userTokenRepoMock, hasherMock, policyMock, password.NewChanger(userRepoMock, hasherMock, policyMock, historyMock), sessionSvcMock, twoFactorSvcMock, throttlerMock, mailerMock, auditLoggerMock)
 
 		ctx = context.Background()
 		ctx, _ = ctxi18n.WithLocale(ctx, "en")
@@ -54,15 +69,15 @@ var _ = Describe("Auth", Label("unit", "usecase"), func() {
 		type testCase struct {
 			args    args
 			arrange func()
-			check   func(token *domain.PairToken, err error)
+			check   func(result *domain.LoginResult, err error)
 		}
 		DescribeTable("Login scenarios",
 			func(tc testCase) {
 				if tc.arrange != nil {
 					tc.arrange()
 				}
-				token, err := svc.Login(ctx, tc.args.email, tc.args.password)
-				tc.check(token, err)
+				result, err := svc.Login(ctx, tc.args.email, tc.args.password)
+				tc.check(result, err)
 			},
 			Entry("should return token when email and password match", testCase{
 				args: args{
@@ -75,23 +90,118 @@ var _ = Describe("Auth", Label("unit", "usecase"), func() {
 						Name:  "John Doe",
 						Email: "john.doe@example.com",
 					}
+					throttlerMock.EXPECT().Check(gomock.Any(), "john.doe@example.com").Return(nil)
 					userRepoMock.EXPECT().FindByEmail(gomock.Any(), "john.doe@example.com").Return(user, nil)
 					hasherMock.EXPECT().Verify("password123", user.Password).Return(true, nil)
+					throttlerMock.EXPECT().Succeed(gomock.Any(), "john.doe@example.com").Return(nil)
+					hasherMock.EXPECT().NeedsRehash(user.Password).Return(false)
 					token := &domain.PairToken{
 						AccessToken:  "access.token.here",
 						RefreshToken: "refresh.token.here",
 					}
-					jwtManagerMock.EXPECT().GeneratePairToken(&domain.JWTClaims{
-						ID:    user.ID,
-						Name:  user.Name,
-						Email: user.Email,
-					}).Return(token, nil)
+					sessionSvcMock.EXPECT().Create(gomock.Any(), user).Return(token, nil)
 				},
-				check: func(token *domain.PairToken, err error) {
+				check: func(result *domain.LoginResult, err error) {
 					Expect(err).NotTo(HaveOccurred())
-					Expect(token).NotTo(BeNil())
-					Expect(token.AccessToken).To(Equal("access.token.here"))
-					Expect(token.RefreshToken).To(Equal("refresh.token.here"))
+					Expect(result.TwoFactorRequired()).To(BeFalse())
+					Expect(result.Token).NotTo(BeNil())
+					Expect(result.Token.AccessToken).To(Equal("access.token.here"))
+					Expect(result.Token.RefreshToken).To(Equal("refresh.token.here"))
+				},
+			}),
+			Entry("should reject a suspended user after checking the password", testCase{
+				args: args{
+					email:    "john.doe@example.com",
+					password: "password123",
+				},
+				arrange: func() {
+					user := &domain.User{
+						ID:     1,
+						Email:  "john.doe@example.com",
+						Status: domain.UserStatusSuspended,
+					}
+					throttlerMock.EXPECT().Check(gomock.Any(), "john.doe@example.com").Return(nil)
+					userRepoMock.EXPECT().FindByEmail(gomock.Any(), "john.doe@example.com").Return(user, nil)
+					hasherMock.EXPECT().Verify("password123", user.Password).Return(true, nil)
+					throttlerMock.EXPECT().Succeed(gomock.Any(), "john.doe@example.com").Return(nil)
+				},
+				check: func(result *domain.LoginResult, err error) {
+					var appErr *errdefs.AppError
+					Expect(errors.As(err, &appErr)).To(BeTrue())
+					Expect(appErr.Type).To(Equal("/problems/account-suspended"))
+					Expect(result).To(BeNil())
+				},
+			}),
+			Entry("should return a challenge token when two-factor is enabled", testCase{
+				args: args{
+					email:    "john.doe@example.com",
+					password: "password123",
+				},
+				arrange: func() {
+					secret := "encrypted-secret"
+					confirmedAt := time.Now()
+					user := &domain.User{
+						ID:                   1,
+						Email:                "john.doe@example.com",
+						TwoFactorSecret:      &secret,
+						TwoFactorConfirmedAt: &confirmedAt,
+					}
+					throttlerMock.EXPECT().Check(gomock.Any(), "john.doe@example.com").Return(nil)
+					userRepoMock.EXPECT().FindByEmail(gomock.Any(), "john.doe@example.com").Return(user, nil)
+					hasherMock.EXPECT().Verify("password123", user.Password).Return(true, nil)
+					throttlerMock.EXPECT().Succeed(gomock.Any(), "john.doe@example.com").Return(nil)
+					hasherMock.EXPECT().NeedsRehash(user.Password).Return(false)
+					twoFactorSvcMock.EXPECT().CreateChallenge(gomock.Any(), user).Return("challenge-token", nil)
+				},
+				check: func(result *domain.LoginResult, err error) {
+					Expect(err).NotTo(HaveOccurred())
+					Expect(result.TwoFactorRequired()).To(BeTrue())
+					Expect(result.Token).To(BeNil())
+					Expect(result.ChallengeToken).To(Equal("challenge-token"))
+				},
+			}),
+			Entry("should upgrade an outdated password hash", testCase{
+				args: args{
+					email:    "john.doe@example.com",
+					password: "password123",
+				},
+				arrange: func() {
+					user := &domain.User{ID: 1, Email: "john.doe@example.com", Password: "$2a$10$legacy"}
+					throttlerMock.EXPECT().Check(gomock.Any(), "john.doe@example.com").Return(nil)
+					userRepoMock.EXPECT().FindByEmail(gomock.Any(), "john.doe@example.com").Return(user, nil)
+					hasherMock.EXPECT().Verify("password123", "$2a$10$legacy").Return(true, nil)
+					throttlerMock.EXPECT().Succeed(gomock.Any(), "john.doe@example.com").Return(nil)
+					hasherMock.EXPECT().NeedsRehash("$2a$10$legacy").Return(true)
+					hasherMock.EXPECT().Hash("password123").Return("$argon2id$current", nil)
+					userRepoMock.EXPECT().Update(gomock.Any(), int64(1), &domain.UserUpdate{
+						PasswordRehash: omit.From("$argon2id$current"),
+					}).Return(nil)
+					sessionSvcMock.EXPECT().Create(gomock.Any(), user).Return(&domain.PairToken{AccessToken: "access"}, nil)
+				},
+				check: func(result *domain.LoginResult, err error) {
+					Expect(err).NotTo(HaveOccurred())
+					Expect(result.Token.AccessToken).To(Equal("access"))
+				},
+			}),
+			Entry("should still log in when upgrading the hash fails", testCase{
+				args: args{
+					email:    "john.doe@example.com",
+					password: "password123",
+				},
+				arrange: func() {
+					user := &domain.User{ID: 1, Email: "john.doe@example.com", Password: "$2a$10$legacy"}
+					throttlerMock.EXPECT().Check(gomock.Any(), "john.doe@example.com").Return(nil)
+					userRepoMock.EXPECT().FindByEmail(gomock.Any(), "john.doe@example.com").Return(user, nil)
+					hasherMock.EXPECT().Verify("password123", "$2a$10$legacy").Return(true, nil)
+					throttlerMock.EXPECT().Succeed(gomock.Any(), "john.doe@example.com").Return(nil)
+					hasherMock.EXPECT().NeedsRehash("$2a$10$legacy").Return(true)
+					hasherMock.EXPECT().Hash("password123").Return("$argon2id$current", nil)
+					userRepoMock.EXPECT().Update(gomock.Any(), int64(1), gomock.Any()).Return(errors.New("db down"))
+					sessionSvcMock.EXPECT().Create(gomock.Any(), user).Return(&domain.PairToken{AccessToken: "access"}, nil)
+				},
+				check: func(result *domain.LoginResult, err error) {
+					Expect(err).NotTo(HaveOccurred())
+					Expect(result.Token.AccessToken).To(Equal("access"))
 				},
 			}),
 			Entry("should return error when email not found", testCase{
@@ -100,17 +210,419 @@ var _ = Describe("Auth", Label("unit", "usecase"), func() {
 					password: "password123",
 				},
 				arrange: func() {
+					throttlerMock.EXPECT().Check(gomock.Any(), "john.doe@example.com").Return(nil)
 					userRepoMock.EXPECT().FindByEmail(gomock.Any(), "john.doe@example.com").Return(nil, domain.ErrResourceNotFound)
+					throttlerMock.EXPECT().Fail(gomock.Any(), "john.doe@example.com").Return(nil)
 				},
-				check: func(token *domain.PairToken, err error) {
+				check: func(result *domain.LoginResult, err error) {
 					Expect(err).To(HaveOccurred())
-					Expect(token).To(BeNil())
+					Expect(result).To(BeNil())
 					var vErr *validator.ValidationError
 					Expect(errors.As(err, &vErr)).To(BeTrue())
 					Expect(vErr.First().Field).To(Equal("email"))
 					Expect(vErr.First().Message).To(Equal("These credentials do not match our records."))
 				},
 			}),
+			Entry("should record a failure when the password is wrong", testCase{
+				args: args{
+					email:    "john.doe@example.com",
+					password: "wrong",
+				},
+				arrange: func() {
+					user := &domain.User{ID: 1, Email: "john.doe@example.com", Password: "hashed"}
+					throttlerMock.EXPECT().Check(gomock.Any(), "john.doe@example.com").Return(nil)
+					userRepoMock.EXPECT().FindByEmail(gomock.Any(), "john.doe@example.com").Return(user, nil)
+					hasherMock.EXPECT().Verify("wrong", "hashed").Return(false, nil)
+					throttlerMock.EXPECT().Fail(gomock.Any(), "john.doe@example.com").Return(nil)
+				},
+				check: func(result *domain.LoginResult, err error) {
+					Expect(result).To(BeNil())
+					var vErr *validator.ValidationError
+					Expect(errors.As(err, &vErr)).To(BeTrue())
+				},
+			}),
+			Entry("should not check the password while throttled", testCase{
+				args: args{
+					email:    "john.doe@example.com",
+					password: "password123",
+				},
+				arrange: func() {
+					throttlerMock.EXPECT().Check(gomock.Any(), "john.doe@example.com").
+						Return(errdefs.ErrLocked().WithRetryAfter(time.Minute))
+				},
+				check: func(result *domain.LoginResult, err error) {
+					Expect(result).To(BeNil())
+					var appErr *errdefs.AppError
+					Expect(errors.As(err, &appErr)).To(BeTrue())
+					Expect(appErr.Status).To(Equal(423))
+					Expect(appErr.RetryAfter()).To(Equal(time.Minute))
+				},
+			}),
 		)
 	})
+
+	Describe("VerifyTwoFactor", func() {
+		var user *domain.User
+		BeforeEach(func() {
+			secret := "encrypted-secret"
+			confirmedAt := time.Now()
+			user = &domain.User{
+				ID:                   1,
+				Email:                "john.doe@example.com",
+				TwoFactorSecret:      &secret,
+				TwoFactorConfirmedAt: &confirmedAt,
+			}
+		})
+
+		It("should create a session when the code is valid", func() {
+			token := &domain.PairToken{AccessToken: "access", RefreshToken: "refresh"}
+			twoFactorSvcMock.EXPECT().ParseChallenge(gomock.Any(), "challenge-token").Return(int64(1), nil)
+			userRepoMock.EXPECT().FindByID(gomock.Any(), int64(1)).Return(user, nil)
+			throttlerMock.EXPECT().Check(gomock.Any(), user.Email).Return(nil)
+			twoFactorSvcMock.EXPECT().Verify(gomock.Any(), user, "123456").Return(true, nil)
+			twoFactorSvcMock.EXPECT().ConsumeChallenge(gomock.Any(), "challenge-token").Return(nil)
+			throttlerMock.EXPECT().Succeed(gomock.Any(), user.Email).Return(nil)
+			sessionSvcMock.EXPECT().Create(gomock.Any(), user).Return(token, nil)
+
+			got, err := svc.VerifyTwoFactor(ctx, "challenge-token", "123456", "")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(got).To(Equal(token))
+		})
+		It("should create a session when a recovery code is used", func() {
+			token := &domain.PairToken{AccessToken: "access", RefreshToken: "refresh"}
+			twoFactorSvcMock.EXPECT().ParseChallenge(gomock.Any(), "challenge-token").Return(int64(1), nil)
+			userRepoMock.EXPECT().FindByID(gomock.Any(), int64(1)).Return(user, nil)
+			throttlerMock.EXPECT().Check(gomock.Any(), user.Email).Return(nil)
+			twoFactorSvcMock.EXPECT().UseRecoveryCode(gomock.Any(), user, "abcde-12345").Return(true, nil)
+			twoFactorSvcMock.EXPECT().ConsumeChallenge(gomock.Any(), "challenge-token").Return(nil)
+			throttlerMock.EXPECT().Succeed(gomock.Any(), user.Email).Return(nil)
+			sessionSvcMock.EXPECT().Create(gomock.Any(), user).Return(token, nil)
+
+			got, err := svc.VerifyTwoFactor(ctx, "challenge-token", "", "abcde-12345")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(got).To(Equal(token))
+		})
+		It("should count a failure when the code is invalid", func() {
+			twoFactorSvcMock.EXPECT().ParseChallenge(gomock.Any(), "challenge-token").Return(int64(1), nil)
+			userRepoMock.EXPECT().FindByID(gomock.Any(), int64(1)).Return(user, nil)
+			throttlerMock.EXPECT().Check(gomock.Any(), user.Email).Return(nil)
+			twoFactorSvcMock.EXPECT().Verify(gomock.Any(), user, "000000").Return(false, nil)
+			throttlerMock.EXPECT().Fail(gomock.Any(), user.Email).Return(nil)
+			twoFactorSvcMock.EXPECT().FailChallenge(gomock.Any(), "challenge-token").Return(nil)
+
+			got, err := svc.VerifyTwoFactor(ctx, "challenge-token", "000000", "")
+			Expect(got).To(BeNil())
+			var vErr *validator.ValidationError
+			Expect(errors.As(err, &vErr)).To(BeTrue())
+			Expect(vErr.First().Field).To(Equal("code"))
+		})
+		It("should not check the code while throttled", func() {
+			twoFactorSvcMock.EXPECT().ParseChallenge(gomock.Any(), "challenge-token").Return(int64(1), nil)
+			userRepoMock.EXPECT().FindByID(gomock.Any(), int64(1)).Return(user, nil)
+			throttlerMock.EXPECT().Check(gomock.Any(), user.Email).Return(errdefs.ErrLocked().WithRetryAfter(time.Minute))
+
+			got, err := svc.VerifyTwoFactor(ctx, "challenge-token", "123456", "")
+			Expect(got).To(BeNil())
+			var appErr *errdefs.AppError
+			Expect(errors.As(err, &appErr)).To(BeTrue())
+			Expect(appErr.Status).To(Equal(423))
+		})
+		It("should reject an invalid challenge token", func() {
+			twoFactorSvcMock.EXPECT().ParseChallenge(gomock.Any(), "bad").Return(int64(0), errdefs.ErrTokenInvalid())
+
+			got, err := svc.VerifyTwoFactor(ctx, "bad", "123456", "")
+			Expect(got).To(BeNil())
+			var appErr *errdefs.AppError
+			Expect(errors.As(err, &appErr)).To(BeTrue())
+			Expect(appErr.Status).To(Equal(401))
+		})
+	})
+
+	Describe("Password expiry", func() {
+		var user *domain.User
+		BeforeEach(func() {
+			cfg.Auth.PasswordPolicy.MaxAge = 90 * 24 * time.Hour
+			svc = auth.NewService(cfg, userRepoMock, userTokenRepoMock, hasherMock, policyMock, password.NewChanger(userRepoMock, hasherMock, policyMock, historyMock), sessionSvcMock, twoFactorSvcMock, throttlerMock, mailerMock, auditLoggerMock)
+			user = &domain.User{
+				ID:                1,
+				Email:             "john.doe@example.com",
+				Password:          "hashed",
+				PasswordChangedAt: time.Now().Add(-100 * 24 * time.Hour),
+			}
+			throttlerMock.EXPECT().Check(gomock.Any(), "john.doe@example.com").Return(nil)
+			userRepoMock.EXPECT().FindByEmail(gomock.Any(), "john.doe@example.com").Return(user, nil)
+			hasherMock.EXPECT().Verify("password123", "hashed").Return(true, nil)
+			throttlerMock.EXPECT().Succeed(gomock.Any(), "john.doe@example.com").Return(nil)
+		})
+		It("should make the login change an expired password first", func() {
+			result, err := svc.Login(ctx, "john.doe@example.com", "password123")
+			Expect(result).To(BeNil())
+			var appErr *errdefs.AppError
+			Expect(errors.As(err, &appErr)).To(BeTrue())
+			Expect(appErr.Type).To(Equal("/problems/password-expired"))
+			Expect(appErr.Status).To(Equal(403))
+		})
+		It("should replace the password and sign the user in", func() {
+			token := &domain.PairToken{AccessToken: "access", RefreshToken: "refresh"}
+			policyMock.EXPECT().Validate(gomock.Any(), "password", "new passphrase", user).Return(nil)
+			historyMock.EXPECT().Check(gomock.Any(), "password", "new passphrase", user).Return(nil)
+			hasherMock.EXPECT().Hash("new passphrase").Return("new-hashed", nil)
+			userRepoMock.EXPECT().Update(gomock.Any(), int64(1), &domain.UserUpdate{Password: omit.From("new-hashed")}).Return(nil)
+			historyMock.EXPECT().Record(gomock.Any(), int64(1), "hashed").Return(nil)
+			sessionSvcMock.EXPECT().RevokeAll(gomock.Any(), int64(1), "").Return(nil)
+			sessionSvcMock.EXPECT().Create(gomock.Any(), user).Return(token, nil)
+
+			result, err := svc.ChangeExpiredPassword(ctx, "john.doe@example.com", "password123", "new passphrase")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Token).To(Equal(token))
+			Expect(user.PasswordExpired(cfg.Auth.PasswordPolicy.MaxAge)).To(BeFalse())
+		})
+		It("should refuse a password used recently", func() {
+			reused := validator.NewError("password", "You have used this password recently. Please choose a different one.")
+			policyMock.EXPECT().Validate(gomock.Any(), "password", "password123", user).Return(nil)
+			historyMock.EXPECT().Check(gomock.Any(), "password", "password123", user).Return(reused)
+
+			result, err := svc.ChangeExpiredPassword(ctx, "john.doe@example.com", "password123", "password123")
+			Expect(result).To(BeNil())
+			Expect(err).To(Equal(reused))
+		})
+	})
+
+	Describe("Register", func() {
+		var user *domain.User
+		BeforeEach(func() {
+			user = &domain.User{Name: "John Doe", Email: "john.doe@example.com", Password: "correct horse battery"}
+		})
+		It("should create the user and sign them in", func() {
+			token := &domain.PairToken{AccessToken: "access", RefreshToken: "refresh"}
+			policyMock.EXPECT().Validate(gomock.Any(), "password", "correct horse battery", user).Return(nil)
+			hasherMock.EXPECT().Hash("correct horse battery").Return("hashed", nil)
+			userRepoMock.EXPECT().Create(gomock.Any(), user).Return(nil)
+			sessionSvcMock.EXPECT().Create(gomock.Any(), user).Return(token, nil)
+
+			got, err := svc.Register(ctx, user)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(got).To(Equal(token))
+			Expect(user.Password).To(Equal("hashed"))
+		})
+		It("should reject a password that breaks the policy", func() {
+			policyErr := validator.NewError("password", "This password is too common. Please choose a different one.")
+			policyMock.EXPECT().Validate(gomock.Any(), "password", "correct horse battery", user).Return(policyErr)
+
+			got, err := svc.Register(ctx, user)
+			Expect(got).To(BeNil())
+			Expect(err).To(Equal(policyErr))
+		})
+	})
+
+	Describe("Stealth mode", func() {
+		BeforeEach(func() {
+			cfg.Auth.Stealth = true
+			svc = auth.NewService(cfg, userRepoMock, userTokenRepoMock, hasherMock, policyMock, password.NewChanger(userRepoMock, hasherMock, policyMock, historyMock), sessionSvcMock, twoFactorSvcMock, throttlerMock, mailerMock, auditLoggerMock)
+		})
+		It("should report a reset link as sent for an unknown email", func() {
+			userRepoMock.EXPECT().FindByEmail(gomock.Any(), "nobody@example.com").Return(nil, domain.ErrResourceNotFound)
+
+			Expect(svc.SendForgotPasswordEmail(ctx, "nobody@example.com")).To(Succeed())
+		})
+		It("should email the owner instead of rejecting a taken email", func() {
+			user := &domain.User{Name: "John Doe", Email: "john.doe@example.com", Password: "correct horse battery"}
+			policyMock.EXPECT().Validate(gomock.Any(), "password", "correct horse battery", user).Return(nil)
+			hasherMock.EXPECT().Hash("correct horse battery").Return("hashed", nil)
+			userRepoMock.EXPECT().Create(gomock.Any(), user).Return(domain.ErrEmailAlreadyExists)
+			userRepoMock.EXPECT().FindByEmail(gomock.Any(), "john.doe@example.com").Return(&domain.User{ID: 1, Email: "john.doe@example.com"}, nil)
+			mailerMock.EXPECT().Send(gomock.Any(), gomock.Any()).Return(nil)
+
+			token, err := svc.Register(ctx, user)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(token).To(BeNil())
+		})
+		It("should send a new user a verification email rather than signing them in", func() {
+			user := &domain.User{ID: 1, Name: "John Doe", Email: "john.doe@example.com", Password: "correct horse battery"}
+			policyMock.EXPECT().Validate(gomock.Any(), "password", "correct horse battery", user).Return(nil)
+			hasherMock.EXPECT().Hash("correct horse battery").Return("hashed", nil)
+			userRepoMock.EXPECT().Create(gomock.Any(), user).Return(nil)
+			hasherMock.EXPECT().Hash(gomock.Any()).Return("hashed-token", nil)
+			userTokenRepoMock.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+			mailerMock.EXPECT().Send(gomock.Any(), gomock.Any()).Return(nil)
+
+			token, err := svc.Register(ctx, user)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(token).To(BeNil())
+		})
+		It("should check the password of an unknown email against a dummy hash", func() {
+			throttlerMock.EXPECT().Check(gomock.Any(), "nobody@example.com").Return(nil)
+			userRepoMock.EXPECT().FindByEmail(gomock.Any(), "nobody@example.com").Return(nil, domain.ErrResourceNotFound)
+			hasherMock.EXPECT().Hash(gomock.Any()).Return("dummy", nil)
+			hasherMock.EXPECT().Verify("password123", "dummy").Return(false, nil)
+			throttlerMock.EXPECT().Fail(gomock.Any(), "nobody@example.com").Return(nil)
+
+			result, err := svc.Login(ctx, "nobody@example.com", "password123")
+			Expect(result).To(BeNil())
+			var vErr *validator.ValidationError
+			Expect(errors.As(err, &vErr)).To(BeTrue())
+		})
+	})
+
+	Describe("Logout", func() {
+		It("should revoke the current session", func() {
+			sessionSvcMock.EXPECT().Revoke(gomock.Any(), int64(1), "session-1").Return(nil)
+			Expect(svc.Logout(ctx, 1, "session-1")).To(Succeed())
+		})
+		It("should ignore sessions that no longer exist", func() {
+			sessionSvcMock.EXPECT().Revoke(gomock.Any(), int64(1), "session-1").Return(domain.ErrResourceNotFound)
+			Expect(svc.Logout(ctx, 1, "session-1")).To(Succeed())
+		})
+		It("should revoke every session when logging out everywhere", func() {
+			sessionSvcMock.EXPECT().RevokeAll(gomock.Any(), int64(1), "").Return(nil)
+			Expect(svc.LogoutAll(ctx, 1)).To(Succeed())
+		})
+	})
+
+	Describe("ConfirmPassword", func() {
+		var user *domain.User
+		BeforeEach(func() {
+			user = &domain.User{ID: 1, Email: "john.doe@example.com", Password: "hashed"}
+			userRepoMock.EXPECT().FindByID(gomock.Any(), int64(1)).Return(user, nil)
+			throttlerMock.EXPECT().Check(gomock.Any(), "john.doe@example.com").Return(nil)
+		})
+		It("should mark the session as recently authenticated", func() {
+			hasherMock.EXPECT().Verify("password123", "hashed").Return(true, nil)
+			throttlerMock.EXPECT().Succeed(gomock.Any(), "john.doe@example.com").Return(nil)
+			sessionSvcMock.EXPECT().Reauthenticate(gomock.Any(), int64(1), "session-1").Return(nil)
+
+			Expect(svc.ConfirmPassword(ctx, 1, "session-1", "password123")).To(Succeed())
+		})
+		It("should reject a wrong password and count the failure", func() {
+			hasherMock.EXPECT().Verify("wrong", "hashed").Return(false, nil)
+			throttlerMock.EXPECT().Fail(gomock.Any(), "john.doe@example.com").Return(nil)
+
+			err := svc.ConfirmPassword(ctx, 1, "session-1", "wrong")
+			var vErr *validator.ValidationError
+			Expect(errors.As(err, &vErr)).To(BeTrue())
+			Expect(vErr.First().Field).To(Equal("password"))
+		})
+		It("should reject a session that is no longer active", func() {
+			hasherMock.EXPECT().Verify("password123", "hashed").Return(true, nil)
+			throttlerMock.EXPECT().Succeed(gomock.Any(), "john.doe@example.com").Return(nil)
+			sessionSvcMock.EXPECT().Reauthenticate(gomock.Any(), int64(1), "session-1").Return(domain.ErrResourceNotFound)
+
+			err := svc.ConfirmPassword(ctx, 1, "session-1", "password123")
+			var appErr *errdefs.AppError
+			Expect(errors.As(err, &appErr)).To(BeTrue())
+			Expect(appErr.Status).To(Equal(401))
+		})
+	})
+
+	Describe("MagicLink", func() {
+		var user *domain.User
+		BeforeEach(func() {
+			cfg.Auth.MagicLinkEnabled = true
+			cfg.Auth.MagicLinkExpiration = 15 * time.Minute
+			svc = auth.NewService(cfg, userRepoMock, userTokenRepoMock, hasherMock, policyMock, password.NewChanger(userRepoMock, hasherMock, policyMock, historyMock), sessionSvcMock, twoFactorSvcMock, throttlerMock, mailerMock, auditLoggerMock)
+			user = &domain.User{ID: 1, Name: "John Doe", Email: "john.doe@example.com"}
+		})
+
+		It("should email a sign-in link", func() {
+			userRepoMock.EXPECT().FindByEmail(gomock.Any(), user.Email).Return(user, nil)
+			hasherMock.EXPECT().Hash(gomock.Any()).Return("hashed-token", nil)
+			userTokenRepoMock.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(_ context.Context, token *domain.UserToken) error {
+					Expect(token.TokenType).To(Equal(domain.TokenTypeMagicLink))
+					Expect(token.Token).To(Equal("hashed-token"))
+					Expect(token.ExpiresAt).To(BeTemporally("~", time.Now().Add(15*time.Minute), time.Second))
+					return nil
+				})
+			mailerMock.EXPECT().Send(gomock.Any(), gomock.Any()).Return(nil)
+
+			Expect(svc.SendMagicLink(ctx, user.Email)).To(Succeed())
+		})
+		It("should refuse when magic links are disabled", func() {
+			cfg.Auth.MagicLinkEnabled = false
+			svc = auth.NewService(cfg, userRepoMock, userTokenRepoMock, hasherMock, policyMock, password.NewChanger(userRepoMock, hasherMock, policyMock, historyMock), sessionSvcMock, twoFactorSvcMock, throttlerMock, mailerMock, auditLoggerMock)
+
+			err := svc.SendMagicLink(ctx, user.Email)
+			var appErr *errdefs.AppError
+			Expect(errors.As(err, &appErr)).To(BeTrue())
+			Expect(appErr.Status).To(Equal(404))
+		})
+		It("should sign in, verify the email and burn the token", func() {
+			token := &domain.PairToken{AccessToken: "access", RefreshToken: "refresh"}
+			userRepoMock.EXPECT().FindByEmail(gomock.Any(), user.Email).Return(user, nil)
+			userTokenRepoMock.EXPECT().FindOne(gomock.Any(), int64(1), domain.TokenTypeMagicLink).Return(&domain.UserToken{
+				ID:        5,
+				Token:     "hashed-token",
+				ExpiresAt: time.Now().Add(time.Minute),
+			}, nil)
+			hasherMock.EXPECT().Verify("plain-token", "hashed-token").Return(true, nil)
+			userTokenRepoMock.EXPECT().Consume(gomock.Any(), int64(5)).Return(nil)
+			userRepoMock.EXPECT().Update(gomock.Any(), int64(1), gomock.Any()).Return(nil)
+			sessionSvcMock.EXPECT().Create(gomock.Any(), user).Return(token, nil)
+
+			result, err := svc.VerifyMagicLink(ctx, "plain-token", user.Email)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Token).To(Equal(token))
+			Expect(user.IsVerified()).To(BeTrue())
+		})
+		It("should still require the second factor", func() {
+			now := time.Now()
+			secret := "encrypted-secret"
+			user.EmailVerifiedAt = &now
+			user.TwoFactorSecret = &secret
+			user.TwoFactorConfirmedAt = &now
+			userRepoMock.EXPECT().FindByEmail(gomock.Any(), user.Email).Return(user, nil)
+			userTokenRepoMock.EXPECT().FindOne(gomock.Any(), int64(1), domain.TokenTypeMagicLink).Return(&domain.UserToken{
+				ID:        5,
+				Token:     "hashed-token",
+				ExpiresAt: time.Now().Add(time.Minute),
+			}, nil)
+			hasherMock.EXPECT().Verify("plain-token", "hashed-token").Return(true, nil)
+			userTokenRepoMock.EXPECT().Consume(gomock.Any(), int64(5)).Return(nil)
+			twoFactorSvcMock.EXPECT().CreateChallenge(gomock.Any(), user).Return("challenge-token", nil)
+
+			result, err := svc.VerifyMagicLink(ctx, "plain-token", user.Email)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.TwoFactorRequired()).To(BeTrue())
+		})
+		It("should reject an expired link", func() {
+			userRepoMock.EXPECT().FindByEmail(gomock.Any(), user.Email).Return(user, nil)
+			userTokenRepoMock.EXPECT().FindOne(gomock.Any(), int64(1), domain.TokenTypeMagicLink).Return(&domain.UserToken{
+				Token:     "hashed-token",
+				ExpiresAt: time.Now().Add(-time.Minute),
+			}, nil)
+
+			result, err := svc.VerifyMagicLink(ctx, "plain-token", user.Email)
+			Expect(result).To(BeNil())
+			var appErr *errdefs.AppError
+			Expect(errors.As(err, &appErr)).To(BeTrue())
+			Expect(appErr.Status).To(Equal(400))
+		})
+		It("should sign in only one of concurrent requests with the same link", func() {
+			userRepoMock.EXPECT().FindByEmail(gomock.Any(), user.Email).Return(user, nil)
+			userTokenRepoMock.EXPECT().FindOne(gomock.Any(), int64(1), domain.TokenTypeMagicLink).Return(&domain.UserToken{
+				ID:        5,
+				Token:     "hashed-token",
+				ExpiresAt: time.Now().Add(time.Minute),
+			}, nil)
+			hasherMock.EXPECT().Verify("plain-token", "hashed-token").Return(true, nil)
+			// The other request deleted the token in the meantime.
+			userTokenRepoMock.EXPECT().Consume(gomock.Any(), int64(5)).Return(domain.ErrResourceNotFound)
+
+			result, err := svc.VerifyMagicLink(ctx, "plain-token", user.Email)
+			Expect(result).To(BeNil())
+			var appErr *errdefs.AppError
+			Expect(errors.As(err, &appErr)).To(BeTrue())
+			Expect(appErr.Status).To(Equal(401))
+		})
+		It("should reject a link that was already used", func() {
+			userRepoMock.EXPECT().FindByEmail(gomock.Any(), user.Email).Return(user, nil)
+			userTokenRepoMock.EXPECT().FindOne(gomock.Any(), int64(1), domain.TokenTypeMagicLink).Return(nil, domain.ErrResourceNotFound)
+
+			result, err := svc.VerifyMagicLink(ctx, "plain-token", user.Email)
+			Expect(result).To(BeNil())
+			var appErr *errdefs.AppError
+			Expect(errors.As(err, &appErr)).To(BeTrue())
+			Expect(appErr.Status).To(Equal(400))
+		})
+	})
 })
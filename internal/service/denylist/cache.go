@@ -0,0 +1,65 @@
+package denylist
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// cache is a size bounded LRU of denylist lookups, positive and negative.
+type cache struct {
+	mu    sync.Mutex
+	size  int
+	order *list.List
+	items map[string]*list.Element
+}
+
+type cacheEntry struct {
+	key       string
+	revoked   bool
+	expiresAt time.Time
+}
+
+func newCache(size int) *cache {
+	return &cache{
+		size:  size,
+		order: list.New(),
+		items: make(map[string]*list.Element),
+	}
+}
+
+func (c *cache) get(key string, now time.Time) (revoked, ok bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	el, ok := c.items[key]
+	if !ok {
+		return false, false
+	}
+	entry := el.Value.(*cacheEntry)
+	if !now.Before(entry.expiresAt) {
+		c.order.Remove(el)
+		delete(c.items, key)
+		return false, false
+	}
+	c.order.MoveToFront(el)
+	return entry.revoked, true
+}
+
+func (c *cache) add(key string, revoked bool, expiresAt time.Time) {
+	if c.size <= 0 {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if el, ok := c.items[key]; ok {
+		el.Value = &cacheEntry{key: key, revoked: revoked, expiresAt: expiresAt}
+		c.order.MoveToFront(el)
+		return
+	}
+	c.items[key] = c.order.PushFront(&cacheEntry{key: key, revoked: revoked, expiresAt: expiresAt})
+	if c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.items, oldest.Value.(*cacheEntry).key)
+	}
+}
@@ -0,0 +1,101 @@
+package denylist
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/akfaiz/go-vue-starter-kit/internal/config"
+	"github.com/akfaiz/go-vue-starter-kit/internal/domain"
+)
+
+type service struct {
+	cfg              config.Auth
+	revokedTokenRepo domain.RevokedTokenRepository
+	cache            *cache
+}
+
+func NewService(cfg config.Auth, revokedTokenRepo domain.RevokedTokenRepository) domain.TokenDenylist {
+	return &service{
+		cfg:              cfg,
+		revokedTokenRepo: revokedTokenRepo,
+		cache:            newCache(cfg.TokenDenylist.CacheSize),
+	}
+}
+
+func (s *service) Revoke(ctx context.Context, jti string, expiresAt time.Time) error {
+	return s.revoke(ctx, []string{jtiKey(jti)}, expiresAt.Add(s.cfg.JWT.Leeway))
+}
+
+func (s *service) RevokeSessions(ctx context.Context, sessionIDs ...string) error {
+	keys := make([]string, 0, len(sessionIDs))
+	for _, id := range sessionIDs {
+		keys = append(keys, sessionKey(id))
+	}
+	return s.revoke(ctx, keys, s.lastExpiry(time.Now()))
+}
+
+func (s *service) IsRevoked(ctx context.Context, claims *domain.JWTClaims) (bool, error) {
+	now := time.Now()
+	var missing []string
+	for _, key := range claimKeys(claims) {
+		revoked, ok := s.cache.get(key, now)
+		if revoked {
+			return true, nil
+		}
+		if !ok {
+			missing = append(missing, key)
+		}
+	}
+	if len(missing) == 0 {
+		return false, nil
+	}
+
+	active, err := s.revokedTokenRepo.FindActive(ctx, missing)
+	if err != nil {
+		return false, err
+	}
+	for _, key := range missing {
+		if slices.Contains(active, key) {
+			s.cache.add(key, true, s.lastExpiry(now))
+		} else {
+			s.cache.add(key, false, now.Add(s.cfg.TokenDenylist.CacheTTL))
+		}
+	}
+	return len(active) > 0, nil
+}
+
+func (s *service) revoke(ctx context.Context, keys []string, until time.Time) error {
+	_ = s.revokedTokenRepo.DeleteExpired(ctx)
+	for _, key := range keys {
+		if err := s.revokedTokenRepo.Create(ctx, key, until); err != nil {
+			return err
+		}
+		s.cache.add(key, true, until)
+	}
+	return nil
+}
+
+// lastExpiry is the latest time an access token issued at now is accepted.
+func (s *service) lastExpiry(now time.Time) time.Time {
+	return now.Add(s.cfg.JWT.AccessExpires + s.cfg.JWT.Leeway)
+}
+
+func claimKeys(claims *domain.JWTClaims) []string {
+	var keys []string
+	if claims.RegisteredClaims.ID != "" {
+		keys = append(keys, jtiKey(claims.RegisteredClaims.ID))
+	}
+	if claims.SessionID != "" {
+		keys = append(keys, sessionKey(claims.SessionID))
+	}
+	return keys
+}
+
+func jtiKey(jti string) string {
+	return "jti:" + jti
+}
+
+func sessionKey(id string) string {
+	return "sid:" + id
+}
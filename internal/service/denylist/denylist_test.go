@@ -0,0 +1,129 @@
+package denylist_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/akfaiz/go-vue-starter-kit/internal/config"
+	"github.com/akfaiz/go-vue-starter-kit/internal/domain"
+	"github.com/akfaiz/go-vue-starter-kit/internal/mocks"
+	"github.com/akfaiz/go-vue-starter-kit/internal/service/denylist"
+	"github.com/golang-jwt/jwt/v5"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+)
+
+var _ = Describe("Token Denylist", Label("unit", "usecase"), func() {
+	var (
+		repoMock *mocks.MockRevokedTokenRepository
+		svc      domain.TokenDenylist
+		cfg      config.Auth
+
+		ctx    context.Context
+		claims *domain.JWTClaims
+	)
+	BeforeEach(func() {
+		ctrl := gomock.NewController(GinkgoT())
+		repoMock = mocks.NewMockRevokedTokenRepository(ctrl)
+		cfg = config.Auth{
+			JWT:           config.JWT{AccessExpires: 15 * time.Minute, Leeway: 30 * time.Second},
+			TokenDenylist: config.TokenDenylist{CacheSize: 100, CacheTTL: time.Minute},
+		}
+		svc = denylist.NewService(cfg, repoMock)
+
+		ctx = context.Background()
+		claims = &domain.JWTClaims{
+			RegisteredClaims: jwt.RegisteredClaims{ID: "jti-1"},
+			SessionID:        "session-1",
+		}
+
+		DeferCleanup(func() {
+			ctrl.Finish()
+		})
+	})
+
+	Describe("Revoke", func() {
+		It("should store the jti until the token expires and deny it without a lookup", func() {
+			expiresAt := time.Now().Add(10 * time.Minute)
+			repoMock.EXPECT().DeleteExpired(ctx).Return(nil)
+			repoMock.EXPECT().Create(ctx, "jti:jti-1", expiresAt.Add(30*time.Second)).Return(nil)
+
+			Expect(svc.Revoke(ctx, "jti-1", expiresAt)).To(Succeed())
+
+			revoked, err := svc.IsRevoked(ctx, claims)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(revoked).To(BeTrue())
+		})
+	})
+
+	Describe("RevokeSessions", func() {
+		It("should deny the sessions for the longest access token lifetime", func() {
+			before := time.Now()
+			repoMock.EXPECT().DeleteExpired(ctx).Return(nil)
+			repoMock.EXPECT().Create(ctx, "sid:session-1", gomock.Any()).DoAndReturn(
+				func(_ context.Context, _ string, expiresAt time.Time) error {
+					Expect(expiresAt).To(BeTemporally(">=", before.Add(15*time.Minute+30*time.Second)))
+					return nil
+				})
+			repoMock.EXPECT().Create(ctx, "sid:session-2", gomock.Any()).Return(nil)
+
+			Expect(svc.RevokeSessions(ctx, "session-1", "session-2")).To(Succeed())
+
+			revoked, err := svc.IsRevoked(ctx, claims)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(revoked).To(BeTrue())
+		})
+	})
+
+	Describe("IsRevoked", func() {
+		When("the store has the session revoked", func() {
+			BeforeEach(func() {
+				repoMock.EXPECT().FindActive(ctx, []string{"jti:jti-1", "sid:session-1"}).Return([]string{"sid:session-1"}, nil).Times(1)
+			})
+			It("should deny the token and cache the answer", func() {
+				for range 2 {
+					revoked, err := svc.IsRevoked(ctx, claims)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(revoked).To(BeTrue())
+				}
+			})
+		})
+		When("the token is not revoked", func() {
+			BeforeEach(func() {
+				repoMock.EXPECT().FindActive(ctx, []string{"jti:jti-1", "sid:session-1"}).Return(nil, nil).Times(1)
+			})
+			It("should accept the token and cache the answer", func() {
+				for range 2 {
+					revoked, err := svc.IsRevoked(ctx, claims)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(revoked).To(BeFalse())
+				}
+			})
+		})
+		When("the negative cache is disabled", func() {
+			BeforeEach(func() {
+				cfg.TokenDenylist.CacheTTL = 0
+				svc = denylist.NewService(cfg, repoMock)
+				repoMock.EXPECT().FindActive(ctx, gomock.Any()).Return(nil, nil).Times(2)
+			})
+			It("should ask the store every time", func() {
+				for range 2 {
+					revoked, err := svc.IsRevoked(ctx, claims)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(revoked).To(BeFalse())
+				}
+			})
+		})
+		When("the store fails", func() {
+			BeforeEach(func() {
+				repoMock.EXPECT().FindActive(ctx, gomock.Any()).Return(nil, errors.New("db down"))
+			})
+			It("should return the error", func() {
+				_, err := svc.IsRevoked(ctx, claims)
+				Expect(err).To(HaveOccurred())
+			})
+		})
+	})
+})
@@ -0,0 +1,124 @@
+package impersonation
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/akfaiz/go-vue-starter-kit/internal/config"
+	"github.com/akfaiz/go-vue-starter-kit/internal/domain"
+	"github.com/akfaiz/go-vue-starter-kit/internal/errdefs"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/invopop/ctxi18n/i18n"
+)
+
+type service struct {
+	cfg               config.Auth
+	impersonationRepo domain.ImpersonationRepository
+	userRepo          domain.UserRepository
+	jwtManager        domain.JWTManager
+	denylist          domain.TokenDenylist
+	auditLogger       domain.AuditLogger
+}
+
+func NewService(
+	cfg config.Auth,
+	impersonationRepo domain.ImpersonationRepository,
+	userRepo domain.UserRepository,
+	jwtManager domain.JWTManager,
+	denylist domain.TokenDenylist,
+	auditLogger domain.AuditLogger,
+) domain.ImpersonationService {
+	return &service{
+		cfg:               cfg,
+		impersonationRepo: impersonationRepo,
+		userRepo:          userRepo,
+		jwtManager:        jwtManager,
+		denylist:          denylist,
+		auditLogger:       auditLogger,
+	}
+}
+
+func (s *service) Start(ctx context.Context, impersonatorID, userID int64) (*domain.ImpersonationToken, error) {
+	if impersonatorID == userID {
+		return nil, errdefs.ErrForbidden(i18n.T(ctx, "impersonation.self"))
+	}
+	impersonator, err := s.userRepo.FindByID(ctx, impersonatorID)
+	if err != nil {
+		return nil, err
+	}
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.IsSuspended() {
+		return nil, errdefs.ErrForbidden(i18n.T(ctx, "impersonation.suspended"))
+	}
+
+	// The token has no session, so it cannot be refreshed and only the denylist
+	// entry for its jti can end it early.
+	tokenID := uuid.NewString()
+	expiresAt := time.Now().Add(min(s.cfg.ImpersonationExpiration, s.cfg.JWT.AccessExpires))
+	claims := &domain.JWTClaims{
+		ID:    user.ID,
+		Name:  user.Name,
+		Email: user.Email,
+		Actor: &domain.JWTActor{
+			Subject: strconv.FormatInt(impersonator.ID, 10),
+			ID:      impersonator.ID,
+			Name:    impersonator.Name,
+			Email:   impersonator.Email,
+		},
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        tokenID,
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		},
+	}
+	accessToken, err := s.jwtManager.GenerateAccessToken(claims)
+	if err != nil {
+		return nil, err
+	}
+
+	client := domain.ClientInfoFromContext(ctx)
+	impersonation := &domain.Impersonation{
+		ImpersonatorID: impersonator.ID,
+		UserID:         user.ID,
+		TokenID:        tokenID,
+		UserAgent:      client.UserAgent,
+		IPAddress:      client.IPAddress,
+		ExpiresAt:      expiresAt,
+	}
+	if err := s.impersonationRepo.Create(ctx, impersonation); err != nil {
+		return nil, err
+	}
+	s.auditLogger.Log(ctx, domain.AuditImpersonationStarted, user.ID, map[string]any{"token_id": tokenID})
+
+	return &domain.ImpersonationToken{
+		AccessToken: accessToken,
+		ExpiresAt:   expiresAt,
+	}, nil
+}
+
+func (s *service) Stop(ctx context.Context, claims *domain.JWTClaims) error {
+	if !claims.IsImpersonated() || claims.RegisteredClaims.ID == "" {
+		return errdefs.ErrBadRequest(i18n.T(ctx, "impersonation.not_active"))
+	}
+	expiresAt := time.Now()
+	if claims.ExpiresAt != nil {
+		expiresAt = claims.ExpiresAt.Time
+	}
+	if err := s.denylist.Revoke(ctx, claims.RegisteredClaims.ID, expiresAt); err != nil {
+		return err
+	}
+	err := s.impersonationRepo.End(ctx, claims.RegisteredClaims.ID, time.Now())
+	if errors.Is(err, domain.ErrResourceNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	s.auditLogger.Log(ctx, domain.AuditImpersonationStopped, claims.ID, map[string]any{"token_id": claims.RegisteredClaims.ID})
+	return nil
+}
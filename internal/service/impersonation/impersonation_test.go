@@ -0,0 +1,134 @@
+package impersonation_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/akfaiz/go-vue-starter-kit/internal/config"
+	"github.com/akfaiz/go-vue-starter-kit/internal/domain"
+	"github.com/akfaiz/go-vue-starter-kit/internal/errdefs"
+	"github.com/akfaiz/go-vue-starter-kit/internal/mocks"
+	"github.com/akfaiz/go-vue-starter-kit/internal/service/impersonation"
+	"github.com/golang-jwt/jwt/v5"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+)
+
+var _ = Describe("Impersonation Service", Label("unit", "usecase"), func() {
+	var (
+		impersonationRepoMock *mocks.MockImpersonationRepository
+		userRepoMock          *mocks.MockUserRepository
+		jwtManagerMock        *mocks.MockJWTManager
+		denylistMock          *mocks.MockTokenDenylist
+		auditLoggerMock       *mocks.MockAuditLogger
+		svc                   domain.ImpersonationService
+
+		ctx   context.Context
+		admin *domain.User
+		user  *domain.User
+	)
+	BeforeEach(func() {
+		ctrl := gomock.NewController(GinkgoT())
+		impersonationRepoMock = mocks.NewMockImpersonationRepository(ctrl)
+		userRepoMock = mocks.NewMockUserRepository(ctrl)
+		jwtManagerMock = mocks.NewMockJWTManager(ctrl)
+		denylistMock = mocks.NewMockTokenDenylist(ctrl)
+		cfg := config.Auth{
+			ImpersonationExpiration: 10 * time.Minute,
+			JWT:                     config.JWT{AccessExpires: time.Hour},
+		}
+		auditLoggerMock = mocks.NewMockAuditLogger(ctrl)
+		auditLoggerMock.EXPECT().Log(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+		svc = impersonation.NewService(cfg, impersonationRepoMock, userRepoMock, jwtManagerMock, denylistMock, auditLoggerMock)
+
+		ctx = domain.ContextWithClientInfo(context.Background(), domain.ClientInfo{IPAddress: "203.0.113.7"})
+		admin = &domain.User{ID: 1, Name: "Admin", Email: "admin@example.com"}
+		user = &domain.User{ID: 2, Name: "Jane Doe", Email: "jane@example.com"}
+
+		DeferCleanup(func() {
+			ctrl.Finish()
+		})
+	})
+
+	expectForbidden := func(err error) {
+		var appErr *errdefs.AppError
+		Expect(errors.As(err, &appErr)).To(BeTrue())
+		Expect(appErr.Status).To(Equal(403))
+	}
+
+	Describe("Start", func() {
+		It("should issue a token with the act claim and record it", func() {
+			var issued *domain.JWTClaims
+			userRepoMock.EXPECT().FindByID(ctx, int64(1)).Return(admin, nil)
+			userRepoMock.EXPECT().FindByID(ctx, int64(2)).Return(user, nil)
+			jwtManagerMock.EXPECT().GenerateAccessToken(gomock.Any()).DoAndReturn(func(claims *domain.JWTClaims) (string, error) {
+				issued = claims
+				return "access", nil
+			})
+			impersonationRepoMock.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, i *domain.Impersonation) error {
+				Expect(i.ImpersonatorID).To(Equal(int64(1)))
+				Expect(i.UserID).To(Equal(int64(2)))
+				Expect(i.TokenID).To(Equal(issued.RegisteredClaims.ID))
+				Expect(i.IPAddress).To(Equal("203.0.113.7"))
+				return nil
+			})
+
+			token, err := svc.Start(ctx, 1, 2)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(token.AccessToken).To(Equal("access"))
+			Expect(token.ExpiresAt).To(BeTemporally("~", time.Now().Add(10*time.Minute), time.Second))
+			Expect(issued.ID).To(Equal(int64(2)))
+			Expect(issued.SessionID).To(BeEmpty())
+			Expect(issued.Actor).To(Equal(&domain.JWTActor{Subject: "1", ID: 1, Name: "Admin", Email: "admin@example.com"}))
+			Expect(issued.RegisteredClaims.ID).NotTo(BeEmpty())
+		})
+		It("should refuse to impersonate yourself", func() {
+			_, err := svc.Start(ctx, 1, 1)
+			expectForbidden(err)
+		})
+		It("should refuse to impersonate a suspended user", func() {
+			user.Status = domain.UserStatusSuspended
+			userRepoMock.EXPECT().FindByID(ctx, int64(1)).Return(admin, nil)
+			userRepoMock.EXPECT().FindByID(ctx, int64(2)).Return(user, nil)
+
+			_, err := svc.Start(ctx, 1, 2)
+			expectForbidden(err)
+		})
+		It("should report a missing user", func() {
+			userRepoMock.EXPECT().FindByID(ctx, int64(1)).Return(admin, nil)
+			userRepoMock.EXPECT().FindByID(ctx, int64(3)).Return(nil, domain.ErrResourceNotFound)
+
+			_, err := svc.Start(ctx, 1, 3)
+			Expect(err).To(MatchError(domain.ErrResourceNotFound))
+		})
+	})
+
+	Describe("Stop", func() {
+		It("should revoke the token and record the end", func() {
+			expiresAt := time.Now().Add(5 * time.Minute)
+			claims := &domain.JWTClaims{
+				ID:    2,
+				Actor: &domain.JWTActor{ID: 1},
+				RegisteredClaims: jwt.RegisteredClaims{
+					ID:        "token-1",
+					ExpiresAt: jwt.NewNumericDate(expiresAt),
+				},
+			}
+			denylistMock.EXPECT().Revoke(ctx, "token-1", claims.ExpiresAt.Time).Return(nil)
+			impersonationRepoMock.EXPECT().End(ctx, "token-1", gomock.Any()).Return(nil)
+
+			Expect(svc.Stop(ctx, claims)).To(Succeed())
+		})
+		It("should reject a token that is not impersonating", func() {
+			claims := &domain.JWTClaims{ID: 2, SessionID: "session-1"}
+			claims.RegisteredClaims.ID = "token-1"
+
+			var appErr *errdefs.AppError
+			Expect(errors.As(svc.Stop(ctx, claims), &appErr)).To(BeTrue())
+			Expect(appErr.Status).To(Equal(400))
+		})
+	})
+})
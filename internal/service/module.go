@@ -1,7 +1,20 @@
 package service
 
 import (
+	"github.com/akfaiz/go-vue-starter-kit/internal/service/admin"
+	"github.com/akfaiz/go-vue-starter-kit/internal/service/audit"
 	"github.com/akfaiz/go-vue-starter-kit/internal/service/auth"
+	"github.com/akfaiz/go-vue-starter-kit/internal/service/denylist"
+	"github.com/akfaiz/go-vue-starter-kit/internal/service/impersonation"
+	"github.com/akfaiz/go-vue-starter-kit/internal/service/oauth"
+	"github.com/akfaiz/go-vue-starter-kit/internal/service/passkey"
+	"github.com/akfaiz/go-vue-starter-kit/internal/service/password"
+	"github.com/akfaiz/go-vue-starter-kit/internal/service/personalaccesstoken"
+	"github.com/akfaiz/go-vue-starter-kit/internal/service/rbac"
+	"github.com/akfaiz/go-vue-starter-kit/internal/service/session"
+	"github.com/akfaiz/go-vue-starter-kit/internal/service/suspension"
+	"github.com/akfaiz/go-vue-starter-kit/internal/service/throttle"
+	"github.com/akfaiz/go-vue-starter-kit/internal/service/twofactor"
 	"github.com/akfaiz/go-vue-starter-kit/internal/service/user"
 	"go.uber.org/fx"
 )
@@ -10,5 +23,22 @@ var Module = fx.Module("service",
 	fx.Provide(
 		auth.NewService,
 		user.NewService,
+		session.NewService,
+		twofactor.NewService,
+		passkey.NewService,
+		oauth.NewService,
+		throttle.NewLoginThrottler,
+		denylist.NewService,
+		personalaccesstoken.NewService,
+		rbac.NewService,
+		admin.NewUserService,
+		suspension.NewService,
+		impersonation.NewService,
+		audit.NewLogger,
+		audit.NewService,
+		password.NewPolicy,
+		password.NewRangeClient,
+		password.NewHistory,
+		password.NewChanger,
 	),
 )
@@ -0,0 +1,353 @@
+package oauth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/aarondl/opt/omitnull"
+	"github.com/akfaiz/go-vue-starter-kit/internal/config"
+	"github.com/akfaiz/go-vue-starter-kit/internal/domain"
+	"github.com/akfaiz/go-vue-starter-kit/internal/errdefs"
+	"github.com/coreos/go-oidc/v3/oidc"
+	"github.com/invopop/ctxi18n/i18n"
+	"golang.org/x/oauth2"
+)
+
+type service struct {
+	cfg              config.OAuth
+	providers        map[string]*provider
+	names            []string
+	identityRepo     domain.UserIdentityRepository
+	userRepo         domain.UserRepository
+	passkeyRepo      domain.PasskeyRepository
+	sessionService   domain.SessionService
+	twoFactorService domain.TwoFactorService
+	encrypter        domain.Encrypter
+	auditLogger      domain.AuditLogger
+}
+
+func NewService(
+	cfg config.Config,
+	identityRepo domain.UserIdentityRepository,
+	userRepo domain.UserRepository,
+	passkeyRepo domain.PasskeyRepository,
+	sessionService domain.SessionService,
+	twoFactorService domain.TwoFactorService,
+	encrypter domain.Encrypter,
+	auditLogger domain.AuditLogger,
+) domain.OAuthService {
+	s := &service{
+		cfg:              cfg.OAuth,
+		providers:        make(map[string]*provider, len(cfg.OAuth.Providers)),
+		identityRepo:     identityRepo,
+		userRepo:         userRepo,
+		passkeyRepo:      passkeyRepo,
+		sessionService:   sessionService,
+		twoFactorService: twoFactorService,
+		encrypter:        encrypter,
+		auditLogger:      auditLogger,
+	}
+	for _, p := range cfg.OAuth.Providers {
+		redirectURL := strings.TrimSuffix(cfg.App.ApiBaseURL, "/") + "/v1/auth/oauth/" + p.Name + "/callback"
+		s.providers[p.Name] = newProvider(p, redirectURL)
+		s.names = append(s.names, p.Name)
+	}
+	return s
+}
+
+func (s *service) Providers() []string {
+	return s.names
+}
+
+// flowState is everything the callback needs to finish the flow. It is
+// encrypted with the application key and round-trips through a cookie.
+type flowState struct {
+	Provider  string `json:"p"`
+	State     string `json:"s"`
+	Nonce     string `json:"n,omitempty"`
+	Verifier  string `json:"v"`
+	UserID    int64  `json:"uid,omitempty"`
+	ExpiresAt int64  `json:"exp"`
+}
+
+func (s *service) Redirect(ctx context.Context, providerName string, linkUserID int64) (*domain.OAuthRedirect, error) {
+	p, err := s.provider(ctx, providerName)
+	if err != nil {
+		return nil, err
+	}
+	oauth2Config, err := p.config(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	flow := flowState{
+		Provider:  providerName,
+		State:     randomToken(),
+		Verifier:  oauth2.GenerateVerifier(),
+		UserID:    linkUserID,
+		ExpiresAt: time.Now().Add(s.cfg.StateExpiration).Unix(),
+	}
+	opts := []oauth2.AuthCodeOption{oauth2.S256ChallengeOption(flow.Verifier)}
+	if p.isOIDC() {
+		flow.Nonce = randomToken()
+		opts = append(opts, oidc.Nonce(flow.Nonce))
+	}
+
+	payload, err := json.Marshal(flow)
+	if err != nil {
+		return nil, err
+	}
+	encrypted, err := s.encrypter.Encrypt(string(payload))
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.OAuthRedirect{
+		URL:       oauth2Config.AuthCodeURL(flow.State, opts...),
+		FlowState: encrypted,
+	}, nil
+}
+
+func (s *service) Callback(ctx context.Context, providerName, code, state, encryptedFlow string) (*domain.OAuthCallbackResult, error) {
+	p, err := s.provider(ctx, providerName)
+	if err != nil {
+		return nil, err
+	}
+	flow, err := s.parseFlowState(encryptedFlow)
+	if err != nil || flow.Provider != providerName ||
+		subtle.ConstantTimeCompare([]byte(flow.State), []byte(state)) != 1 {
+		return nil, errdefs.ErrBadRequest(i18n.T(ctx, "oauth.state"))
+	}
+
+	oauth2Config, err := p.config(ctx)
+	if err != nil {
+		return nil, err
+	}
+	token, err := oauth2Config.Exchange(ctx, code, oauth2.VerifierOption(flow.Verifier))
+	if err != nil {
+		return nil, errdefs.ErrBadRequest(i18n.T(ctx, "oauth.failed")).WithCause(err)
+	}
+	identity, err := p.identity(ctx, token, flow.Nonce)
+	if err != nil {
+		return nil, errdefs.ErrBadRequest(i18n.T(ctx, "oauth.failed")).WithCause(err)
+	}
+
+	if flow.UserID != 0 {
+		if err := s.link(ctx, flow.UserID, providerName, identity); err != nil {
+			return nil, err
+		}
+		return &domain.OAuthCallbackResult{Linked: true}, nil
+	}
+
+	user, err := s.findOrCreateUser(ctx, providerName, identity)
+	if err != nil {
+		return nil, err
+	}
+	login, err := s.login(ctx, providerName, user)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.OAuthCallbackResult{Login: login}, nil
+}
+
+func (s *service) ListIdentities(ctx context.Context, userID int64) ([]*domain.UserIdentity, error) {
+	return s.identityRepo.FindByUserID(ctx, userID)
+}
+
+func (s *service) Unlink(ctx context.Context, userID int64, providerName string) error {
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	identities, err := s.identityRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if !hasProvider(identities, providerName) {
+		return domain.ErrResourceNotFound
+	}
+
+	// Never remove the last way the user can sign in.
+	if !user.HasPassword() && len(identities) == 1 {
+		passkeys, err := s.passkeyRepo.FindByUserID(ctx, userID)
+		if err != nil {
+			return err
+		}
+		if len(passkeys) == 0 {
+			return errdefs.ErrBadRequest(i18n.T(ctx, "oauth.last_method"))
+		}
+	}
+
+	return s.identityRepo.Delete(ctx, userID, providerName)
+}
+
+func (s *service) link(ctx context.Context, userID int64, providerName string, identity *claims) error {
+	existing, err := s.identityRepo.FindByProviderSubject(ctx, providerName, identity.Subject)
+	if err == nil {
+		if existing.UserID != userID {
+			return errdefs.ErrConflict(i18n.T(ctx, "oauth.identity_taken"))
+		}
+		return nil
+	}
+	if !errors.Is(err, domain.ErrResourceNotFound) {
+		return err
+	}
+
+	identities, err := s.identityRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if hasProvider(identities, providerName) {
+		return errdefs.ErrConflict(i18n.T(ctx, "oauth.already_linked"))
+	}
+
+	return s.identityRepo.Create(ctx, &domain.UserIdentity{
+		UserID:   userID,
+		Provider: providerName,
+		Subject:  identity.Subject,
+		Email:    identity.Email,
+	})
+}
+
+// findOrCreateUser resolves the account for a provider identity. An existing
+// account is only linked automatically when the provider has verified the email,
+// otherwise anyone could claim an account by registering its email elsewhere.
+// Nor is it linked while the account has a password but an unverified email:
+// whoever registered it may not own the address and would keep their password.
+func (s *service) findOrCreateUser(ctx context.Context, providerName string, identity *claims) (*domain.User, error) {
+	linked, err := s.identityRepo.FindByProviderSubject(ctx, providerName, identity.Subject)
+	if err == nil {
+		user, err := s.userRepo.FindByID(ctx, linked.UserID)
+		if err != nil {
+			return nil, err
+		}
+		return user, s.markEmailVerified(ctx, user, identity)
+	}
+	if !errors.Is(err, domain.ErrResourceNotFound) {
+		return nil, err
+	}
+
+	if identity.Email == "" {
+		return nil, errdefs.ErrBadRequest(i18n.T(ctx, "oauth.email_missing"))
+	}
+	user, err := s.userRepo.FindByEmail(ctx, identity.Email)
+	switch {
+	case err == nil:
+		if !identity.EmailVerified {
+			return nil, errdefs.ErrConflict(i18n.T(ctx, "oauth.email_taken"))
+		}
+		if !user.IsVerified() && user.HasPassword() {
+			return nil, errdefs.ErrConflict(i18n.T(ctx, "oauth.email_unverified"))
+		}
+		if err := s.markEmailVerified(ctx, user, identity); err != nil {
+			return nil, err
+		}
+	case errors.Is(err, domain.ErrResourceNotFound):
+		user = &domain.User{
+			Name:  identity.Name,
+			Email: identity.Email,
+		}
+		if user.Name == "" {
+			user.Name = identity.Email
+		}
+		if identity.EmailVerified {
+			now := time.Now()
+			user.EmailVerifiedAt = &now
+		}
+		if err := s.userRepo.Create(ctx, user); err != nil {
+			return nil, err
+		}
+		s.auditLogger.Log(ctx, domain.AuditRegistered, user.ID, map[string]any{"provider": providerName})
+	default:
+		return nil, err
+	}
+
+	if err := s.identityRepo.Create(ctx, &domain.UserIdentity{
+		UserID:   user.ID,
+		Provider: providerName,
+		Subject:  identity.Subject,
+		Email:    identity.Email,
+	}); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+func (s *service) markEmailVerified(ctx context.Context, user *domain.User, identity *claims) error {
+	if user.IsVerified() || !identity.EmailVerified || !strings.EqualFold(user.Email, identity.Email) {
+		return nil
+	}
+	now := time.Now()
+	if err := s.userRepo.Update(ctx, user.ID, &domain.UserUpdate{
+		EmailVerifiedAt: omitnull.From(now),
+	}); err != nil {
+		return err
+	}
+	user.EmailVerifiedAt = &now
+	return nil
+}
+
+// login issues tokens, still requiring the second factor when the user enabled it.
+func (s *service) login(ctx context.Context, providerName string, user *domain.User) (*domain.LoginResult, error) {
+	if user.HasTwoFactorEnabled() {
+		challengeToken, err := s.twoFactorService.CreateChallenge(ctx, user)
+		if err != nil {
+			return nil, err
+		}
+		return &domain.LoginResult{ChallengeToken: challengeToken}, nil
+	}
+
+	token, err := s.sessionService.Create(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+	s.auditLogger.Log(ctx, domain.AuditLogin, user.ID, map[string]any{
+		"method":   "oauth",
+		"provider": providerName,
+	})
+	return &domain.LoginResult{Token: token}, nil
+}
+
+func (s *service) provider(ctx context.Context, name string) (*provider, error) {
+	p, ok := s.providers[name]
+	if !ok {
+		return nil, errdefs.ErrNotFound(i18n.T(ctx, "oauth.provider"))
+	}
+	return p, nil
+}
+
+func (s *service) parseFlowState(encrypted string) (*flowState, error) {
+	payload, err := s.encrypter.Decrypt(encrypted)
+	if err != nil {
+		return nil, err
+	}
+	var flow flowState
+	if err := json.Unmarshal([]byte(payload), &flow); err != nil {
+		return nil, err
+	}
+	if time.Now().Unix() > flow.ExpiresAt {
+		return nil, errors.New("oauth flow expired")
+	}
+	return &flow, nil
+}
+
+func hasProvider(identities []*domain.UserIdentity, providerName string) bool {
+	for _, identity := range identities {
+		if identity.Provider == providerName {
+			return true
+		}
+	}
+	return false
+}
+
+func randomToken() string {
+	b := make([]byte, 32)
+	_, _ = rand.Read(b)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
@@ -0,0 +1,309 @@
+package oauth_test
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"time"
+
+	"github.com/akfaiz/go-vue-starter-kit/internal/config"
+	"github.com/akfaiz/go-vue-starter-kit/internal/domain"
+	"github.com/akfaiz/go-vue-starter-kit/internal/errdefs"
+	"github.com/akfaiz/go-vue-starter-kit/internal/hash/aesgcm"
+	"github.com/akfaiz/go-vue-starter-kit/internal/mocks"
+	"github.com/akfaiz/go-vue-starter-kit/internal/service/oauth"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/invopop/ctxi18n"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+)
+
+var _ = Describe("OAuth Service", Label("unit", "usecase"), func() {
+	var (
+		identityRepoMock *mocks.MockUserIdentityRepository
+		userRepoMock     *mocks.MockUserRepository
+		passkeyRepoMock  *mocks.MockPasskeyRepository
+		sessionSvcMock   *mocks.MockSessionService
+		twoFactorSvcMock *mocks.MockTwoFactorService
+		auditLoggerMock  *mocks.MockAuditLogger
+		svc              domain.OAuthService
+		issuer           *fakeIssuer
+
+		ctx        context.Context
+		users      map[int64]*domain.User
+		identities []*domain.UserIdentity
+	)
+	BeforeEach(func() {
+		ctrl := gomock.NewController(GinkgoT())
+		identityRepoMock = mocks.NewMockUserIdentityRepository(ctrl)
+		userRepoMock = mocks.NewMockUserRepository(ctrl)
+		passkeyRepoMock = mocks.NewMockPasskeyRepository(ctrl)
+		sessionSvcMock = mocks.NewMockSessionService(ctrl)
+		twoFactorSvcMock = mocks.NewMockTwoFactorService(ctrl)
+
+		issuer = newFakeIssuer("client-id")
+		DeferCleanup(issuer.Close)
+
+		encrypter, err := aesgcm.NewEncrypter(config.App{Key: "test-app-key"})
+		Expect(err).NotTo(HaveOccurred())
+		cfg := config.Config{}
+		cfg.App.ApiBaseURL = "http://localhost:8080/api"
+		cfg.OAuth.StateExpiration = time.Minute
+		cfg.OAuth.Providers = []config.OAuthProvider{{
+			Name:         "acme",
+			Issuer:       issuer.URL(),
+			ClientID:     "client-id",
+			ClientSecret: "client-secret",
+		}}
+		auditLoggerMock = mocks.NewMockAuditLogger(ctrl)
+		auditLoggerMock.EXPECT().Log(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+		svc = oauth.NewService(cfg, identityRepoMock, userRepoMock, passkeyRepoMock, sessionSvcMock, twoFactorSvcMock, encrypter, auditLoggerMock)
+
+		ctx = context.Background()
+		ctx, _ = ctxi18n.WithLocale(ctx, "en")
+		users = map[int64]*domain.User{}
+		identities = nil
+
+		// Back the repositories with in-memory state so full flows can run.
+		userRepoMock.EXPECT().FindByID(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, id int64) (*domain.User, error) {
+				if user, ok := users[id]; ok {
+					return user, nil
+				}
+				return nil, domain.ErrResourceNotFound
+			}).AnyTimes()
+		userRepoMock.EXPECT().FindByEmail(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, email string) (*domain.User, error) {
+				for _, user := range users {
+					if user.Email == email {
+						return user, nil
+					}
+				}
+				return nil, domain.ErrResourceNotFound
+			}).AnyTimes()
+		userRepoMock.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, user *domain.User) error {
+				user.ID = int64(len(users) + 1)
+				users[user.ID] = user
+				return nil
+			}).AnyTimes()
+		userRepoMock.EXPECT().Update(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+		identityRepoMock.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, identity *domain.UserIdentity) error {
+				identities = append(identities, identity)
+				return nil
+			}).AnyTimes()
+		identityRepoMock.EXPECT().FindByProviderSubject(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, provider, subject string) (*domain.UserIdentity, error) {
+				for _, identity := range identities {
+					if identity.Provider == provider && identity.Subject == subject {
+						return identity, nil
+					}
+				}
+				return nil, domain.ErrResourceNotFound
+			}).AnyTimes()
+		identityRepoMock.EXPECT().FindByUserID(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, userID int64) ([]*domain.UserIdentity, error) {
+				var res []*domain.UserIdentity
+				for _, identity := range identities {
+					if identity.UserID == userID {
+						res = append(res, identity)
+					}
+				}
+				return res, nil
+			}).AnyTimes()
+		sessionSvcMock.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, user *domain.User) (*domain.PairToken, error) {
+				return &domain.PairToken{AccessToken: "access-token", RefreshToken: "refresh-token"}, nil
+			}).AnyTimes()
+
+		DeferCleanup(func() {
+			ctrl.Finish()
+		})
+	})
+
+	// signIn runs the whole authorization code flow for the given ID token claims.
+	signIn := func(linkUserID int64, claims jwt.MapClaims) (*domain.OAuthCallbackResult, error) {
+		redirect, err := svc.Redirect(ctx, "acme", linkUserID)
+		Expect(err).NotTo(HaveOccurred())
+		authURL, err := url.Parse(redirect.URL)
+		Expect(err).NotTo(HaveOccurred())
+		query := authURL.Query()
+
+		if _, ok := claims["nonce"]; !ok {
+			claims["nonce"] = query.Get("nonce")
+		}
+		code := issuer.authorize(query.Get("code_challenge"), claims)
+		return svc.Callback(ctx, "acme", code, query.Get("state"), redirect.FlowState)
+	}
+
+	Context("Redirect", func() {
+		It("should build an authorization URL with state, nonce and a PKCE challenge", func() {
+			redirect, err := svc.Redirect(ctx, "acme", 0)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(redirect.FlowState).NotTo(BeEmpty())
+
+			authURL, err := url.Parse(redirect.URL)
+			Expect(err).NotTo(HaveOccurred())
+			query := authURL.Query()
+			Expect(authURL.Path).To(Equal("/authorize"))
+			Expect(query.Get("client_id")).To(Equal("client-id"))
+			Expect(query.Get("redirect_uri")).To(Equal("http://localhost:8080/api/v1/auth/oauth/acme/callback"))
+			Expect(query.Get("code_challenge_method")).To(Equal("S256"))
+			Expect(query.Get("code_challenge")).NotTo(BeEmpty())
+			Expect(query.Get("state")).NotTo(BeEmpty())
+			Expect(query.Get("nonce")).NotTo(BeEmpty())
+		})
+
+		It("should reject an unknown provider", func() {
+			_, err := svc.Redirect(ctx, "unknown", 0)
+			var appErr *errdefs.AppError
+			Expect(errors.As(err, &appErr)).To(BeTrue())
+			Expect(appErr.Status).To(Equal(404))
+		})
+	})
+
+	Context("Callback", func() {
+		It("should create a verified user on first sign in", func() {
+			result, err := signIn(0, jwt.MapClaims{
+				"sub": "acme-1", "email": "jane@example.com", "email_verified": true, "name": "Jane Doe",
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Login.Token.AccessToken).To(Equal("access-token"))
+
+			Expect(users).To(HaveLen(1))
+			Expect(users[1].Name).To(Equal("Jane Doe"))
+			Expect(users[1].IsVerified()).To(BeTrue())
+			Expect(users[1].HasPassword()).To(BeFalse())
+			Expect(identities).To(HaveLen(1))
+			Expect(identities[0].Subject).To(Equal("acme-1"))
+		})
+
+		It("should sign in the user already linked to the identity", func() {
+			users[7] = &domain.User{ID: 7, Name: "John", Email: "john@example.com"}
+			identities = append(identities, &domain.UserIdentity{UserID: 7, Provider: "acme", Subject: "acme-7"})
+
+			result, err := signIn(0, jwt.MapClaims{"sub": "acme-7", "email": "other@example.com"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Login.Token).NotTo(BeNil())
+			Expect(users).To(HaveLen(1))
+		})
+
+		It("should link an existing account when the provider verified the email", func() {
+			verifiedAt := time.Now()
+			users[3] = &domain.User{ID: 3, Email: "john@example.com", Password: "hash", EmailVerifiedAt: &verifiedAt}
+
+			result, err := signIn(0, jwt.MapClaims{"sub": "acme-3", "email": "john@example.com", "email_verified": true})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Login.Token).NotTo(BeNil())
+			Expect(identities).To(HaveLen(1))
+			Expect(identities[0].UserID).To(Equal(int64(3)))
+			Expect(users[3].IsVerified()).To(BeTrue())
+		})
+
+		It("should link and verify an existing account without a password", func() {
+			users[3] = &domain.User{ID: 3, Email: "john@example.com"}
+
+			result, err := signIn(0, jwt.MapClaims{"sub": "acme-3", "email": "john@example.com", "email_verified": true})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Login.Token).NotTo(BeNil())
+			Expect(identities).To(HaveLen(1))
+			Expect(users[3].IsVerified()).To(BeTrue())
+		})
+
+		It("should not link an unverified account someone else may have registered with a password", func() {
+			users[3] = &domain.User{ID: 3, Email: "john@example.com", Password: "attacker-hash"}
+
+			result, err := signIn(0, jwt.MapClaims{"sub": "acme-3", "email": "john@example.com", "email_verified": true})
+			Expect(result).To(BeNil())
+			var appErr *errdefs.AppError
+			Expect(errors.As(err, &appErr)).To(BeTrue())
+			Expect(appErr.Status).To(Equal(409))
+			Expect(identities).To(BeEmpty())
+			Expect(users[3].IsVerified()).To(BeFalse())
+		})
+
+		It("should not take over an existing account with an unverified email", func() {
+			users[3] = &domain.User{ID: 3, Email: "john@example.com", Password: "hash"}
+
+			_, err := signIn(0, jwt.MapClaims{"sub": "acme-3", "email": "john@example.com", "email_verified": false})
+			var appErr *errdefs.AppError
+			Expect(errors.As(err, &appErr)).To(BeTrue())
+			Expect(appErr.Status).To(Equal(409))
+			Expect(identities).To(BeEmpty())
+		})
+
+		It("should require the second factor when two-factor authentication is enabled", func() {
+			secret, confirmedAt := "encrypted-secret", time.Now()
+			users[5] = &domain.User{ID: 5, Email: "john@example.com", TwoFactorSecret: &secret, TwoFactorConfirmedAt: &confirmedAt}
+			identities = append(identities, &domain.UserIdentity{UserID: 5, Provider: "acme", Subject: "acme-5"})
+			twoFactorSvcMock.EXPECT().CreateChallenge(gomock.Any(), users[5]).Return("challenge-token", nil)
+
+			result, err := signIn(0, jwt.MapClaims{"sub": "acme-5"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Login.TwoFactorRequired()).To(BeTrue())
+			Expect(result.Login.ChallengeToken).To(Equal("challenge-token"))
+		})
+
+		It("should reject a mismatched state", func() {
+			redirect, err := svc.Redirect(ctx, "acme", 0)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = svc.Callback(ctx, "acme", "code", "forged-state", redirect.FlowState)
+			var appErr *errdefs.AppError
+			Expect(errors.As(err, &appErr)).To(BeTrue())
+			Expect(appErr.Status).To(Equal(400))
+		})
+
+		It("should reject an ID token with a different nonce", func() {
+			_, err := signIn(0, jwt.MapClaims{"sub": "acme-1", "email": "jane@example.com", "nonce": "replayed"})
+			var appErr *errdefs.AppError
+			Expect(errors.As(err, &appErr)).To(BeTrue())
+			Expect(appErr.Status).To(Equal(400))
+			Expect(users).To(BeEmpty())
+		})
+	})
+
+	Context("Linking", func() {
+		It("should link the identity to the signed in user", func() {
+			users[9] = &domain.User{ID: 9, Email: "john@example.com", Password: "hash"}
+
+			result, err := signIn(9, jwt.MapClaims{"sub": "acme-9", "email": "someone@else.com"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Linked).To(BeTrue())
+			Expect(result.Login).To(BeNil())
+			Expect(identities).To(HaveLen(1))
+			Expect(identities[0].UserID).To(Equal(int64(9)))
+		})
+
+		It("should refuse an identity linked to another user", func() {
+			users[9] = &domain.User{ID: 9, Email: "john@example.com", Password: "hash"}
+			identities = append(identities, &domain.UserIdentity{UserID: 10, Provider: "acme", Subject: "acme-10"})
+
+			_, err := signIn(9, jwt.MapClaims{"sub": "acme-10"})
+			var appErr *errdefs.AppError
+			Expect(errors.As(err, &appErr)).To(BeTrue())
+			Expect(appErr.Status).To(Equal(409))
+		})
+
+		It("should not unlink the only sign-in method", func() {
+			users[9] = &domain.User{ID: 9, Email: "john@example.com"}
+			identities = append(identities, &domain.UserIdentity{UserID: 9, Provider: "acme", Subject: "acme-9"})
+			passkeyRepoMock.EXPECT().FindByUserID(gomock.Any(), int64(9)).Return(nil, nil)
+
+			err := svc.Unlink(ctx, 9, "acme")
+			var appErr *errdefs.AppError
+			Expect(errors.As(err, &appErr)).To(BeTrue())
+			Expect(appErr.Status).To(Equal(400))
+		})
+
+		It("should unlink when the user has a password", func() {
+			users[9] = &domain.User{ID: 9, Email: "john@example.com", Password: "hash"}
+			identities = append(identities, &domain.UserIdentity{UserID: 9, Provider: "acme", Subject: "acme-9"})
+			identityRepoMock.EXPECT().Delete(gomock.Any(), int64(9), "acme").Return(nil)
+
+			Expect(svc.Unlink(ctx, 9, "acme")).To(Succeed())
+		})
+	})
+})
@@ -0,0 +1,164 @@
+package oauth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+
+	"github.com/akfaiz/go-vue-starter-kit/internal/config"
+	"github.com/coreos/go-oidc/v3/oidc"
+	"golang.org/x/oauth2"
+)
+
+// claims is the identity asserted by a provider.
+type claims struct {
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+}
+
+// provider wraps a configured OpenID Connect or plain OAuth2 provider. OIDC
+// discovery happens on first use so an unreachable issuer does not prevent
+// the server from starting.
+type provider struct {
+	cfg         config.OAuthProvider
+	redirectURL string
+
+	mu       sync.Mutex
+	oauth2   *oauth2.Config
+	verifier *oidc.IDTokenVerifier
+}
+
+func newProvider(cfg config.OAuthProvider, redirectURL string) *provider {
+	return &provider{cfg: cfg, redirectURL: redirectURL}
+}
+
+func (p *provider) isOIDC() bool {
+	return p.cfg.Issuer != ""
+}
+
+func (p *provider) config(ctx context.Context) (*oauth2.Config, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.oauth2 != nil {
+		return p.oauth2, nil
+	}
+
+	endpoint := oauth2.Endpoint{AuthURL: p.cfg.AuthURL, TokenURL: p.cfg.TokenURL}
+	scopes := p.cfg.Scopes
+	if p.isOIDC() {
+		discovered, err := oidc.NewProvider(ctx, p.cfg.Issuer)
+		if err != nil {
+			return nil, fmt.Errorf("discover oidc provider %q: %w", p.cfg.Name, err)
+		}
+		endpoint = discovered.Endpoint()
+		p.verifier = discovered.Verifier(&oidc.Config{ClientID: p.cfg.ClientID})
+		if len(scopes) == 0 {
+			scopes = []string{oidc.ScopeOpenID, "email", "profile"}
+		}
+	}
+
+	p.oauth2 = &oauth2.Config{
+		ClientID:     p.cfg.ClientID,
+		ClientSecret: p.cfg.ClientSecret,
+		Endpoint:     endpoint,
+		RedirectURL:  p.redirectURL,
+		Scopes:       scopes,
+	}
+	return p.oauth2, nil
+}
+
+// identity reads the user's claims from the ID token, or from the userinfo
+// endpoint for plain OAuth2 providers.
+func (p *provider) identity(ctx context.Context, token *oauth2.Token, nonce string) (*claims, error) {
+	if p.isOIDC() {
+		rawIDToken, ok := token.Extra("id_token").(string)
+		if !ok {
+			return nil, fmt.Errorf("provider %q returned no id_token", p.cfg.Name)
+		}
+		idToken, err := p.verifier.Verify(ctx, rawIDToken)
+		if err != nil {
+			return nil, err
+		}
+		if idToken.Nonce != nonce {
+			return nil, fmt.Errorf("provider %q returned an id_token with a mismatched nonce", p.cfg.Name)
+		}
+		var c struct {
+			Email         string `json:"email"`
+			EmailVerified any    `json:"email_verified"`
+			Name          string `json:"name"`
+		}
+		if err := idToken.Claims(&c); err != nil {
+			return nil, err
+		}
+		return &claims{
+			Subject:       idToken.Subject,
+			Email:         c.Email,
+			EmailVerified: isTrue(c.EmailVerified),
+			Name:          c.Name,
+		}, nil
+	}
+
+	return p.userInfo(ctx, token)
+}
+
+func (p *provider) userInfo(ctx context.Context, token *oauth2.Token) (*claims, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.cfg.UserInfoURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	res, err := p.oauth2.Client(ctx, token).Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("provider %q userinfo returned status %d", p.cfg.Name, res.StatusCode)
+	}
+
+	var info struct {
+		Subject       string      `json:"sub"`
+		ID            json.Number `json:"id"`
+		Email         string      `json:"email"`
+		EmailVerified any         `json:"email_verified"`
+		Name          string      `json:"name"`
+		Login         string      `json:"login"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&info); err != nil {
+		return nil, err
+	}
+	subject := info.Subject
+	if subject == "" {
+		subject = info.ID.String()
+	}
+	if subject == "" {
+		return nil, fmt.Errorf("provider %q userinfo has no subject", p.cfg.Name)
+	}
+	name := info.Name
+	if name == "" {
+		name = info.Login
+	}
+	return &claims{
+		Subject:       subject,
+		Email:         info.Email,
+		EmailVerified: isTrue(info.EmailVerified),
+		Name:          name,
+	}, nil
+}
+
+// isTrue accepts email_verified as a boolean or, as some providers send it, a string.
+func isTrue(v any) bool {
+	switch v := v.(type) {
+	case bool:
+		return v
+	case string:
+		b, _ := strconv.ParseBool(v)
+		return b
+	}
+	return false
+}
@@ -0,0 +1,160 @@
+package passkey_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+
+	"github.com/go-webauthn/webauthn/protocol/webauthncbor"
+	"github.com/go-webauthn/webauthn/protocol/webauthncose"
+)
+
+const (
+	flagUserPresent  = 0x01
+	flagUserVerified = 0x04
+	flagAttested     = 0x40
+)
+
+// softwareAuthenticator is a minimal platform authenticator producing "none"
+// attestations and ES256 assertions, enough to drive both ceremonies in tests.
+type softwareAuthenticator struct {
+	rpID         string
+	origin       string
+	key          *ecdsa.PrivateKey
+	credentialID []byte
+	userHandle   []byte
+	signCount    uint32
+}
+
+func newSoftwareAuthenticator(rpID, origin string) *softwareAuthenticator {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		panic(err)
+	}
+	credentialID := make([]byte, 16)
+	if _, err := rand.Read(credentialID); err != nil {
+		panic(err)
+	}
+	return &softwareAuthenticator{rpID: rpID, origin: origin, key: key, credentialID: credentialID}
+}
+
+// create answers navigator.credentials.create() for the given options.
+func (a *softwareAuthenticator) create(options json.RawMessage) json.RawMessage {
+	var creation struct {
+		PublicKey struct {
+			Challenge string `json:"challenge"`
+			User      struct {
+				ID string `json:"id"`
+			} `json:"user"`
+		} `json:"publicKey"`
+	}
+	must(json.Unmarshal(options, &creation))
+	userHandle, err := base64.RawURLEncoding.DecodeString(creation.PublicKey.User.ID)
+	must(err)
+	a.userHandle = userHandle
+
+	x, y := a.publicKeyCoords()
+	publicKey, err := webauthncbor.Marshal(webauthncose.EC2PublicKeyData{
+		PublicKeyData: webauthncose.PublicKeyData{
+			KeyType:   int64(webauthncose.EllipticKey),
+			Algorithm: int64(webauthncose.AlgES256),
+		},
+		Curve:  int64(webauthncose.P256),
+		XCoord: x,
+		YCoord: y,
+	})
+	must(err)
+
+	authData := a.authenticatorData(flagUserPresent | flagUserVerified | flagAttested)
+	authData = append(authData, make([]byte, 16)...) // zero AAGUID
+	authData = binary.BigEndian.AppendUint16(authData, uint16(len(a.credentialID)))
+	authData = append(authData, a.credentialID...)
+	authData = append(authData, publicKey...)
+
+	attestation, err := webauthncbor.Marshal(map[string]any{
+		"fmt":      "none",
+		"attStmt":  map[string]any{},
+		"authData": authData,
+	})
+	must(err)
+
+	return a.credential(map[string]string{
+		"clientDataJSON":    encode(a.clientData("webauthn.create", creation.PublicKey.Challenge)),
+		"attestationObject": encode(attestation),
+	})
+}
+
+// get answers navigator.credentials.get() for the given options.
+func (a *softwareAuthenticator) get(options json.RawMessage) json.RawMessage {
+	var assertion struct {
+		PublicKey struct {
+			Challenge string `json:"challenge"`
+		} `json:"publicKey"`
+	}
+	must(json.Unmarshal(options, &assertion))
+
+	a.signCount++
+	authData := a.authenticatorData(flagUserPresent | flagUserVerified)
+	clientData := a.clientData("webauthn.get", assertion.PublicKey.Challenge)
+	clientDataHash := sha256.Sum256(clientData)
+	digest := sha256.Sum256(append(append([]byte{}, authData...), clientDataHash[:]...))
+	signature, err := ecdsa.SignASN1(rand.Reader, a.key, digest[:])
+	must(err)
+
+	return a.credential(map[string]string{
+		"clientDataJSON":    encode(clientData),
+		"authenticatorData": encode(authData),
+		"signature":         encode(signature),
+		"userHandle":        encode(a.userHandle),
+	})
+}
+
+func (a *softwareAuthenticator) authenticatorData(flags byte) []byte {
+	rpIDHash := sha256.Sum256([]byte(a.rpID))
+	data := append([]byte{}, rpIDHash[:]...)
+	data = append(data, flags)
+	return binary.BigEndian.AppendUint32(data, a.signCount)
+}
+
+func (a *softwareAuthenticator) clientData(typ, challenge string) []byte {
+	data, err := json.Marshal(map[string]string{
+		"type":      typ,
+		"challenge": challenge,
+		"origin":    a.origin,
+	})
+	must(err)
+	return data
+}
+
+func (a *softwareAuthenticator) credential(response map[string]string) json.RawMessage {
+	data, err := json.Marshal(map[string]any{
+		"id":       encode(a.credentialID),
+		"rawId":    encode(a.credentialID),
+		"type":     "public-key",
+		"response": response,
+	})
+	must(err)
+	return data
+}
+
+func (a *softwareAuthenticator) publicKeyCoords() ([]byte, []byte) {
+	x := make([]byte, 32)
+	y := make([]byte, 32)
+	a.key.X.FillBytes(x)
+	a.key.Y.FillBytes(y)
+	return x, y
+}
+
+func encode(data []byte) string {
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func must(err error) {
+	if err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,371 @@
+package passkey
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"net/url"
+	"slices"
+	"time"
+
+	"github.com/akfaiz/go-vue-starter-kit/internal/config"
+	"github.com/akfaiz/go-vue-starter-kit/internal/domain"
+	"github.com/akfaiz/go-vue-starter-kit/internal/errdefs"
+	"github.com/go-webauthn/webauthn/protocol"
+	"github.com/go-webauthn/webauthn/webauthn"
+	"github.com/google/uuid"
+	"github.com/invopop/ctxi18n/i18n"
+)
+
+const defaultPasskeyName = "Passkey"
+
+type service struct {
+	cfg                 config.WebAuthn
+	webAuthn            *webauthn.WebAuthn
+	passkeyRepo         domain.PasskeyRepository
+	webAuthnSessionRepo domain.WebAuthnSessionRepository
+	userRepo            domain.UserRepository
+	identityRepo        domain.UserIdentityRepository
+	sessionService      domain.SessionService
+	auditLogger         domain.AuditLogger
+}
+
+func NewService(
+	cfg config.Config,
+	passkeyRepo domain.PasskeyRepository,
+	webAuthnSessionRepo domain.WebAuthnSessionRepository,
+	userRepo domain.UserRepository,
+	identityRepo domain.UserIdentityRepository,
+	sessionService domain.SessionService,
+	auditLogger domain.AuditLogger,
+) (domain.PasskeyService, error) {
+	rpID, rpOrigins, err := relyingParty(cfg)
+	if err != nil {
+		return nil, err
+	}
+	webAuthn, err := webauthn.New(&webauthn.Config{
+		RPID:          rpID,
+		RPDisplayName: cfg.App.Name,
+		RPOrigins:     rpOrigins,
+		AuthenticatorSelection: protocol.AuthenticatorSelection{
+			ResidentKey:      protocol.ResidentKeyRequirementRequired,
+			UserVerification: protocol.VerificationRequired,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &service{
+		cfg:                 cfg.Auth.WebAuthn,
+		webAuthn:            webAuthn,
+		passkeyRepo:         passkeyRepo,
+		webAuthnSessionRepo: webAuthnSessionRepo,
+		userRepo:            userRepo,
+		identityRepo:        identityRepo,
+		sessionService:      sessionService,
+		auditLogger:         auditLogger,
+	}, nil
+}
+
+func (s *service) BeginRegistration(ctx context.Context, userID int64) (*domain.PasskeyCeremony, error) {
+	user, err := s.loadUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	creation, sessionData, err := s.webAuthn.BeginRegistration(user,
+		webauthn.WithExclusions(webauthn.Credentials(user.WebAuthnCredentials()).CredentialDescriptors()),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.startCeremony(ctx, &userID, sessionData, creation)
+}
+
+func (s *service) FinishRegistration(ctx context.Context, userID int64, ceremonyID, name string, credential json.RawMessage) (*domain.Passkey, error) {
+	sessionData, err := s.finishCeremony(ctx, ceremonyID, &userID)
+	if err != nil {
+		return nil, err
+	}
+	user, err := s.loadUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	parsed, err := protocol.ParseCredentialCreationResponseBytes(credential)
+	if err != nil {
+		return nil, errdefs.ErrBadRequest(i18n.T(ctx, "passkeys.invalid")).WithCause(err)
+	}
+	created, err := s.webAuthn.CreateCredential(user, *sessionData, parsed)
+	if err != nil {
+		return nil, errdefs.ErrBadRequest(i18n.T(ctx, "passkeys.invalid")).WithCause(err)
+	}
+
+	if name == "" {
+		name = defaultPasskeyName
+	}
+	transports := make([]string, len(created.Transport))
+	for i, transport := range created.Transport {
+		transports[i] = string(transport)
+	}
+	passkey := &domain.Passkey{
+		UserID:          userID,
+		Name:            name,
+		CredentialID:    created.ID,
+		PublicKey:       created.PublicKey,
+		AttestationType: created.AttestationType,
+		Transports:      transports,
+		AAGUID:          created.Authenticator.AAGUID,
+		SignCount:       created.Authenticator.SignCount,
+		BackupEligible:  created.Flags.BackupEligible,
+		BackupState:     created.Flags.BackupState,
+	}
+	if err := s.passkeyRepo.Create(ctx, passkey); err != nil {
+		return nil, err
+	}
+
+	return passkey, nil
+}
+
+func (s *service) List(ctx context.Context, userID int64) ([]*domain.Passkey, error) {
+	return s.passkeyRepo.FindByUserID(ctx, userID)
+}
+
+func (s *service) Delete(ctx context.Context, userID, id int64) error {
+	owner, err := s.loadUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if !slices.ContainsFunc(owner.passkeys, func(p *domain.Passkey) bool { return p.ID == id }) {
+		return domain.ErrResourceNotFound
+	}
+
+	// Never remove the last way the user can sign in.
+	if !owner.user.HasPassword() && len(owner.passkeys) == 1 {
+		identities, err := s.identityRepo.FindByUserID(ctx, userID)
+		if err != nil {
+			return err
+		}
+		if len(identities) == 0 {
+			return errdefs.ErrBadRequest(i18n.T(ctx, "passkeys.last_method"))
+		}
+	}
+
+	return s.passkeyRepo.Delete(ctx, userID, id)
+}
+
+func (s *service) BeginLogin(ctx context.Context) (*domain.PasskeyCeremony, error) {
+	assertion, sessionData, err := s.webAuthn.BeginDiscoverableLogin(
+		webauthn.WithUserVerification(protocol.VerificationRequired),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.startCeremony(ctx, nil, sessionData, assertion)
+}
+
+func (s *service) FinishLogin(ctx context.Context, ceremonyID string, credential json.RawMessage) (*domain.PairToken, error) {
+	sessionData, err := s.finishCeremony(ctx, ceremonyID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	parsed, err := protocol.ParseCredentialRequestResponseBytes(credential)
+	if err != nil {
+		return nil, errdefs.ErrBadRequest(i18n.T(ctx, "passkeys.invalid")).WithCause(err)
+	}
+
+	var (
+		owner     *webauthnUser
+		lookupErr error
+	)
+	handler := func(_, userHandle []byte) (webauthn.User, error) {
+		owner, lookupErr = s.loadUser(ctx, parseUserHandle(userHandle))
+		if lookupErr != nil {
+			return nil, lookupErr
+		}
+		return owner, nil
+	}
+	validated, err := s.webAuthn.ValidateDiscoverableLogin(handler, *sessionData, parsed)
+	if err != nil {
+		if lookupErr != nil && !errors.Is(lookupErr, domain.ErrResourceNotFound) {
+			return nil, lookupErr
+		}
+		return nil, errdefs.ErrUnauthorized(i18n.T(ctx, "passkeys.failed")).WithCause(err)
+	}
+	// A sign count that did not increase suggests the authenticator was cloned.
+	if validated.Authenticator.CloneWarning {
+		return nil, errdefs.ErrUnauthorized(i18n.T(ctx, "passkeys.failed"))
+	}
+
+	passkey := owner.passkey(validated.ID)
+	if err := s.passkeyRepo.UpdateUsage(ctx, passkey.ID, validated.Authenticator.SignCount, validated.Flags.BackupState); err != nil {
+		return nil, err
+	}
+
+	token, err := s.sessionService.Create(ctx, owner.user)
+	if err != nil {
+		return nil, err
+	}
+	s.auditLogger.Log(ctx, domain.AuditLogin, owner.user.ID, map[string]any{"method": "passkey"})
+	return token, nil
+}
+
+func (s *service) loadUser(ctx context.Context, userID int64) (*webauthnUser, error) {
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	passkeys, err := s.passkeyRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return &webauthnUser{user: user, passkeys: passkeys}, nil
+}
+
+// startCeremony stores the WebAuthn session data server side and returns the
+// options for the browser together with the ceremony ID to send back.
+func (s *service) startCeremony(ctx context.Context, userID *int64, sessionData *webauthn.SessionData, options any) (*domain.PasskeyCeremony, error) {
+	data, err := json.Marshal(sessionData)
+	if err != nil {
+		return nil, err
+	}
+	rawOptions, err := json.Marshal(options)
+	if err != nil {
+		return nil, err
+	}
+
+	_ = s.webAuthnSessionRepo.DeleteExpired(ctx)
+	ceremony := &domain.WebAuthnSession{
+		ID:        uuid.NewString(),
+		UserID:    userID,
+		Data:      data,
+		ExpiresAt: time.Now().Add(s.cfg.CeremonyExpiration),
+	}
+	if err := s.webAuthnSessionRepo.Create(ctx, ceremony); err != nil {
+		return nil, err
+	}
+
+	return &domain.PasskeyCeremony{ID: ceremony.ID, Options: rawOptions}, nil
+}
+
+// finishCeremony consumes the stored ceremony, making sure it was started by the same user.
+func (s *service) finishCeremony(ctx context.Context, ceremonyID string, userID *int64) (*webauthn.SessionData, error) {
+	if err := uuid.Validate(ceremonyID); err != nil {
+		return nil, errdefs.ErrBadRequest(i18n.T(ctx, "passkeys.ceremony"))
+	}
+	ceremony, err := s.webAuthnSessionRepo.Consume(ctx, ceremonyID)
+	if err != nil {
+		if errors.Is(err, domain.ErrResourceNotFound) {
+			return nil, errdefs.ErrBadRequest(i18n.T(ctx, "passkeys.ceremony"))
+		}
+		return nil, err
+	}
+	if !sameUser(ceremony.UserID, userID) {
+		return nil, errdefs.ErrBadRequest(i18n.T(ctx, "passkeys.ceremony"))
+	}
+
+	var sessionData webauthn.SessionData
+	if err := json.Unmarshal(ceremony.Data, &sessionData); err != nil {
+		return nil, err
+	}
+	return &sessionData, nil
+}
+
+func sameUser(a, b *int64) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
+
+// relyingParty resolves the relying party ID and allowed origins, falling back
+// to the frontend URL the passkeys will be used from.
+func relyingParty(cfg config.Config) (string, []string, error) {
+	rpID := cfg.Auth.WebAuthn.RPID
+	rpOrigins := cfg.Auth.WebAuthn.RPOrigins
+	if rpID != "" && len(rpOrigins) > 0 {
+		return rpID, rpOrigins, nil
+	}
+
+	frontendURL, err := url.Parse(cfg.App.FrontendBaseURL)
+	if err != nil {
+		return "", nil, err
+	}
+	if rpID == "" {
+		rpID = frontendURL.Hostname()
+	}
+	if len(rpOrigins) == 0 {
+		rpOrigins = []string{frontendURL.Scheme + "://" + frontendURL.Host}
+	}
+	return rpID, rpOrigins, nil
+}
+
+// webauthnUser adapts a user and their passkeys to webauthn.User.
+type webauthnUser struct {
+	user     *domain.User
+	passkeys []*domain.Passkey
+}
+
+func (u *webauthnUser) WebAuthnID() []byte {
+	return userHandle(u.user.ID)
+}
+
+func (u *webauthnUser) WebAuthnName() string {
+	return u.user.Email
+}
+
+func (u *webauthnUser) WebAuthnDisplayName() string {
+	return u.user.Name
+}
+
+func (u *webauthnUser) WebAuthnCredentials() []webauthn.Credential {
+	credentials := make([]webauthn.Credential, len(u.passkeys))
+	for i, passkey := range u.passkeys {
+		transports := make([]protocol.AuthenticatorTransport, len(passkey.Transports))
+		for j, transport := range passkey.Transports {
+			transports[j] = protocol.AuthenticatorTransport(transport)
+		}
+		credentials[i] = webauthn.Credential{
+			ID:              passkey.CredentialID,
+			PublicKey:       passkey.PublicKey,
+			AttestationType: passkey.AttestationType,
+			Transport:       transports,
+			Flags: webauthn.CredentialFlags{
+				BackupEligible: passkey.BackupEligible,
+				BackupState:    passkey.BackupState,
+			},
+			Authenticator: webauthn.Authenticator{
+				AAGUID:    passkey.AAGUID,
+				SignCount: passkey.SignCount,
+			},
+		}
+	}
+	return credentials
+}
+
+func (u *webauthnUser) passkey(credentialID []byte) *domain.Passkey {
+	for _, passkey := range u.passkeys {
+		if bytes.Equal(passkey.CredentialID, credentialID) {
+			return passkey
+		}
+	}
+	return nil
+}
+
+// userHandle encodes the user ID as the opaque WebAuthn user handle.
+func userHandle(userID int64) []byte {
+	return binary.BigEndian.AppendUint64(nil, uint64(userID))
+}
+
+func parseUserHandle(handle []byte) int64 {
+	if len(handle) != 8 {
+		return 0
+	}
+	return int64(binary.BigEndian.Uint64(handle))
+}
@@ -0,0 +1,232 @@
+package passkey_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/akfaiz/go-vue-starter-kit/internal/config"
+	"github.com/akfaiz/go-vue-starter-kit/internal/domain"
+	"github.com/akfaiz/go-vue-starter-kit/internal/errdefs"
+	"github.com/akfaiz/go-vue-starter-kit/internal/mocks"
+	"github.com/akfaiz/go-vue-starter-kit/internal/service/passkey"
+	"github.com/invopop/ctxi18n"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+)
+
+var _ = Describe("Passkey Service", Label("unit", "usecase"), func() {
+	var (
+		passkeyRepoMock  *mocks.MockPasskeyRepository
+		ceremonyRepoMock *mocks.MockWebAuthnSessionRepository
+		userRepoMock     *mocks.MockUserRepository
+		identityRepoMock *mocks.MockUserIdentityRepository
+		sessionSvcMock   *mocks.MockSessionService
+		auditLoggerMock  *mocks.MockAuditLogger
+		svc              domain.PasskeyService
+
+		ctx           context.Context
+		user          *domain.User
+		authenticator *softwareAuthenticator
+		stored        []*domain.Passkey
+		ceremonies    map[string]*domain.WebAuthnSession
+	)
+	BeforeEach(func() {
+		ctrl := gomock.NewController(GinkgoT())
+		passkeyRepoMock = mocks.NewMockPasskeyRepository(ctrl)
+		ceremonyRepoMock = mocks.NewMockWebAuthnSessionRepository(ctrl)
+		userRepoMock = mocks.NewMockUserRepository(ctrl)
+		identityRepoMock = mocks.NewMockUserIdentityRepository(ctrl)
+		sessionSvcMock = mocks.NewMockSessionService(ctrl)
+
+		cfg := config.Config{}
+		cfg.App.Name = "Starter Kit"
+		cfg.App.FrontendBaseURL = "http://localhost:8080"
+		cfg.Auth.WebAuthn.CeremonyExpiration = time.Minute
+		var err error
+		auditLoggerMock = mocks.NewMockAuditLogger(ctrl)
+		auditLoggerMock.EXPECT().Log(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+		svc, err = passkey.NewService(cfg, passkeyRepoMock, ceremonyRepoMock, userRepoMock, identityRepoMock, sessionSvcMock, auditLoggerMock)
+		Expect(err).NotTo(HaveOccurred())
+
+		ctx = context.Background()
+		ctx, _ = ctxi18n.WithLocale(ctx, "en")
+		user = &domain.User{ID: 42, Name: "John Doe", Email: "john.doe@example.com"}
+		authenticator = newSoftwareAuthenticator("localhost", "http://localhost:8080")
+		stored = nil
+		ceremonies = map[string]*domain.WebAuthnSession{}
+
+		// Back the repositories with in-memory state so full ceremonies can run.
+		userRepoMock.EXPECT().FindByID(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, id int64) (*domain.User, error) {
+				if id != user.ID {
+					return nil, domain.ErrResourceNotFound
+				}
+				return user, nil
+			}).AnyTimes()
+		passkeyRepoMock.EXPECT().FindByUserID(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, _ int64) ([]*domain.Passkey, error) {
+				return stored, nil
+			}).AnyTimes()
+		ceremonyRepoMock.EXPECT().DeleteExpired(gomock.Any()).Return(nil).AnyTimes()
+		ceremonyRepoMock.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, s *domain.WebAuthnSession) error {
+				ceremonies[s.ID] = s
+				return nil
+			}).AnyTimes()
+		ceremonyRepoMock.EXPECT().Consume(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, id string) (*domain.WebAuthnSession, error) {
+				s, ok := ceremonies[id]
+				if !ok {
+					return nil, domain.ErrResourceNotFound
+				}
+				delete(ceremonies, id)
+				return s, nil
+			}).AnyTimes()
+
+		DeferCleanup(func() {
+			ctrl.Finish()
+		})
+	})
+
+	register := func() *domain.Passkey {
+		ceremony, err := svc.BeginRegistration(ctx, user.ID)
+		Expect(err).NotTo(HaveOccurred())
+
+		passkeyRepoMock.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, p *domain.Passkey) error {
+				p.ID = int64(len(stored) + 1)
+				stored = append(stored, p)
+				return nil
+			})
+		created, err := svc.FinishRegistration(ctx, user.ID, ceremony.ID, "", authenticator.create(ceremony.Options))
+		Expect(err).NotTo(HaveOccurred())
+		return created
+	}
+
+	expectAppError := func(err error, status int) {
+		var appErr *errdefs.AppError
+		Expect(errors.As(err, &appErr)).To(BeTrue(), "expected AppError, got %v", err)
+		Expect(appErr.Status).To(Equal(status))
+	}
+
+	Describe("Registration", func() {
+		It("should store the credential created by the authenticator", func() {
+			created := register()
+			Expect(created.Name).To(Equal("Passkey"))
+			Expect(created.UserID).To(Equal(user.ID))
+			Expect(created.CredentialID).To(Equal(authenticator.credentialID))
+			Expect(created.AttestationType).To(Equal("none"))
+			Expect(ceremonies).To(BeEmpty())
+		})
+		It("should exclude credentials the user already registered", func() {
+			register()
+			ceremony, err := svc.BeginRegistration(ctx, user.ID)
+			Expect(err).NotTo(HaveOccurred())
+
+			var options struct {
+				PublicKey struct {
+					ExcludeCredentials []json.RawMessage `json:"excludeCredentials"`
+				} `json:"publicKey"`
+			}
+			Expect(json.Unmarshal(ceremony.Options, &options)).To(Succeed())
+			Expect(options.PublicKey.ExcludeCredentials).To(HaveLen(1))
+		})
+		It("should reject a ceremony started by another user", func() {
+			ceremony, err := svc.BeginRegistration(ctx, user.ID)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = svc.FinishRegistration(ctx, 7, ceremony.ID, "", authenticator.create(ceremony.Options))
+			expectAppError(err, 400)
+		})
+		It("should reject a response for a different origin", func() {
+			ceremony, err := svc.BeginRegistration(ctx, user.ID)
+			Expect(err).NotTo(HaveOccurred())
+			authenticator.origin = "https://evil.example.com"
+
+			_, err = svc.FinishRegistration(ctx, user.ID, ceremony.ID, "", authenticator.create(ceremony.Options))
+			expectAppError(err, 400)
+		})
+	})
+
+	Describe("Login", func() {
+		It("should create a session after a valid assertion", func() {
+			created := register()
+			ceremony, err := svc.BeginLogin(ctx)
+			Expect(err).NotTo(HaveOccurred())
+
+			token := &domain.PairToken{AccessToken: "access", RefreshToken: "refresh"}
+			passkeyRepoMock.EXPECT().UpdateUsage(gomock.Any(), created.ID, uint32(1), false).Return(nil)
+			sessionSvcMock.EXPECT().Create(gomock.Any(), user).Return(token, nil)
+
+			got, err := svc.FinishLogin(ctx, ceremony.ID, authenticator.get(ceremony.Options))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(got).To(Equal(token))
+		})
+		It("should not allow a ceremony to be finished twice", func() {
+			register()
+			ceremony, err := svc.BeginLogin(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			assertion := authenticator.get(ceremony.Options)
+
+			passkeyRepoMock.EXPECT().UpdateUsage(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			sessionSvcMock.EXPECT().Create(gomock.Any(), user).Return(&domain.PairToken{}, nil)
+			_, err = svc.FinishLogin(ctx, ceremony.ID, assertion)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = svc.FinishLogin(ctx, ceremony.ID, assertion)
+			expectAppError(err, 400)
+		})
+		It("should reject an assertion signed by an unknown key", func() {
+			register()
+			ceremony, err := svc.BeginLogin(ctx)
+			Expect(err).NotTo(HaveOccurred())
+
+			impostor := newSoftwareAuthenticator("localhost", "http://localhost:8080")
+			impostor.credentialID = authenticator.credentialID
+			impostor.userHandle = authenticator.userHandle
+
+			_, err = svc.FinishLogin(ctx, ceremony.ID, impostor.get(ceremony.Options))
+			expectAppError(err, 401)
+		})
+		It("should reject a sign count that went backwards", func() {
+			created := register()
+			created.SignCount = 10
+			ceremony, err := svc.BeginLogin(ctx)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = svc.FinishLogin(ctx, ceremony.ID, authenticator.get(ceremony.Options))
+			expectAppError(err, 401)
+		})
+	})
+
+	Describe("Delete", func() {
+		BeforeEach(func() {
+			stored = []*domain.Passkey{{ID: 1, UserID: user.ID}}
+		})
+		It("should delete the user's passkey", func() {
+			user.Password = "hashed"
+			passkeyRepoMock.EXPECT().Delete(gomock.Any(), user.ID, int64(1)).Return(nil)
+			Expect(svc.Delete(ctx, user.ID, 1)).To(Succeed())
+		})
+		It("should delete the last passkey when a provider is linked", func() {
+			identityRepoMock.EXPECT().FindByUserID(gomock.Any(), user.ID).
+				Return([]*domain.UserIdentity{{UserID: user.ID, Provider: "google"}}, nil)
+			passkeyRepoMock.EXPECT().Delete(gomock.Any(), user.ID, int64(1)).Return(nil)
+			Expect(svc.Delete(ctx, user.ID, 1)).To(Succeed())
+		})
+		It("should refuse to delete the only sign-in method", func() {
+			identityRepoMock.EXPECT().FindByUserID(gomock.Any(), user.ID).Return(nil, nil)
+
+			err := svc.Delete(ctx, user.ID, 1)
+			var appErr *errdefs.AppError
+			Expect(errors.As(err, &appErr)).To(BeTrue())
+			Expect(appErr.Status).To(Equal(400))
+		})
+		It("should not find another user's passkey", func() {
+			Expect(svc.Delete(ctx, user.ID, 2)).To(MatchError(domain.ErrResourceNotFound))
+		})
+	})
+})
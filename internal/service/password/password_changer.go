@@ -0,0 +1,55 @@
+package password
+
+import (
+	"context"
+	"time"
+
+	"github.com/aarondl/opt/omit"
+	"github.com/akfaiz/go-vue-starter-kit/internal/domain"
+)
+
+type changer struct {
+	userRepo        domain.UserRepository
+	passwordHasher  domain.PasswordHasher
+	passwordPolicy  domain.PasswordPolicy
+	passwordHistory domain.PasswordHistory
+}
+
+func NewChanger(
+	userRepo domain.UserRepository,
+	passwordHasher domain.PasswordHasher,
+	passwordPolicy domain.PasswordPolicy,
+	passwordHistory domain.PasswordHistory,
+) domain.PasswordChanger {
+	return &changer{
+		userRepo:        userRepo,
+		passwordHasher:  passwordHasher,
+		passwordPolicy:  passwordPolicy,
+		passwordHistory: passwordHistory,
+	}
+}
+
+func (c *changer) Change(ctx context.Context, field string, user *domain.User, newPassword string) error {
+	if err := c.passwordPolicy.Validate(ctx, field, newPassword, user); err != nil {
+		return err
+	}
+	if err := c.passwordHistory.Check(ctx, field, newPassword, user); err != nil {
+		return err
+	}
+
+	hashedPassword, err := c.passwordHasher.Hash(newPassword)
+	if err != nil {
+		return err
+	}
+	if err := c.userRepo.Update(ctx, user.ID, &domain.UserUpdate{
+		Password: omit.From(hashedPassword),
+	}); err != nil {
+		return err
+	}
+	if err := c.passwordHistory.Record(ctx, user.ID, user.Password); err != nil {
+		return err
+	}
+	user.Password = hashedPassword
+	user.PasswordChangedAt = time.Now()
+	return nil
+}
@@ -0,0 +1,61 @@
+package password_test
+
+import (
+	"context"
+	"errors"
+
+	"github.com/aarondl/opt/omit"
+	"github.com/akfaiz/go-vue-starter-kit/internal/domain"
+	"github.com/akfaiz/go-vue-starter-kit/internal/mocks"
+	"github.com/akfaiz/go-vue-starter-kit/internal/service/password"
+	"github.com/akfaiz/go-vue-starter-kit/internal/validator"
+	"github.com/invopop/ctxi18n"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+)
+
+var _ = Describe("Password Changer", Label("unit", "usecase"), func() {
+	var (
+		userRepoMock *mocks.MockUserRepository
+		hasherMock   *mocks.MockPasswordHasher
+		policyMock   *mocks.MockPasswordPolicy
+		historyMock  *mocks.MockPasswordHistory
+		changer      domain.PasswordChanger
+		user         *domain.User
+		ctx          context.Context
+	)
+	BeforeEach(func() {
+		ctrl := gomock.NewController(GinkgoT())
+		userRepoMock = mocks.NewMockUserRepository(ctrl)
+		hasherMock = mocks.NewMockPasswordHasher(ctrl)
+		policyMock = mocks.NewMockPasswordPolicy(ctrl)
+		historyMock = mocks.NewMockPasswordHistory(ctrl)
+		changer = password.NewChanger(userRepoMock, hasherMock, policyMock, historyMock)
+		user = &domain.User{ID: 1, Password: "current"}
+		ctx, _ = ctxi18n.WithLocale(context.Background(), "en")
+	})
+
+	It("should store the new hash and remember the replaced one", func() {
+		policyMock.EXPECT().Validate(ctx, "password", "secret", user).Return(nil)
+		historyMock.EXPECT().Check(ctx, "password", "secret", user).Return(nil)
+		hasherMock.EXPECT().Hash("secret").Return("new", nil)
+		userRepoMock.EXPECT().Update(ctx, int64(1), &domain.UserUpdate{Password: omit.From("new")}).Return(nil)
+		historyMock.EXPECT().Record(ctx, int64(1), "current").Return(nil)
+
+		Expect(changer.Change(ctx, "password", user, "secret")).To(Succeed())
+		Expect(user.Password).To(Equal("new"))
+		Expect(user.PasswordChangedAt).NotTo(BeZero())
+	})
+
+	It("should leave the password alone when the policy rejects it", func() {
+		policyErr := validator.NewError("new_password", "This password is too common. Please choose a different one.")
+		policyMock.EXPECT().Validate(ctx, "new_password", "secret", user).Return(policyErr)
+
+		err := changer.Change(ctx, "new_password", user, "secret")
+		var vErr *validator.ValidationError
+		Expect(errors.As(err, &vErr)).To(BeTrue())
+		Expect(vErr.First().Field).To(Equal("new_password"))
+		Expect(user.Password).To(Equal("current"))
+	})
+})
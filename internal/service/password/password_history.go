@@ -0,0 +1,67 @@
+package password
+
+import (
+	"context"
+
+	"github.com/akfaiz/go-vue-starter-kit/internal/config"
+	"github.com/akfaiz/go-vue-starter-kit/internal/domain"
+	"github.com/akfaiz/go-vue-starter-kit/internal/validator"
+	"github.com/invopop/ctxi18n/i18n"
+)
+
+type history struct {
+	size           int
+	historyRepo    domain.PasswordHistoryRepository
+	passwordHasher domain.PasswordHasher
+}
+
+func NewHistory(
+	cfg config.Config,
+	historyRepo domain.PasswordHistoryRepository,
+	passwordHasher domain.PasswordHasher,
+) domain.PasswordHistory {
+	return &history{
+		size:           cfg.Auth.PasswordPolicy.HistorySize,
+		historyRepo:    historyRepo,
+		passwordHasher: passwordHasher,
+	}
+}
+
+func (h *history) Check(ctx context.Context, field, password string, user *domain.User) error {
+	if h.size <= 0 {
+		return nil
+	}
+	var hashes []string
+	if user.HasPassword() {
+		hashes = append(hashes, user.Password)
+	}
+	if keep := h.size - 1; keep > 0 {
+		previous, err := h.historyRepo.ListRecent(ctx, user.ID, keep)
+		if err != nil {
+			return err
+		}
+		hashes = append(hashes, previous...)
+	}
+	for _, hash := range hashes {
+		match, err := h.passwordHasher.Verify(password, hash)
+		if err != nil {
+			return err
+		}
+		if match {
+			return validator.NewError(field, i18n.T(ctx, "passwords.reused"))
+		}
+	}
+	return nil
+}
+
+// Record keeps HistorySize-1 hashes, since the current password is checked from
+// the user itself.
+func (h *history) Record(ctx context.Context, userID int64, replacedHash string) error {
+	keep := max(h.size-1, 0)
+	if keep > 0 && replacedHash != "" {
+		if err := h.historyRepo.Create(ctx, userID, replacedHash); err != nil {
+			return err
+		}
+	}
+	return h.historyRepo.Prune(ctx, userID, keep)
+}
@@ -0,0 +1,86 @@
+package password_test
+
+import (
+	"context"
+	"errors"
+
+	"github.com/akfaiz/go-vue-starter-kit/internal/config"
+	"github.com/akfaiz/go-vue-starter-kit/internal/domain"
+	"github.com/akfaiz/go-vue-starter-kit/internal/mocks"
+	"github.com/akfaiz/go-vue-starter-kit/internal/service/password"
+	"github.com/akfaiz/go-vue-starter-kit/internal/validator"
+	"github.com/invopop/ctxi18n"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+)
+
+var _ = Describe("Password History", Label("unit", "usecase"), func() {
+	var (
+		historyRepoMock *mocks.MockPasswordHistoryRepository
+		hasherMock      *mocks.MockPasswordHasher
+		cfg             config.Config
+		user            *domain.User
+		ctx             context.Context
+	)
+	BeforeEach(func() {
+		ctrl := gomock.NewController(GinkgoT())
+		historyRepoMock = mocks.NewMockPasswordHistoryRepository(ctrl)
+		hasherMock = mocks.NewMockPasswordHasher(ctrl)
+		cfg = config.Config{Auth: config.Auth{PasswordPolicy: config.PasswordPolicy{HistorySize: 3}}}
+		user = &domain.User{ID: 1, Password: "current"}
+		ctx, _ = ctxi18n.WithLocale(context.Background(), "en")
+	})
+	newHistory := func() domain.PasswordHistory {
+		return password.NewHistory(cfg, historyRepoMock, hasherMock)
+	}
+
+	Describe("Check", func() {
+		It("should reject the current password", func() {
+			historyRepoMock.EXPECT().ListRecent(ctx, int64(1), 2).Return([]string{"older"}, nil)
+			hasherMock.EXPECT().Verify("secret", "current").Return(true, nil)
+
+			err := newHistory().Check(ctx, "password", "secret", user)
+			var vErr *validator.ValidationError
+			Expect(errors.As(err, &vErr)).To(BeTrue())
+			Expect(vErr.First().Field).To(Equal("password"))
+			Expect(vErr.First().Message).To(Equal("You have used this password recently. Please choose a different one."))
+		})
+
+		It("should reject a remembered password", func() {
+			historyRepoMock.EXPECT().ListRecent(ctx, int64(1), 2).Return([]string{"previous", "older"}, nil)
+			hasherMock.EXPECT().Verify("secret", "current").Return(false, nil)
+			hasherMock.EXPECT().Verify("secret", "previous").Return(false, nil)
+			hasherMock.EXPECT().Verify("secret", "older").Return(true, nil)
+
+			Expect(newHistory().Check(ctx, "password", "secret", user)).To(HaveOccurred())
+		})
+
+		It("should accept a new password", func() {
+			historyRepoMock.EXPECT().ListRecent(ctx, int64(1), 2).Return(nil, nil)
+			hasherMock.EXPECT().Verify("secret", "current").Return(false, nil)
+
+			Expect(newHistory().Check(ctx, "password", "secret", user)).To(Succeed())
+		})
+
+		It("should allow any password when the history is off", func() {
+			cfg.Auth.PasswordPolicy.HistorySize = 0
+			Expect(newHistory().Check(ctx, "password", "current", user)).To(Succeed())
+		})
+	})
+
+	Describe("Record", func() {
+		It("should remember the replaced hash and forget the oldest", func() {
+			historyRepoMock.EXPECT().Create(ctx, int64(1), "current").Return(nil)
+			historyRepoMock.EXPECT().Prune(ctx, int64(1), 2).Return(nil)
+
+			Expect(newHistory().Record(ctx, 1, "current")).To(Succeed())
+		})
+
+		It("should not remember users without a previous password", func() {
+			historyRepoMock.EXPECT().Prune(ctx, int64(1), 2).Return(nil)
+
+			Expect(newHistory().Record(ctx, 1, "")).To(Succeed())
+		})
+	})
+})
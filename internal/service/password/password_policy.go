@@ -0,0 +1,139 @@
+package password
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha1" //nolint:gosec // SHA-1 is what the range API indexes by
+	_ "embed"
+	"encoding/hex"
+	"log/slog"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/akfaiz/go-vue-starter-kit/internal/config"
+	"github.com/akfaiz/go-vue-starter-kit/internal/domain"
+	"github.com/akfaiz/go-vue-starter-kit/internal/validator"
+	"github.com/invopop/ctxi18n/i18n"
+)
+
+//go:embed common_passwords.txt
+var commonPasswordsFile string
+
+// commonPasswords holds the bundled list, lower-cased.
+var commonPasswords = func() map[string]struct{} {
+	set := make(map[string]struct{})
+	scanner := bufio.NewScanner(strings.NewReader(commonPasswordsFile))
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); line != "" {
+			set[strings.ToLower(line)] = struct{}{}
+		}
+	}
+	return set
+}()
+
+type policy struct {
+	cfg         config.PasswordPolicy
+	rangeClient domain.PasswordRangeClient
+}
+
+// NewPolicy returns the password policy configured in cfg. rangeClient is nil
+// when the breach check is off.
+func NewPolicy(cfg config.Config, rangeClient domain.PasswordRangeClient) domain.PasswordPolicy {
+	return &policy{
+		cfg:         cfg.Auth.PasswordPolicy,
+		rangeClient: rangeClient,
+	}
+}
+
+func (p *policy) Validate(ctx context.Context, field, password string, user *domain.User) error {
+	errs := validator.ValidationError{}
+	length := utf8.RuneCountInString(password)
+	if length < p.cfg.MinLength {
+		errs.Add(field, i18n.T(ctx, "password_policy.min", i18n.M{"min": p.cfg.MinLength}))
+	}
+	if p.cfg.MaxLength > 0 && length > p.cfg.MaxLength {
+		errs.Add(field, i18n.T(ctx, "password_policy.max", i18n.M{"max": p.cfg.MaxLength}))
+	}
+
+	var upper, lower, digit, symbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsDigit(r):
+			digit = true
+		case !unicode.IsLetter(r):
+			symbol = true
+		}
+	}
+	if p.cfg.RequireUppercase && !upper {
+		errs.Add(field, i18n.T(ctx, "password_policy.uppercase"))
+	}
+	if p.cfg.RequireLowercase && !lower {
+		errs.Add(field, i18n.T(ctx, "password_policy.lowercase"))
+	}
+	if p.cfg.RequireDigit && !digit {
+		errs.Add(field, i18n.T(ctx, "password_policy.digit"))
+	}
+	if p.cfg.RequireSymbol && !symbol {
+		errs.Add(field, i18n.T(ctx, "password_policy.symbol"))
+	}
+
+	if isPersonal(password, user) {
+		errs.Add(field, i18n.T(ctx, "password_policy.personal"))
+	}
+	if _, ok := commonPasswords[strings.ToLower(password)]; ok {
+		errs.Add(field, i18n.T(ctx, "password_policy.common"))
+	} else if p.isBreached(ctx, password) {
+		errs.Add(field, i18n.T(ctx, "password_policy.breached"))
+	}
+
+	if len(errs) > 0 {
+		return &errs
+	}
+	return nil
+}
+
+// isPersonal reports whether password is the user's email, the part of it before
+// the @ or their name.
+func isPersonal(password string, user *domain.User) bool {
+	if user == nil {
+		return false
+	}
+	candidates := []string{user.Email, strings.TrimSpace(user.Name)}
+	if local, _, ok := strings.Cut(user.Email, "@"); ok {
+		candidates = append(candidates, local)
+	}
+	for _, candidate := range candidates {
+		if candidate != "" && strings.EqualFold(password, candidate) {
+			return true
+		}
+	}
+	return false
+}
+
+// isBreached looks the password up through the range client. The check fails
+// open: when the range API cannot be reached the password is accepted.
+func (p *policy) isBreached(ctx context.Context, password string) bool {
+	if p.rangeClient == nil || password == "" {
+		return false
+	}
+	prefix, suffix := hashParts(password)
+	suffixes, err := p.rangeClient.Range(ctx, prefix)
+	if err != nil {
+		slog.WarnContext(ctx, "failed to check password against breaches", slog.Any("error", err))
+		return false
+	}
+	return suffixes[suffix] > 0
+}
+
+// hashParts splits the upper-case hex SHA-1 of password into the five character
+// prefix sent to the range API and the suffix it is compared against.
+func hashParts(password string) (string, string) {
+	sum := sha1.Sum([]byte(password))
+	hash := strings.ToUpper(hex.EncodeToString(sum[:]))
+	return hash[:5], hash[5:]
+}
@@ -0,0 +1,119 @@
+package password_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/akfaiz/go-vue-starter-kit/internal/config"
+	"github.com/akfaiz/go-vue-starter-kit/internal/domain"
+	"github.com/akfaiz/go-vue-starter-kit/internal/mocks"
+	"github.com/akfaiz/go-vue-starter-kit/internal/service/password"
+	"github.com/akfaiz/go-vue-starter-kit/internal/validator"
+	"github.com/invopop/ctxi18n"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+)
+
+var _ = Describe("Password Policy", Label("unit", "usecase"), func() {
+	var (
+		cfg         config.Config
+		rangeClient domain.PasswordRangeClient
+		user        *domain.User
+		ctx         context.Context
+	)
+	BeforeEach(func() {
+		cfg = config.Config{Auth: config.Auth{PasswordPolicy: config.PasswordPolicy{MinLength: 8, MaxLength: 64}}}
+		rangeClient = nil
+		user = &domain.User{Name: "John Doe", Email: "john.doe@example.com"}
+		ctx, _ = ctxi18n.WithLocale(context.Background(), "en")
+	})
+
+	messages := func(value string) []string {
+		err := password.NewPolicy(cfg, rangeClient).Validate(ctx, "password", value, user)
+		if err == nil {
+			return nil
+		}
+		var vErr *validator.ValidationError
+		Expect(errors.As(err, &vErr)).To(BeTrue())
+		Expect(vErr.Fields()).To(HaveEach("password"))
+		return vErr.Messages()
+	}
+
+	It("should accept a long passphrase", func() {
+		Expect(messages("correct horse battery staple")).To(BeEmpty())
+	})
+
+	It("should enforce the length limits", func() {
+		Expect(messages("a1b2c3")).To(ConsistOf("The password must be at least 8 characters."))
+		cfg.Auth.PasswordPolicy.MaxLength = 10
+		Expect(messages("correct horse battery staple")).To(ConsistOf("The password may not be greater than 10 characters."))
+	})
+
+	It("should report every missing character class", func() {
+		cfg.Auth.PasswordPolicy.RequireUppercase = true
+		cfg.Auth.PasswordPolicy.RequireDigit = true
+		cfg.Auth.PasswordPolicy.RequireSymbol = true
+		Expect(messages("correcthorse")).To(ConsistOf(
+			"The password must contain at least one uppercase letter.",
+			"The password must contain at least one number.",
+			"The password must contain at least one symbol.",
+		))
+		Expect(messages("Correct-horse-9")).To(BeEmpty())
+	})
+
+	It("should reject the user's email or name", func() {
+		for _, candidate := range []string{"John.Doe@example.com", "john.doe", "john doe"} {
+			Expect(messages(candidate)).To(ContainElement("The password must not be your name or email address."))
+		}
+	})
+
+	It("should reject common passwords regardless of case", func() {
+		Expect(messages("Password123")).To(ConsistOf("This password is too common. Please choose a different one."))
+	})
+
+	Describe("breach check", func() {
+		It("should reject passwords the range client knows", func() {
+			ctrl := gomock.NewController(GinkgoT())
+			rangeMock := mocks.NewMockPasswordRangeClient(ctrl)
+			// Only the first five characters of the SHA-1 hash leave the process
+			rangeMock.EXPECT().Range(gomock.Any(), "ABF7A").Return(map[string]int{"AD6438836DBE526AA231ABDE2D0EEF74D42": 3}, nil)
+			rangeClient = rangeMock
+			Expect(messages("correct horse battery staple")).To(ConsistOf("This password has appeared in a data breach. Please choose a different one."))
+		})
+
+		It("should fail open when the range API is unavailable", func() {
+			ctrl := gomock.NewController(GinkgoT())
+			rangeMock := mocks.NewMockPasswordRangeClient(ctrl)
+			rangeMock.EXPECT().Range(gomock.Any(), gomock.Any()).Return(nil, errors.New("timeout"))
+			rangeClient = rangeMock
+			Expect(messages("correct horse battery staple")).To(BeEmpty())
+		})
+
+		It("should answer range queries for the bundled list locally", func() {
+			suffixes, err := password.NewLocalRangeClient().Range(ctx, "5CEC1")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(suffixes).To(HaveKeyWithValue("75B165E3D5E62C9E13CE848EF6FEAC81BFF", 1))
+		})
+
+		It("should query a range API and skip padding", func() {
+			var gotPath, gotPadding string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				gotPath = r.URL.Path
+				gotPadding = r.Header.Get("Add-Padding")
+				_, _ = w.Write([]byte("0018A45C4D1DEF81644B54AB7F969B88D65:1\r\n00D4F6E8FA6EECAD2A3AA415EEC418D38EC:0\r\n"))
+			}))
+			DeferCleanup(server.Close)
+
+			client := password.NewAPIRangeClient(server.URL+"/range/", time.Second)
+			suffixes, err := client.Range(ctx, "21BD1")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(gotPath).To(Equal("/range/21BD1"))
+			Expect(gotPadding).To(Equal("true"))
+			Expect(suffixes).To(Equal(map[string]int{"0018A45C4D1DEF81644B54AB7F969B88D65": 1}))
+		})
+	})
+})
@@ -0,0 +1,18 @@
+package password_test
+
+import (
+	"testing"
+
+	"github.com/akfaiz/go-vue-starter-kit/internal/lang"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestPassword(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Password Policy Suite")
+}
+
+var _ = BeforeSuite(func() {
+	lang.Init()
+})
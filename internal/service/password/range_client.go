@@ -0,0 +1,98 @@
+package password
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/akfaiz/go-vue-starter-kit/internal/config"
+	"github.com/akfaiz/go-vue-starter-kit/internal/domain"
+)
+
+// NewRangeClient returns the breach range client selected by
+// PASSWORD_BREACH_CHECK, or nil when the check is off.
+func NewRangeClient(cfg config.Config) (domain.PasswordRangeClient, error) {
+	policy := cfg.Auth.PasswordPolicy
+	switch policy.BreachCheck {
+	case "", "off":
+		return nil, nil
+	case "api":
+		return NewAPIRangeClient(policy.BreachAPIURL, policy.BreachTimeout), nil
+	case "local":
+		return NewLocalRangeClient(), nil
+	default:
+		return nil, fmt.Errorf("unknown password breach check %q", policy.BreachCheck)
+	}
+}
+
+type apiRangeClient struct {
+	baseURL string
+	client  *http.Client
+}
+
+// NewAPIRangeClient queries a range API compatible with Have I Been Pwned,
+// which answers GET <baseURL><prefix> with lines of "SUFFIX:COUNT".
+func NewAPIRangeClient(baseURL string, timeout time.Duration) domain.PasswordRangeClient {
+	return &apiRangeClient{
+		baseURL: baseURL,
+		client:  &http.Client{Timeout: timeout},
+	}
+}
+
+func (c *apiRangeClient) Range(ctx context.Context, prefix string) (map[string]int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+prefix, nil)
+	if err != nil {
+		return nil, err
+	}
+	// Padding hides the real number of suffixes from anyone watching the response size.
+	req.Header.Set("Add-Padding", "true")
+	res, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = res.Body.Close() }()
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("password range API returned %s", res.Status)
+	}
+
+	suffixes := make(map[string]int)
+	scanner := bufio.NewScanner(res.Body)
+	for scanner.Scan() {
+		suffix, count, ok := strings.Cut(strings.TrimSpace(scanner.Text()), ":")
+		if !ok {
+			continue
+		}
+		n, err := strconv.Atoi(count)
+		if err != nil || n == 0 { // padding entries have a count of zero
+			continue
+		}
+		suffixes[strings.ToUpper(suffix)] = n
+	}
+	return suffixes, scanner.Err()
+}
+
+type localRangeClient struct {
+	ranges map[string]map[string]int
+}
+
+// NewLocalRangeClient answers range queries from the bundled list of common
+// passwords, standing in for the range API where it cannot be reached.
+func NewLocalRangeClient() domain.PasswordRangeClient {
+	ranges := make(map[string]map[string]int)
+	for password := range commonPasswords {
+		prefix, suffix := hashParts(password)
+		if ranges[prefix] == nil {
+			ranges[prefix] = make(map[string]int)
+		}
+		ranges[prefix][suffix] = 1
+	}
+	return &localRangeClient{ranges: ranges}
+}
+
+func (c *localRangeClient) Range(_ context.Context, prefix string) (map[string]int, error) {
+	return c.ranges[strings.ToUpper(prefix)], nil
+}
@@ -0,0 +1,146 @@
+package personalaccesstoken
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/akfaiz/go-vue-starter-kit/internal/domain"
+	"github.com/akfaiz/go-vue-starter-kit/internal/errdefs"
+	"github.com/akfaiz/go-vue-starter-kit/internal/validator"
+	"github.com/invopop/ctxi18n/i18n"
+)
+
+const (
+	// prefixLength covers "pat_" and 12 hex characters; the rest is the secret.
+	prefixLength = len(domain.PersonalAccessTokenPrefix) + 12
+	// touchInterval limits last_used_at writes for tokens used in bursts.
+	touchInterval = time.Minute
+)
+
+type service struct {
+	tokenRepo domain.PersonalAccessTokenRepository
+	userRepo  domain.UserRepository
+}
+
+func NewService(
+	tokenRepo domain.PersonalAccessTokenRepository,
+	userRepo domain.UserRepository,
+) domain.PersonalAccessTokenService {
+	return &service{
+		tokenRepo: tokenRepo,
+		userRepo:  userRepo,
+	}
+}
+
+func (s *service) Create(ctx context.Context, userID int64, name string, scopes []string, expiresAt *time.Time) (*domain.PersonalAccessToken, string, error) {
+	if len(scopes) == 0 {
+		return nil, "", validator.NewError("scopes", i18n.T(ctx, "tokens.scopes_required"))
+	}
+	for _, scope := range scopes {
+		if !slices.Contains(domain.Scopes, scope) {
+			return nil, "", validator.NewError("scopes", i18n.T(ctx, "tokens.scope_invalid", i18n.M{"scope": scope}))
+		}
+	}
+	if expiresAt != nil && !expiresAt.After(time.Now()) {
+		return nil, "", validator.NewError("expires_at", i18n.T(ctx, "tokens.expires_at"))
+	}
+
+	plainText, err := generateToken()
+	if err != nil {
+		return nil, "", err
+	}
+	token := &domain.PersonalAccessToken{
+		UserID:    userID,
+		Name:      name,
+		Prefix:    plainText[:prefixLength],
+		TokenHash: hashToken(plainText),
+		Scopes:    slices.Compact(slices.Sorted(slices.Values(scopes))),
+		ExpiresAt: expiresAt,
+	}
+	if err := s.tokenRepo.Create(ctx, token); err != nil {
+		return nil, "", err
+	}
+	return token, plainText, nil
+}
+
+func (s *service) List(ctx context.Context, userID int64) ([]*domain.PersonalAccessToken, error) {
+	return s.tokenRepo.FindByUserID(ctx, userID)
+}
+
+func (s *service) Delete(ctx context.Context, userID, id int64) error {
+	return s.tokenRepo.Delete(ctx, userID, id)
+}
+
+func (s *service) Authenticate(ctx context.Context, plainText string) (*domain.JWTClaims, error) {
+	if len(plainText) <= prefixLength || !strings.HasPrefix(plainText, domain.PersonalAccessTokenPrefix) {
+		return nil, errdefs.ErrTokenInvalid()
+	}
+	token, err := s.tokenRepo.FindByPrefix(ctx, plainText[:prefixLength])
+	if err != nil {
+		if errors.Is(err, domain.ErrResourceNotFound) {
+			return nil, errdefs.ErrTokenInvalid()
+		}
+		return nil, err
+	}
+	if subtle.ConstantTimeCompare([]byte(hashToken(plainText)), []byte(token.TokenHash)) != 1 {
+		return nil, errdefs.ErrTokenInvalid()
+	}
+	if token.IsExpired() {
+		return nil, errdefs.ErrTokenExpired()
+	}
+
+	user, err := s.userRepo.FindByID(ctx, token.UserID)
+	if err != nil {
+		if errors.Is(err, domain.ErrResourceNotFound) {
+			return nil, errdefs.ErrTokenInvalid()
+		}
+		return nil, err
+	}
+	if user.IsSuspended() {
+		return nil, errdefs.ErrAccountSuspended(i18n.T(ctx, "auth.suspended"))
+	}
+
+	if token.LastUsedAt == nil || time.Since(*token.LastUsedAt) > touchInterval {
+		if err := s.tokenRepo.TouchLastUsed(ctx, token.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	claims := &domain.JWTClaims{
+		Type:   domain.JWTTypePersonalAccess,
+		ID:     user.ID,
+		Name:   user.Name,
+		Email:  user.Email,
+		Scopes: token.Scopes,
+	}
+	claims.Subject = strconv.FormatInt(user.ID, 10)
+	return claims, nil
+}
+
+// generateToken returns "pat_" followed by 12 hex characters that identify the
+// token and a 256-bit secret.
+func generateToken() (string, error) {
+	id := make([]byte, 6)
+	if _, err := rand.Read(id); err != nil {
+		return "", err
+	}
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	return domain.PersonalAccessTokenPrefix + hex.EncodeToString(id) + "_" + base64.RawURLEncoding.EncodeToString(secret), nil
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,146 @@
+package personalaccesstoken_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/akfaiz/go-vue-starter-kit/internal/domain"
+	"github.com/akfaiz/go-vue-starter-kit/internal/errdefs"
+	"github.com/akfaiz/go-vue-starter-kit/internal/mocks"
+	"github.com/akfaiz/go-vue-starter-kit/internal/service/personalaccesstoken"
+	"github.com/akfaiz/go-vue-starter-kit/internal/validator"
+	"github.com/invopop/ctxi18n"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+)
+
+func timePtr(t time.Time) *time.Time {
+	return &t
+}
+
+var _ = Describe("Personal Access Token Service", Label("unit", "usecase"), func() {
+	var (
+		tokenRepoMock *mocks.MockPersonalAccessTokenRepository
+		userRepoMock  *mocks.MockUserRepository
+		svc           domain.PersonalAccessTokenService
+
+		ctx  context.Context
+		user *domain.User
+	)
+	BeforeEach(func() {
+		ctrl := gomock.NewController(GinkgoT())
+		tokenRepoMock = mocks.NewMockPersonalAccessTokenRepository(ctrl)
+		userRepoMock = mocks.NewMockUserRepository(ctrl)
+		svc = personalaccesstoken.NewService(tokenRepoMock, userRepoMock)
+
+		var err error
+		ctx, err = ctxi18n.WithLocale(context.Background(), "en")
+		Expect(err).NotTo(HaveOccurred())
+		user = &domain.User{ID: 1, Name: "John Doe", Email: "john.doe@example.com"}
+
+		DeferCleanup(func() {
+			ctrl.Finish()
+		})
+	})
+
+	// create stores a token through the mocked repository and returns it with its plain text.
+	create := func(scopes []string, expiresAt *time.Time) (*domain.PersonalAccessToken, string) {
+		var stored *domain.PersonalAccessToken
+		tokenRepoMock.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(
+			func(_ context.Context, token *domain.PersonalAccessToken) error {
+				token.ID = 7
+				stored = token
+				return nil
+			})
+		token, plainText, err := svc.Create(ctx, user.ID, "CI", scopes, expiresAt)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(token).To(BeIdenticalTo(stored))
+		return token, plainText
+	}
+
+	Describe("Create", func() {
+		It("should store only a hash and a lookup prefix", func() {
+			token, plainText := create([]string{domain.ScopeProfileWrite, domain.ScopeProfileRead, domain.ScopeProfileRead}, nil)
+
+			Expect(plainText).To(HavePrefix(domain.PersonalAccessTokenPrefix))
+			Expect(strings.HasPrefix(plainText, token.Prefix)).To(BeTrue())
+			Expect(token.TokenHash).To(HaveLen(64))
+			Expect(token.TokenHash).NotTo(ContainSubstring(plainText[len(token.Prefix):]))
+			Expect(token.Scopes).To(Equal([]string{domain.ScopeProfileRead, domain.ScopeProfileWrite}))
+		})
+		DescribeTable("should reject invalid input",
+			func(scopes []string, expiresAt *time.Time, field string) {
+				_, _, err := svc.Create(ctx, user.ID, "CI", scopes, expiresAt)
+
+				var vErr *validator.ValidationError
+				Expect(errors.As(err, &vErr)).To(BeTrue())
+				Expect(vErr.First().Field).To(Equal(field))
+			},
+			Entry("without scopes", nil, nil, "scopes"),
+			Entry("with an unknown scope", []string{"admin"}, nil, "scopes"),
+			Entry("with a past expiry", []string{domain.ScopeProfileRead}, timePtr(time.Now().Add(-time.Minute)), "expires_at"),
+		)
+	})
+
+	Describe("Authenticate", func() {
+		var (
+			token     *domain.PersonalAccessToken
+			plainText string
+		)
+		BeforeEach(func() {
+			token, plainText = create([]string{domain.ScopeProfileRead}, nil)
+		})
+
+		It("should return scoped claims for the owner and record the use", func() {
+			tokenRepoMock.EXPECT().FindByPrefix(ctx, token.Prefix).Return(token, nil)
+			userRepoMock.EXPECT().FindByID(ctx, int64(1)).Return(user, nil)
+			tokenRepoMock.EXPECT().TouchLastUsed(ctx, int64(7)).Return(nil)
+
+			claims, err := svc.Authenticate(ctx, plainText)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(claims.ID).To(Equal(int64(1)))
+			Expect(claims.Type).To(Equal(domain.JWTTypePersonalAccess))
+			Expect(claims.HasScope(domain.ScopeProfileRead)).To(BeTrue())
+			Expect(claims.HasScope(domain.ScopeProfileWrite)).To(BeFalse())
+		})
+		It("should not record every use of a busy token", func() {
+			token.LastUsedAt = timePtr(time.Now().Add(-10 * time.Second))
+			tokenRepoMock.EXPECT().FindByPrefix(ctx, token.Prefix).Return(token, nil)
+			userRepoMock.EXPECT().FindByID(ctx, int64(1)).Return(user, nil)
+
+			_, err := svc.Authenticate(ctx, plainText)
+
+			Expect(err).NotTo(HaveOccurred())
+		})
+		It("should reject a token with the right prefix but a wrong secret", func() {
+			tokenRepoMock.EXPECT().FindByPrefix(ctx, token.Prefix).Return(token, nil)
+
+			_, err := svc.Authenticate(ctx, token.Prefix+"_forged")
+
+			var appErr *errdefs.AppError
+			Expect(errors.As(err, &appErr)).To(BeTrue())
+			Expect(appErr.Status).To(Equal(401))
+		})
+		It("should reject an expired token", func() {
+			token.ExpiresAt = timePtr(time.Now().Add(-time.Minute))
+			tokenRepoMock.EXPECT().FindByPrefix(ctx, token.Prefix).Return(token, nil)
+
+			_, err := svc.Authenticate(ctx, plainText)
+
+			var appErr *errdefs.AppError
+			Expect(errors.As(err, &appErr)).To(BeTrue())
+			Expect(appErr.Status).To(Equal(401))
+		})
+		It("should reject an unknown token", func() {
+			tokenRepoMock.EXPECT().FindByPrefix(ctx, token.Prefix).Return(nil, domain.ErrResourceNotFound)
+
+			_, err := svc.Authenticate(ctx, plainText)
+
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
@@ -0,0 +1,122 @@
+package rbac
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/akfaiz/go-vue-starter-kit/internal/config"
+	"github.com/akfaiz/go-vue-starter-kit/internal/domain"
+)
+
+// pruneThreshold is the cache size from which expired entries are dropped
+// before adding another.
+const pruneThreshold = 1024
+
+type service struct {
+	cacheTTL time.Duration
+	roleRepo domain.RoleRepository
+
+	mu    sync.Mutex
+	cache map[int64]cachedAuthorization
+}
+
+type cachedAuthorization struct {
+	authorization *domain.Authorization
+	expiresAt     time.Time
+}
+
+func NewService(cfg config.Auth, roleRepo domain.RoleRepository) domain.RBACService {
+	return &service{
+		cacheTTL: cfg.PermissionCacheTTL,
+		roleRepo: roleRepo,
+		cache:    make(map[int64]cachedAuthorization),
+	}
+}
+
+func (s *service) Authorization(ctx context.Context, userID int64) (*domain.Authorization, error) {
+	now := time.Now()
+	s.mu.Lock()
+	cached, ok := s.cache[userID]
+	s.mu.Unlock()
+	if ok && now.Before(cached.expiresAt) {
+		return cached.authorization, nil
+	}
+
+	roles, err := s.roleRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	authorization := &domain.Authorization{
+		Roles:       make([]string, 0, len(roles)),
+		Permissions: []string{},
+	}
+	for _, role := range roles {
+		authorization.Roles = append(authorization.Roles, role.Name)
+		authorization.Permissions = append(authorization.Permissions, role.Permissions...)
+	}
+	slices.Sort(authorization.Permissions)
+	authorization.Permissions = slices.Compact(authorization.Permissions)
+
+	if s.cacheTTL > 0 {
+		s.mu.Lock()
+		if len(s.cache) >= pruneThreshold {
+			s.prune(now)
+		}
+		s.cache[userID] = cachedAuthorization{authorization: authorization, expiresAt: now.Add(s.cacheTTL)}
+		s.mu.Unlock()
+	}
+	return authorization, nil
+}
+
+func (s *service) HasPermission(ctx context.Context, userID int64, permission string) (bool, error) {
+	authorization, err := s.Authorization(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	return authorization.Can(permission), nil
+}
+
+func (s *service) ListRoles(ctx context.Context) ([]*domain.Role, error) {
+	return s.roleRepo.FindAll(ctx)
+}
+
+func (s *service) AssignRole(ctx context.Context, userID int64, roleName string) error {
+	role, err := s.roleRepo.FindByName(ctx, roleName)
+	if err != nil {
+		return err
+	}
+	if err := s.roleRepo.AssignToUser(ctx, userID, role.ID); err != nil {
+		return err
+	}
+	s.forget(userID)
+	return nil
+}
+
+func (s *service) RemoveRole(ctx context.Context, userID int64, roleName string) error {
+	role, err := s.roleRepo.FindByName(ctx, roleName)
+	if err != nil {
+		return err
+	}
+	if err := s.roleRepo.RemoveFromUser(ctx, userID, role.ID); err != nil {
+		return err
+	}
+	s.forget(userID)
+	return nil
+}
+
+func (s *service) forget(userID int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.cache, userID)
+}
+
+// prune drops expired entries. Callers must hold s.mu.
+func (s *service) prune(now time.Time) {
+	for id, cached := range s.cache {
+		if !now.Before(cached.expiresAt) {
+			delete(s.cache, id)
+		}
+	}
+}
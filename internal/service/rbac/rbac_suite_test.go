@@ -0,0 +1,13 @@
+package rbac_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestRBACService(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "RBAC Service Suite")
+}
@@ -0,0 +1,113 @@
+package rbac_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/akfaiz/go-vue-starter-kit/internal/config"
+	"github.com/akfaiz/go-vue-starter-kit/internal/domain"
+	"github.com/akfaiz/go-vue-starter-kit/internal/mocks"
+	"github.com/akfaiz/go-vue-starter-kit/internal/service/rbac"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+)
+
+var _ = Describe("RBAC Service", Label("unit", "usecase"), func() {
+	var (
+		roleRepoMock *mocks.MockRoleRepository
+		svc          domain.RBACService
+
+		ctx   context.Context
+		admin *domain.Role
+	)
+	BeforeEach(func() {
+		ctrl := gomock.NewController(GinkgoT())
+		roleRepoMock = mocks.NewMockRoleRepository(ctrl)
+		svc = rbac.NewService(config.Auth{PermissionCacheTTL: time.Minute}, roleRepoMock)
+
+		ctx = context.Background()
+		admin = &domain.Role{ID: 1, Name: domain.RoleAdmin, Permissions: []string{domain.PermissionRolesManage, domain.PermissionUsersManage}}
+
+		DeferCleanup(func() {
+			ctrl.Finish()
+		})
+	})
+
+	Describe("Authorization", func() {
+		It("should merge the permissions of every role", func() {
+			support := &domain.Role{ID: 2, Name: "support", Permissions: []string{domain.PermissionUsersManage}}
+			roleRepoMock.EXPECT().FindByUserID(ctx, int64(1)).Return([]*domain.Role{admin, support}, nil)
+
+			authorization, err := svc.Authorization(ctx, 1)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(authorization.Roles).To(Equal([]string{domain.RoleAdmin, "support"}))
+			Expect(authorization.Permissions).To(Equal([]string{domain.PermissionRolesManage, domain.PermissionUsersManage}))
+		})
+		It("should cache the lookup per user", func() {
+			roleRepoMock.EXPECT().FindByUserID(ctx, int64(1)).Return([]*domain.Role{admin}, nil).Times(1)
+
+			for range 2 {
+				allowed, err := svc.HasPermission(ctx, 1, domain.PermissionUsersManage)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(allowed).To(BeTrue())
+			}
+		})
+		It("should deny users without roles", func() {
+			roleRepoMock.EXPECT().FindByUserID(ctx, int64(2)).Return([]*domain.Role{}, nil)
+
+			allowed, err := svc.HasPermission(ctx, 2, domain.PermissionUsersManage)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(allowed).To(BeFalse())
+		})
+		It("should return store errors", func() {
+			roleRepoMock.EXPECT().FindByUserID(ctx, int64(1)).Return(nil, errors.New("db down"))
+
+			_, err := svc.HasPermission(ctx, 1, domain.PermissionUsersManage)
+
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("AssignRole", func() {
+		It("should assign the role and refresh the cached permissions", func() {
+			gomock.InOrder(
+				roleRepoMock.EXPECT().FindByUserID(ctx, int64(1)).Return([]*domain.Role{}, nil),
+				roleRepoMock.EXPECT().FindByName(ctx, domain.RoleAdmin).Return(admin, nil),
+				roleRepoMock.EXPECT().AssignToUser(ctx, int64(1), int64(1)).Return(nil),
+				roleRepoMock.EXPECT().FindByUserID(ctx, int64(1)).Return([]*domain.Role{admin}, nil),
+			)
+
+			allowed, err := svc.HasPermission(ctx, 1, domain.PermissionUsersManage)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(allowed).To(BeFalse())
+
+			Expect(svc.AssignRole(ctx, 1, domain.RoleAdmin)).To(Succeed())
+
+			allowed, err = svc.HasPermission(ctx, 1, domain.PermissionUsersManage)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(allowed).To(BeTrue())
+		})
+		It("should fail for an unknown role", func() {
+			roleRepoMock.EXPECT().FindByName(ctx, "owner").Return(nil, domain.ErrResourceNotFound)
+
+			err := svc.AssignRole(ctx, 1, "owner")
+
+			Expect(err).To(Equal(domain.ErrResourceNotFound))
+		})
+	})
+
+	Describe("RemoveRole", func() {
+		It("should report a role the user does not have", func() {
+			roleRepoMock.EXPECT().FindByName(ctx, domain.RoleAdmin).Return(admin, nil)
+			roleRepoMock.EXPECT().RemoveFromUser(ctx, int64(1), int64(1)).Return(domain.ErrResourceNotFound)
+
+			err := svc.RemoveRole(ctx, 1, domain.RoleAdmin)
+
+			Expect(err).To(Equal(domain.ErrResourceNotFound))
+		})
+	})
+})
@@ -0,0 +1,198 @@
+package session
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"github.com/akfaiz/go-vue-starter-kit/internal/config"
+	"github.com/akfaiz/go-vue-starter-kit/internal/domain"
+	"github.com/akfaiz/go-vue-starter-kit/internal/errdefs"
+	"github.com/google/uuid"
+	"github.com/invopop/ctxi18n/i18n"
+)
+
+type service struct {
+	cfg         config.JWT
+	sessionRepo domain.SessionRepository
+	userRepo    domain.UserRepository
+	jwtManager  domain.JWTManager
+	denylist    domain.TokenDenylist
+}
+
+func NewService(
+	cfg config.JWT,
+	sessionRepo domain.SessionRepository,
+	userRepo domain.UserRepository,
+	jwtManager domain.JWTManager,
+	denylist domain.TokenDenylist,
+) domain.SessionService {
+	return &service{
+		cfg:         cfg,
+		sessionRepo: sessionRepo,
+		userRepo:    userRepo,
+		jwtManager:  jwtManager,
+		denylist:    denylist,
+	}
+}
+
+func (s *service) Create(ctx context.Context, user *domain.User) (*domain.PairToken, error) {
+	// Every sign-in method ends here, so this is where suspended accounts are kept out.
+	if user.IsSuspended() {
+		return nil, errdefs.ErrAccountSuspended(i18n.T(ctx, "auth.suspended"))
+	}
+	sessionID := uuid.NewString()
+	pairToken, err := s.jwtManager.GeneratePairToken(newClaims(user, sessionID))
+	if err != nil {
+		return nil, err
+	}
+
+	client := domain.ClientInfoFromContext(ctx)
+	session := &domain.Session{
+		ID:        sessionID,
+		UserID:    user.ID,
+		TokenHash: hashToken(pairToken.RefreshToken),
+		UserAgent: client.UserAgent,
+		IPAddress: client.IPAddress,
+		ExpiresAt: time.Now().Add(s.cfg.RefreshExpires),
+	}
+	if err := s.sessionRepo.Create(ctx, session); err != nil {
+		return nil, err
+	}
+
+	return pairToken, nil
+}
+
+func (s *service) Refresh(ctx context.Context, refreshToken string) (*domain.PairToken, error) {
+	claims, err := s.jwtManager.VerifyRefreshToken(refreshToken)
+	if err != nil {
+		return nil, err
+	}
+	if claims.SessionID == "" {
+		return nil, errdefs.ErrTokenInvalid()
+	}
+
+	session, err := s.sessionRepo.FindByID(ctx, claims.SessionID)
+	if err != nil {
+		if errors.Is(err, domain.ErrResourceNotFound) {
+			return nil, errdefs.ErrTokenInvalid()
+		}
+		return nil, err
+	}
+	if session.UserID != claims.ID || !session.IsActive() {
+		return nil, errdefs.ErrTokenInvalid()
+	}
+
+	currentHash := hashToken(refreshToken)
+	if subtle.ConstantTimeCompare([]byte(currentHash), []byte(session.TokenHash)) != 1 {
+		// The token was already rotated, so whoever holds it is replaying it.
+		return nil, s.revokeReused(ctx, session.ID)
+	}
+
+	user, err := s.userRepo.FindByID(ctx, claims.ID)
+	if err != nil {
+		return nil, err
+	}
+	if user.IsSuspended() {
+		return nil, errdefs.ErrAccountSuspended(i18n.T(ctx, "auth.suspended"))
+	}
+	pairToken, err := s.jwtManager.GeneratePairToken(newClaims(user, session.ID))
+	if err != nil {
+		return nil, err
+	}
+
+	expiresAt := time.Now().Add(s.cfg.RefreshExpires)
+	err = s.sessionRepo.Rotate(ctx, session.ID, currentHash, hashToken(pairToken.RefreshToken), expiresAt)
+	if err != nil {
+		if errors.Is(err, domain.ErrResourceNotFound) {
+			return nil, s.revokeReused(ctx, session.ID)
+		}
+		return nil, err
+	}
+
+	return pairToken, nil
+}
+
+func (s *service) List(ctx context.Context, userID int64) ([]*domain.Session, error) {
+	return s.sessionRepo.FindActiveByUserID(ctx, userID)
+}
+
+func (s *service) Revoke(ctx context.Context, userID int64, sessionID string) error {
+	session, err := s.sessionRepo.FindByID(ctx, sessionID)
+	if err != nil {
+		return err
+	}
+	if session.UserID != userID {
+		return domain.ErrResourceNotFound
+	}
+	return s.revoke(ctx, session.ID)
+}
+
+func (s *service) RevokeAll(ctx context.Context, userID int64, exceptSessionID string) error {
+	ids, err := s.sessionRepo.RevokeByUserID(ctx, userID, exceptSessionID)
+	if err != nil {
+		return err
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+	return s.denylist.RevokeSessions(ctx, ids...)
+}
+
+func (s *service) Reauthenticate(ctx context.Context, userID int64, sessionID string) error {
+	if _, err := s.activeSession(ctx, userID, sessionID); err != nil {
+		return err
+	}
+	return s.sessionRepo.MarkAuthenticated(ctx, sessionID, time.Now())
+}
+
+func (s *service) AuthenticatedAt(ctx context.Context, userID int64, sessionID string) (time.Time, error) {
+	session, err := s.activeSession(ctx, userID, sessionID)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return session.AuthenticatedAt, nil
+}
+
+func (s *service) activeSession(ctx context.Context, userID int64, sessionID string) (*domain.Session, error) {
+	session, err := s.sessionRepo.FindByID(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if session.UserID != userID || !session.IsActive() {
+		return nil, domain.ErrResourceNotFound
+	}
+	return session, nil
+}
+
+// revoke ends the session and rejects the access tokens already issued for it.
+func (s *service) revoke(ctx context.Context, sessionID string) error {
+	if err := s.sessionRepo.Revoke(ctx, sessionID); err != nil {
+		return err
+	}
+	return s.denylist.RevokeSessions(ctx, sessionID)
+}
+
+func (s *service) revokeReused(ctx context.Context, sessionID string) error {
+	if err := s.revoke(ctx, sessionID); err != nil {
+		return err
+	}
+	return errdefs.ErrTokenInvalid("This refresh token has already been used. Please log in again.")
+}
+
+func newClaims(user *domain.User, sessionID string) *domain.JWTClaims {
+	return &domain.JWTClaims{
+		ID:        user.ID,
+		Name:      user.Name,
+		Email:     user.Email,
+		SessionID: sessionID,
+	}
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
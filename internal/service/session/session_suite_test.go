@@ -0,0 +1,13 @@
+package session_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestSessionService(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Session Service Suite")
+}
@@ -0,0 +1,329 @@
+package session_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"github.com/akfaiz/go-vue-starter-kit/internal/config"
+	"github.com/akfaiz/go-vue-starter-kit/internal/domain"
+	"github.com/akfaiz/go-vue-starter-kit/internal/errdefs"
+	"github.com/akfaiz/go-vue-starter-kit/internal/mocks"
+	"github.com/akfaiz/go-vue-starter-kit/internal/service/session"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+)
+
+func sha256Hex(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:])
+}
+
+var _ = Describe("Session Service", Label("unit", "usecase"), func() {
+	var (
+		sessionRepoMock *mocks.MockSessionRepository
+		userRepoMock    *mocks.MockUserRepository
+		jwtManagerMock  *mocks.MockJWTManager
+		denylistMock    *mocks.MockTokenDenylist
+		svc             domain.SessionService
+
+		ctx    context.Context
+		user   *domain.User
+		actErr error
+	)
+	BeforeEach(func() {
+		ctrl := gomock.NewController(GinkgoT())
+		sessionRepoMock = mocks.NewMockSessionRepository(ctrl)
+		userRepoMock = mocks.NewMockUserRepository(ctrl)
+		jwtManagerMock = mocks.NewMockJWTManager(ctrl)
+		denylistMock = mocks.NewMockTokenDenylist(ctrl)
+		cfg := config.JWT{RefreshExpires: time.Hour}
+		svc = session.NewService(cfg, sessionRepoMock, userRepoMock, jwtManagerMock, denylistMock)
+
+		ctx = context.Background()
+		user = &domain.User{ID: 1, Name: "John Doe", Email: "john.doe@example.com"}
+
+		DeferCleanup(func() {
+			ctrl.Finish()
+		})
+	})
+
+	Describe("Create", func() {
+		var token *domain.PairToken
+		JustBeforeEach(func() {
+			token, actErr = svc.Create(ctx, user)
+		})
+		When("the tokens are generated", func() {
+			var stored *domain.Session
+			BeforeEach(func() {
+				ctx = domain.ContextWithClientInfo(ctx, domain.ClientInfo{
+					IPAddress: "203.0.113.7",
+					UserAgent: "Mozilla/5.0",
+				})
+				jwtManagerMock.EXPECT().GeneratePairToken(gomock.Any()).DoAndReturn(func(claims *domain.JWTClaims) (*domain.PairToken, error) {
+					Expect(claims.ID).To(Equal(user.ID))
+					Expect(claims.SessionID).NotTo(BeEmpty())
+					return &domain.PairToken{AccessToken: "access", RefreshToken: "refresh"}, nil
+				})
+				sessionRepoMock.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, s *domain.Session) error {
+					stored = s
+					return nil
+				})
+			})
+			It("should persist the hashed refresh token", func() {
+				Expect(stored.UserAgent).To(Equal("Mozilla/5.0"))
+				Expect(stored.IPAddress).To(Equal("203.0.113.7"))
+				Expect(actErr).NotTo(HaveOccurred())
+				Expect(token.RefreshToken).To(Equal("refresh"))
+				Expect(stored.UserID).To(Equal(user.ID))
+				Expect(stored.ID).NotTo(BeEmpty())
+				Expect(stored.TokenHash).To(Equal(sha256Hex("refresh")))
+				Expect(stored.ExpiresAt).To(BeTemporally("~", time.Now().Add(time.Hour), time.Minute))
+			})
+		})
+		When("the session cannot be stored", func() {
+			BeforeEach(func() {
+				jwtManagerMock.EXPECT().GeneratePairToken(gomock.Any()).Return(&domain.PairToken{RefreshToken: "refresh"}, nil)
+				sessionRepoMock.EXPECT().Create(ctx, gomock.Any()).Return(errors.New("db down"))
+			})
+			It("bubbles the error", func() {
+				Expect(actErr).To(HaveOccurred())
+				Expect(token).To(BeNil())
+			})
+		})
+		When("the user is suspended", func() {
+			BeforeEach(func() {
+				user = &domain.User{ID: user.ID, Status: domain.UserStatusSuspended}
+			})
+			It("should not start a session", func() {
+				var appErr *errdefs.AppError
+				Expect(errors.As(actErr, &appErr)).To(BeTrue())
+				Expect(appErr.Type).To(Equal("/problems/account-suspended"))
+				Expect(token).To(BeNil())
+			})
+		})
+	})
+
+	Describe("Refresh", func() {
+		var (
+			token   *domain.PairToken
+			current *domain.Session
+		)
+		BeforeEach(func() {
+			current = &domain.Session{
+				ID:        "session-1",
+				UserID:    1,
+				TokenHash: sha256Hex("refresh-1"),
+				ExpiresAt: time.Now().Add(time.Hour),
+			}
+		})
+		JustBeforeEach(func() {
+			token, actErr = svc.Refresh(ctx, "refresh-1")
+		})
+		When("the refresh token is the current one", func() {
+			BeforeEach(func() {
+				jwtManagerMock.EXPECT().VerifyRefreshToken("refresh-1").Return(&domain.JWTClaims{ID: 1, SessionID: "session-1"}, nil)
+				sessionRepoMock.EXPECT().FindByID(ctx, "session-1").Return(current, nil)
+				userRepoMock.EXPECT().FindByID(ctx, int64(1)).Return(user, nil)
+				jwtManagerMock.EXPECT().GeneratePairToken(&domain.JWTClaims{
+					ID:        user.ID,
+					Name:      user.Name,
+					Email:     user.Email,
+					SessionID: "session-1",
+				}).Return(&domain.PairToken{AccessToken: "access-2", RefreshToken: "refresh-2"}, nil)
+				sessionRepoMock.EXPECT().Rotate(ctx, "session-1", sha256Hex("refresh-1"), sha256Hex("refresh-2"), gomock.Any()).Return(nil)
+			})
+			It("should rotate the refresh token", func() {
+				Expect(actErr).NotTo(HaveOccurred())
+				Expect(token.RefreshToken).To(Equal("refresh-2"))
+			})
+		})
+		When("an already rotated refresh token is replayed", func() {
+			BeforeEach(func() {
+				current.TokenHash = sha256Hex("refresh-2")
+				jwtManagerMock.EXPECT().VerifyRefreshToken("refresh-1").Return(&domain.JWTClaims{ID: 1, SessionID: "session-1"}, nil)
+				sessionRepoMock.EXPECT().FindByID(ctx, "session-1").Return(current, nil)
+				sessionRepoMock.EXPECT().Revoke(ctx, "session-1").Return(nil)
+				denylistMock.EXPECT().RevokeSessions(ctx, "session-1").Return(nil)
+			})
+			It("should revoke the whole session", func() {
+				var appErr *errdefs.AppError
+				Expect(errors.As(actErr, &appErr)).To(BeTrue())
+				Expect(appErr.Status).To(Equal(401))
+				Expect(token).To(BeNil())
+			})
+		})
+		When("the session was rotated concurrently", func() {
+			BeforeEach(func() {
+				jwtManagerMock.EXPECT().VerifyRefreshToken("refresh-1").Return(&domain.JWTClaims{ID: 1, SessionID: "session-1"}, nil)
+				sessionRepoMock.EXPECT().FindByID(ctx, "session-1").Return(current, nil)
+				userRepoMock.EXPECT().FindByID(ctx, int64(1)).Return(user, nil)
+				jwtManagerMock.EXPECT().GeneratePairToken(gomock.Any()).Return(&domain.PairToken{RefreshToken: "refresh-2"}, nil)
+				sessionRepoMock.EXPECT().Rotate(ctx, "session-1", gomock.Any(), gomock.Any(), gomock.Any()).Return(domain.ErrResourceNotFound)
+				sessionRepoMock.EXPECT().Revoke(ctx, "session-1").Return(nil)
+				denylistMock.EXPECT().RevokeSessions(ctx, "session-1").Return(nil)
+			})
+			It("should treat it as reuse", func() {
+				Expect(actErr).To(HaveOccurred())
+				Expect(token).To(BeNil())
+			})
+		})
+		When("the session is revoked", func() {
+			BeforeEach(func() {
+				revokedAt := time.Now()
+				current.RevokedAt = &revokedAt
+				jwtManagerMock.EXPECT().VerifyRefreshToken("refresh-1").Return(&domain.JWTClaims{ID: 1, SessionID: "session-1"}, nil)
+				sessionRepoMock.EXPECT().FindByID(ctx, "session-1").Return(current, nil)
+			})
+			It("should reject the token", func() {
+				var appErr *errdefs.AppError
+				Expect(errors.As(actErr, &appErr)).To(BeTrue())
+				Expect(appErr.Status).To(Equal(401))
+			})
+		})
+		When("the session does not exist", func() {
+			BeforeEach(func() {
+				jwtManagerMock.EXPECT().VerifyRefreshToken("refresh-1").Return(&domain.JWTClaims{ID: 1, SessionID: "session-1"}, nil)
+				sessionRepoMock.EXPECT().FindByID(ctx, "session-1").Return(nil, domain.ErrResourceNotFound)
+			})
+			It("should reject the token", func() {
+				var appErr *errdefs.AppError
+				Expect(errors.As(actErr, &appErr)).To(BeTrue())
+				Expect(appErr.Status).To(Equal(401))
+			})
+		})
+		When("the token carries no session", func() {
+			BeforeEach(func() {
+				jwtManagerMock.EXPECT().VerifyRefreshToken("refresh-1").Return(&domain.JWTClaims{ID: 1}, nil)
+			})
+			It("should reject the token", func() {
+				Expect(actErr).To(HaveOccurred())
+				Expect(token).To(BeNil())
+			})
+		})
+	})
+
+	Describe("List", func() {
+		It("should return the user's active sessions", func() {
+			sessions := []*domain.Session{{ID: "session-1", UserID: 1}}
+			sessionRepoMock.EXPECT().FindActiveByUserID(ctx, int64(1)).Return(sessions, nil)
+
+			res, err := svc.List(ctx, 1)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(res).To(Equal(sessions))
+		})
+	})
+
+	Describe("Revoke", func() {
+		JustBeforeEach(func() {
+			actErr = svc.Revoke(ctx, 1, "session-1")
+		})
+		When("the session belongs to the user", func() {
+			BeforeEach(func() {
+				sessionRepoMock.EXPECT().FindByID(ctx, "session-1").Return(&domain.Session{ID: "session-1", UserID: 1}, nil)
+				sessionRepoMock.EXPECT().Revoke(ctx, "session-1").Return(nil)
+				denylistMock.EXPECT().RevokeSessions(ctx, "session-1").Return(nil)
+			})
+			It("should revoke the session and its access tokens", func() {
+				Expect(actErr).NotTo(HaveOccurred())
+			})
+		})
+		When("the session belongs to another user", func() {
+			BeforeEach(func() {
+				sessionRepoMock.EXPECT().FindByID(ctx, "session-1").Return(&domain.Session{ID: "session-1", UserID: 2}, nil)
+			})
+			It("should report it as not found", func() {
+				Expect(actErr).To(Equal(domain.ErrResourceNotFound))
+			})
+		})
+	})
+
+	Describe("RevokeAll", func() {
+		JustBeforeEach(func() {
+			actErr = svc.RevokeAll(ctx, 1, "session-1")
+		})
+		When("other sessions are active", func() {
+			BeforeEach(func() {
+				sessionRepoMock.EXPECT().RevokeByUserID(ctx, int64(1), "session-1").Return([]string{"session-2", "session-3"}, nil)
+				denylistMock.EXPECT().RevokeSessions(ctx, "session-2", "session-3").Return(nil)
+			})
+			It("should revoke their access tokens", func() {
+				Expect(actErr).NotTo(HaveOccurred())
+			})
+		})
+		When("no other session is active", func() {
+			BeforeEach(func() {
+				sessionRepoMock.EXPECT().RevokeByUserID(ctx, int64(1), "session-1").Return(nil, nil)
+			})
+			It("should not touch the denylist", func() {
+				Expect(actErr).NotTo(HaveOccurred())
+			})
+		})
+	})
+
+	Describe("Reauthenticate", func() {
+		JustBeforeEach(func() {
+			actErr = svc.Reauthenticate(ctx, 1, "session-1")
+		})
+		When("the session is active", func() {
+			BeforeEach(func() {
+				sessionRepoMock.EXPECT().FindByID(ctx, "session-1").Return(&domain.Session{
+					ID:        "session-1",
+					UserID:    1,
+					ExpiresAt: time.Now().Add(time.Hour),
+				}, nil)
+				sessionRepoMock.EXPECT().MarkAuthenticated(ctx, "session-1", gomock.Any()).DoAndReturn(func(_ context.Context, _ string, at time.Time) error {
+					Expect(at).To(BeTemporally("~", time.Now(), time.Second))
+					return nil
+				})
+			})
+			It("should restart the recent authentication window", func() {
+				Expect(actErr).NotTo(HaveOccurred())
+			})
+		})
+		When("the session was revoked", func() {
+			BeforeEach(func() {
+				revokedAt := time.Now()
+				sessionRepoMock.EXPECT().FindByID(ctx, "session-1").Return(&domain.Session{
+					ID:        "session-1",
+					UserID:    1,
+					ExpiresAt: time.Now().Add(time.Hour),
+					RevokedAt: &revokedAt,
+				}, nil)
+			})
+			It("should report it as not found", func() {
+				Expect(actErr).To(Equal(domain.ErrResourceNotFound))
+			})
+		})
+	})
+
+	Describe("AuthenticatedAt", func() {
+		It("should return when the user last proved their identity", func() {
+			authenticatedAt := time.Now().Add(-5 * time.Minute)
+			sessionRepoMock.EXPECT().FindByID(ctx, "session-1").Return(&domain.Session{
+				ID:              "session-1",
+				UserID:          1,
+				ExpiresAt:       time.Now().Add(time.Hour),
+				AuthenticatedAt: authenticatedAt,
+			}, nil)
+
+			at, err := svc.AuthenticatedAt(ctx, 1, "session-1")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(at).To(Equal(authenticatedAt))
+		})
+		It("should not reveal sessions of other users", func() {
+			sessionRepoMock.EXPECT().FindByID(ctx, "session-1").Return(&domain.Session{
+				ID:        "session-1",
+				UserID:    2,
+				ExpiresAt: time.Now().Add(time.Hour),
+			}, nil)
+
+			_, err := svc.AuthenticatedAt(ctx, 1, "session-1")
+			Expect(err).To(Equal(domain.ErrResourceNotFound))
+		})
+	})
+})
@@ -0,0 +1,174 @@
+package suspension
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/aarondl/opt/omit"
+	"github.com/aarondl/opt/omitnull"
+	"github.com/akfaiz/go-mailgen"
+	"github.com/akfaiz/go-vue-starter-kit/internal/config"
+	"github.com/akfaiz/go-vue-starter-kit/internal/domain"
+)
+
+// pruneThreshold is the cache size from which expired entries are dropped
+// before adding another.
+const pruneThreshold = 1024
+
+type service struct {
+	cfg            config.Config
+	userRepo       domain.UserRepository
+	sessionService domain.SessionService
+	mailer         domain.Mailer
+	auditLogger    domain.AuditLogger
+
+	mu    sync.Mutex
+	cache map[int64]cachedStatus
+}
+
+type cachedStatus struct {
+	suspended bool
+	expiresAt time.Time
+}
+
+func NewService(
+	cfg config.Config,
+	userRepo domain.UserRepository,
+	sessionService domain.SessionService,
+	mailer domain.Mailer,
+	auditLogger domain.AuditLogger,
+) domain.SuspensionService {
+	return &service{
+		cfg:            cfg,
+		userRepo:       userRepo,
+		sessionService: sessionService,
+		mailer:         mailer,
+		auditLogger:    auditLogger,
+		cache:          make(map[int64]cachedStatus),
+	}
+}
+
+func (s *service) Suspend(ctx context.Context, userID int64, reason string) error {
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if user.IsSuspended() {
+		return nil
+	}
+
+	update := &domain.UserUpdate{
+		Status:           omit.From(domain.UserStatusSuspended),
+		SuspendedAt:      omitnull.From(time.Now()),
+		SuspensionReason: omitnull.FromPtr[string](nil),
+	}
+	if reason != "" {
+		update.SuspensionReason = omitnull.From(reason)
+	}
+	if err := s.userRepo.Update(ctx, userID, update); err != nil {
+		return err
+	}
+	s.forget(userID)
+
+	// Sign the user out everywhere, including the access tokens still in circulation.
+	if err := s.sessionService.RevokeAll(ctx, userID, ""); err != nil {
+		return err
+	}
+	s.auditLogger.Log(ctx, domain.AuditUserSuspended, userID, map[string]any{"reason": reason})
+	// The suspension stands even if the notification cannot be delivered.
+	_ = s.mailer.Send(ctx, s.buildEmailSuspended(user, reason))
+	return nil
+}
+
+func (s *service) Unsuspend(ctx context.Context, userID int64) error {
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if !user.IsSuspended() {
+		return nil
+	}
+
+	update := &domain.UserUpdate{
+		Status:           omit.From(domain.UserStatusActive),
+		SuspendedAt:      omitnull.FromPtr[time.Time](nil),
+		SuspensionReason: omitnull.FromPtr[string](nil),
+	}
+	if err := s.userRepo.Update(ctx, userID, update); err != nil {
+		return err
+	}
+	s.forget(userID)
+	s.auditLogger.Log(ctx, domain.AuditUserUnsuspended, userID, nil)
+
+	_ = s.mailer.Send(ctx, s.buildEmailUnsuspended(user))
+	return nil
+}
+
+// IsSuspended is checked on every authenticated request, so the answer is cached
+// for SuspensionCacheTTL. Suspending also revokes the user's sessions, which
+// takes effect regardless of this cache.
+func (s *service) IsSuspended(ctx context.Context, userID int64) (bool, error) {
+	now := time.Now()
+	s.mu.Lock()
+	cached, ok := s.cache[userID]
+	s.mu.Unlock()
+	if ok && now.Before(cached.expiresAt) {
+		return cached.suspended, nil
+	}
+
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, domain.ErrResourceNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	if ttl := s.cfg.Auth.SuspensionCacheTTL; ttl > 0 {
+		s.mu.Lock()
+		if len(s.cache) >= pruneThreshold {
+			s.prune(now)
+		}
+		s.cache[userID] = cachedStatus{suspended: user.IsSuspended(), expiresAt: now.Add(ttl)}
+		s.mu.Unlock()
+	}
+	return user.IsSuspended(), nil
+}
+
+func (s *service) forget(userID int64) {
+	s.mu.Lock()
+	delete(s.cache, userID)
+	s.mu.Unlock()
+}
+
+// prune drops expired entries. Callers must hold s.mu.
+func (s *service) prune(now time.Time) {
+	for userID, cached := range s.cache {
+		if !now.Before(cached.expiresAt) {
+			delete(s.cache, userID)
+		}
+	}
+}
+
+func (s *service) buildEmailSuspended(user *domain.User, reason string) *mailgen.Builder {
+	msg := mailgen.New().
+		To(user.Email).
+		Subject("Your Account Has Been Suspended").
+		Name(user.Name).
+		Line("Your account has been suspended and you have been signed out of all devices.")
+	if reason != "" {
+		msg = msg.Linef("Reason: %s", reason)
+	}
+	return msg.Line("If you believe this is a mistake, please reply to this email to contact us.")
+}
+
+func (s *service) buildEmailUnsuspended(user *domain.User) *mailgen.Builder {
+	return mailgen.New().
+		To(user.Email).
+		Subject("Your Account Has Been Reinstated").
+		Name(user.Name).
+		Line("The suspension on your account has been lifted, so you can sign in again.").
+		Action("Sign In", s.cfg.App.FrontendBaseURL+"/login")
+}
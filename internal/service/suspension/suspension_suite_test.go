@@ -0,0 +1,13 @@
+package suspension_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestSuspensionService(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Suspension Service Suite")
+}
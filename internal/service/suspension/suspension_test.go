@@ -0,0 +1,137 @@
+package suspension_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/akfaiz/go-vue-starter-kit/internal/config"
+	"github.com/akfaiz/go-vue-starter-kit/internal/domain"
+	"github.com/akfaiz/go-vue-starter-kit/internal/mocks"
+	"github.com/akfaiz/go-vue-starter-kit/internal/service/suspension"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+)
+
+var _ = Describe("Suspension Service", Label("unit", "usecase"), func() {
+	var (
+		userRepoMock    *mocks.MockUserRepository
+		sessionSvcMock  *mocks.MockSessionService
+		mailerMock      *mocks.MockMailer
+		auditLoggerMock *mocks.MockAuditLogger
+		svc             domain.SuspensionService
+
+		ctx  context.Context
+		user *domain.User
+	)
+	BeforeEach(func() {
+		ctrl := gomock.NewController(GinkgoT())
+		userRepoMock = mocks.NewMockUserRepository(ctrl)
+		sessionSvcMock = mocks.NewMockSessionService(ctrl)
+		mailerMock = mocks.NewMockMailer(ctrl)
+		cfg := config.Config{Auth: config.Auth{SuspensionCacheTTL: time.Minute}}
+		auditLoggerMock = mocks.NewMockAuditLogger(ctrl)
+		auditLoggerMock.EXPECT().Log(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+		svc = suspension.NewService(cfg, userRepoMock, sessionSvcMock, mailerMock, auditLoggerMock)
+
+		ctx = context.Background()
+		user = &domain.User{ID: 2, Name: "Jane Doe", Email: "jane@example.com", Status: domain.UserStatusActive}
+
+		DeferCleanup(func() {
+			ctrl.Finish()
+		})
+	})
+
+	Describe("Suspend", func() {
+		It("should suspend, sign out and notify the user", func() {
+			userRepoMock.EXPECT().FindByID(ctx, int64(2)).Return(user, nil)
+			userRepoMock.EXPECT().Update(ctx, int64(2), gomock.Any()).DoAndReturn(func(_ context.Context, _ int64, update *domain.UserUpdate) error {
+				Expect(update.Status.MustGet()).To(Equal(domain.UserStatusSuspended))
+				Expect(update.SuspendedAt.MustGet()).To(BeTemporally("~", time.Now(), time.Second))
+				Expect(update.SuspensionReason.MustGet()).To(Equal("Spam"))
+				return nil
+			})
+			sessionSvcMock.EXPECT().RevokeAll(ctx, int64(2), "").Return(nil)
+			mailerMock.EXPECT().Send(ctx, gomock.Any()).Return(nil)
+
+			Expect(svc.Suspend(ctx, 2, "Spam")).To(Succeed())
+		})
+		It("should not fail when the notification cannot be sent", func() {
+			userRepoMock.EXPECT().FindByID(ctx, int64(2)).Return(user, nil)
+			userRepoMock.EXPECT().Update(ctx, int64(2), gomock.Any()).DoAndReturn(func(_ context.Context, _ int64, update *domain.UserUpdate) error {
+				Expect(update.SuspensionReason.IsNull()).To(BeTrue())
+				return nil
+			})
+			sessionSvcMock.EXPECT().RevokeAll(ctx, int64(2), "").Return(nil)
+			mailerMock.EXPECT().Send(ctx, gomock.Any()).Return(errors.New("smtp down"))
+
+			Expect(svc.Suspend(ctx, 2, "")).To(Succeed())
+		})
+		It("should leave a suspended user alone", func() {
+			user.Status = domain.UserStatusSuspended
+			userRepoMock.EXPECT().FindByID(ctx, int64(2)).Return(user, nil)
+
+			Expect(svc.Suspend(ctx, 2, "Spam")).To(Succeed())
+		})
+	})
+
+	Describe("Unsuspend", func() {
+		It("should reactivate and notify the user", func() {
+			user.Status = domain.UserStatusSuspended
+			userRepoMock.EXPECT().FindByID(ctx, int64(2)).Return(user, nil)
+			userRepoMock.EXPECT().Update(ctx, int64(2), gomock.Any()).DoAndReturn(func(_ context.Context, _ int64, update *domain.UserUpdate) error {
+				Expect(update.Status.MustGet()).To(Equal(domain.UserStatusActive))
+				Expect(update.SuspendedAt.IsNull()).To(BeTrue())
+				Expect(update.SuspensionReason.IsNull()).To(BeTrue())
+				return nil
+			})
+			mailerMock.EXPECT().Send(ctx, gomock.Any()).Return(nil)
+
+			Expect(svc.Unsuspend(ctx, 2)).To(Succeed())
+		})
+		It("should leave an active user alone", func() {
+			userRepoMock.EXPECT().FindByID(ctx, int64(2)).Return(user, nil)
+
+			Expect(svc.Unsuspend(ctx, 2)).To(Succeed())
+		})
+	})
+
+	Describe("IsSuspended", func() {
+		It("should cache the lookup per user", func() {
+			user.Status = domain.UserStatusSuspended
+			userRepoMock.EXPECT().FindByID(ctx, int64(2)).Return(user, nil).Times(1)
+
+			for range 2 {
+				suspended, err := svc.IsSuspended(ctx, 2)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(suspended).To(BeTrue())
+			}
+		})
+		It("should forget the cached answer when the status changes", func() {
+			userRepoMock.EXPECT().FindByID(ctx, int64(2)).Return(user, nil).Times(2)
+			userRepoMock.EXPECT().Update(ctx, int64(2), gomock.Any()).Return(nil)
+			sessionSvcMock.EXPECT().RevokeAll(ctx, int64(2), "").Return(nil)
+			mailerMock.EXPECT().Send(ctx, gomock.Any()).Return(nil)
+
+			suspended, err := svc.IsSuspended(ctx, 2)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(suspended).To(BeFalse())
+
+			Expect(svc.Suspend(ctx, 2, "")).To(Succeed())
+
+			suspended2 := &domain.User{ID: 2, Status: domain.UserStatusSuspended}
+			userRepoMock.EXPECT().FindByID(ctx, int64(2)).Return(suspended2, nil)
+			suspended, err = svc.IsSuspended(ctx, 2)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(suspended).To(BeTrue())
+		})
+		It("should treat a deleted user as not suspended", func() {
+			userRepoMock.EXPECT().FindByID(ctx, int64(3)).Return(nil, domain.ErrResourceNotFound)
+
+			suspended, err := svc.IsSuspended(ctx, 3)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(suspended).To(BeFalse())
+		})
+	})
+})
@@ -0,0 +1,145 @@
+package throttle
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/akfaiz/go-mailgen"
+	"github.com/akfaiz/go-vue-starter-kit/internal/config"
+	"github.com/akfaiz/go-vue-starter-kit/internal/domain"
+	"github.com/akfaiz/go-vue-starter-kit/internal/errdefs"
+	"github.com/invopop/ctxi18n/i18n"
+)
+
+type loginThrottler struct {
+	cfg      config.LoginThrottle
+	appCfg   config.App
+	store    domain.ThrottleStore
+	userRepo domain.UserRepository
+	mailer   domain.Mailer
+}
+
+func NewLoginThrottler(
+	cfg config.Config,
+	store domain.ThrottleStore,
+	userRepo domain.UserRepository,
+	mailer domain.Mailer,
+) domain.LoginThrottler {
+	return &loginThrottler{
+		cfg:      cfg.Throttle.Login,
+		appCfg:   cfg.App,
+		store:    store,
+		userRepo: userRepo,
+		mailer:   mailer,
+	}
+}
+
+func (t *loginThrottler) Check(ctx context.Context, email string) error {
+	now := time.Now()
+
+	account, err := t.get(ctx, accountKey(email))
+	if err != nil {
+		return err
+	}
+	if wait := account.BlockedFor(now); wait > 0 {
+		return errdefs.ErrLocked(i18n.T(ctx, "auth.locked")).WithRetryAfter(wait)
+	}
+
+	client, err := t.get(ctx, clientKey(ctx, email))
+	if err != nil {
+		return err
+	}
+	if wait := client.BlockedFor(now); wait > 0 {
+		return errdefs.ErrTooManyRequests(i18n.T(ctx, "auth.throttle")).WithRetryAfter(wait)
+	}
+	return nil
+}
+
+func (t *loginThrottler) Fail(ctx context.Context, email string) error {
+	now := time.Now()
+	_ = t.store.DeleteExpired(ctx)
+
+	client, err := t.store.Hit(ctx, clientKey(ctx, email), t.cfg.Window)
+	if err != nil {
+		return err
+	}
+	if over := client.Hits - t.cfg.FreeAttempts; over > 0 {
+		if err := t.store.Block(ctx, client.Key, now.Add(t.backoff(over))); err != nil {
+			return err
+		}
+	}
+
+	account, err := t.store.Hit(ctx, accountKey(email), t.cfg.Window)
+	if err != nil {
+		return err
+	}
+	if account.Hits < t.cfg.LockoutThreshold {
+		return nil
+	}
+	// Start counting from zero so the account is not locked again on the first
+	// failure after the lockout ends.
+	if err := t.store.Reset(ctx, account.Key); err != nil {
+		return err
+	}
+	if err := t.store.Block(ctx, account.Key, now.Add(t.cfg.LockoutDuration)); err != nil {
+		return err
+	}
+	t.notifyLocked(ctx, email)
+	return nil
+}
+
+func (t *loginThrottler) Succeed(ctx context.Context, email string) error {
+	if err := t.store.Reset(ctx, clientKey(ctx, email)); err != nil {
+		return err
+	}
+	return t.store.Reset(ctx, accountKey(email))
+}
+
+// backoff doubles BaseDelay for every failure past the free attempts, up to MaxDelay.
+func (t *loginThrottler) backoff(over int) time.Duration {
+	delay := t.cfg.BaseDelay
+	for i := 1; i < over && delay < t.cfg.MaxDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, t.cfg.MaxDelay)
+}
+
+func (t *loginThrottler) get(ctx context.Context, key string) (*domain.ThrottleCounter, error) {
+	counter, err := t.store.Get(ctx, key)
+	if errors.Is(err, domain.ErrResourceNotFound) {
+		return &domain.ThrottleCounter{Key: key}, nil
+	}
+	return counter, err
+}
+
+// notifyLocked tells the owner of the account, if there is one, that it was locked.
+// Delivery problems must not turn a failed login into a server error.
+func (t *loginThrottler) notifyLocked(ctx context.Context, email string) {
+	user, err := t.userRepo.FindByEmail(ctx, email)
+	if err != nil {
+		return
+	}
+	_ = t.mailer.Send(ctx, t.buildEmailLocked(user))
+}
+
+func (t *loginThrottler) buildEmailLocked(user *domain.User) *mailgen.Builder {
+	return mailgen.New().
+		To(user.Email).
+		Subject("Your Account Has Been Locked").
+		Name(user.Name).
+		Line("We noticed too many failed login attempts on your account, so we have temporarily locked it.").
+		Linef("You can try again in %d minutes.", int(t.cfg.LockoutDuration.Minutes())).
+		Line("If this was not you, we recommend resetting your password. Doing so also unlocks your account.").
+		Action("Reset Password", t.appCfg.FrontendBaseURL+"/forgot-password")
+}
+
+func accountKey(email string) string {
+	return "login:account:" + strings.ToLower(email)
+}
+
+func clientKey(ctx context.Context, email string) string {
+	client := domain.ClientInfoFromContext(ctx)
+	return "login:client:" + strings.ToLower(email) + "|" + client.IPAddress
+}
@@ -0,0 +1,128 @@
+package throttle_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/akfaiz/go-vue-starter-kit/internal/config"
+	"github.com/akfaiz/go-vue-starter-kit/internal/domain"
+	"github.com/akfaiz/go-vue-starter-kit/internal/errdefs"
+	"github.com/akfaiz/go-vue-starter-kit/internal/mocks"
+	throttlestore "github.com/akfaiz/go-vue-starter-kit/internal/repository/throttle"
+	"github.com/akfaiz/go-vue-starter-kit/internal/service/throttle"
+	"github.com/invopop/ctxi18n"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+)
+
+var _ = Describe("Login Throttler", Label("unit", "usecase"), func() {
+	const (
+		email            = "john.doe@example.com"
+		lockoutThreshold = 10
+	)
+	var (
+		userRepoMock *mocks.MockUserRepository
+		mailerMock   *mocks.MockMailer
+		store        domain.ThrottleStore
+		svc          domain.LoginThrottler
+
+		ctx context.Context
+	)
+	BeforeEach(func() {
+		ctrl := gomock.NewController(GinkgoT())
+		userRepoMock = mocks.NewMockUserRepository(ctrl)
+		mailerMock = mocks.NewMockMailer(ctrl)
+		store = throttlestore.NewMemoryStore()
+
+		cfg := config.Config{}
+		cfg.Throttle.Login = config.LoginThrottle{
+			FreeAttempts:     2,
+			BaseDelay:        time.Second,
+			MaxDelay:         4 * time.Second,
+			LockoutThreshold: lockoutThreshold,
+			LockoutDuration:  15 * time.Minute,
+			Window:           time.Hour,
+		}
+		svc = throttle.NewLoginThrottler(cfg, store, userRepoMock, mailerMock)
+
+		ctx = context.Background()
+		ctx, _ = ctxi18n.WithLocale(ctx, "en")
+		ctx = domain.ContextWithClientInfo(ctx, domain.ClientInfo{IPAddress: "203.0.113.7"})
+
+		DeferCleanup(func() {
+			ctrl.Finish()
+		})
+	})
+
+	fromIP := func(i int) context.Context {
+		return domain.ContextWithClientInfo(ctx, domain.ClientInfo{IPAddress: fmt.Sprintf("192.0.2.%d", i+1)})
+	}
+	problem := func(err error) *errdefs.AppError {
+		var appErr *errdefs.AppError
+		Expect(errors.As(err, &appErr)).To(BeTrue())
+		return appErr
+	}
+
+	It("should allow the free attempts without delay", func() {
+		for range 2 {
+			Expect(svc.Fail(ctx, email)).To(Succeed())
+		}
+		Expect(svc.Check(ctx, email)).To(Succeed())
+	})
+
+	It("should back off exponentially up to the maximum delay", func() {
+		for range 2 {
+			Expect(svc.Fail(ctx, email)).To(Succeed())
+		}
+		expected := []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 4 * time.Second}
+		for _, delay := range expected {
+			Expect(svc.Fail(ctx, email)).To(Succeed())
+			appErr := problem(svc.Check(ctx, email))
+			Expect(appErr.Status).To(Equal(429))
+			Expect(appErr.RetryAfter()).To(BeNumerically("~", delay, 50*time.Millisecond))
+		}
+	})
+
+	It("should throttle per client IP", func() {
+		for range 3 {
+			Expect(svc.Fail(ctx, email)).To(Succeed())
+		}
+		other := domain.ContextWithClientInfo(ctx, domain.ClientInfo{IPAddress: "198.51.100.1"})
+		Expect(svc.Check(other, email)).To(Succeed())
+	})
+
+	It("should lock the account and email the owner after too many failures", func() {
+		user := &domain.User{ID: 1, Name: "John Doe", Email: email}
+		userRepoMock.EXPECT().FindByEmail(gomock.Any(), email).Return(user, nil)
+		mailerMock.EXPECT().Send(gomock.Any(), gomock.Any()).Return(nil)
+
+		// Spread the attempts over several IPs so only the account counter trips.
+		for i := range lockoutThreshold {
+			Expect(svc.Fail(fromIP(i), email)).To(Succeed())
+		}
+
+		appErr := problem(svc.Check(ctx, email))
+		Expect(appErr.Status).To(Equal(423))
+		Expect(appErr.RetryAfter()).To(BeNumerically("~", 15*time.Minute, time.Second))
+	})
+
+	It("should not email anyone when the account does not exist", func() {
+		userRepoMock.EXPECT().FindByEmail(gomock.Any(), email).Return(nil, domain.ErrResourceNotFound)
+
+		for i := range lockoutThreshold {
+			Expect(svc.Fail(fromIP(i), email)).To(Succeed())
+		}
+		Expect(problem(svc.Check(ctx, email)).Status).To(Equal(423))
+	})
+
+	It("should clear the counters after a successful login", func() {
+		for range 4 {
+			Expect(svc.Fail(ctx, email)).To(Succeed())
+		}
+		Expect(svc.Succeed(ctx, email)).To(Succeed())
+		Expect(svc.Check(ctx, email)).To(Succeed())
+	})
+})
@@ -0,0 +1,306 @@
+package twofactor
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/aarondl/opt/omitnull"
+	"github.com/akfaiz/go-vue-starter-kit/internal/config"
+	"github.com/akfaiz/go-vue-starter-kit/internal/domain"
+	"github.com/akfaiz/go-vue-starter-kit/internal/errdefs"
+	"github.com/akfaiz/go-vue-starter-kit/internal/validator"
+	"github.com/akfaiz/go-vue-starter-kit/pkg/totp"
+	"github.com/invopop/ctxi18n/i18n"
+)
+
+const (
+	recoveryCodeCount = 8
+	// codeSkew accepts codes from the previous and next period to tolerate clock drift.
+	codeSkew = 1
+	// maxChallengeFailures is how many wrong codes a challenge survives before
+	// the user has to log in again.
+	maxChallengeFailures = 5
+)
+
+type service struct {
+	cfg            config.Config
+	userRepo       domain.UserRepository
+	passwordHasher domain.PasswordHasher
+	encrypter      domain.Encrypter
+	throttleStore  domain.ThrottleStore
+}
+
+func NewService(
+	cfg config.Config,
+	userRepo domain.UserRepository,
+	passwordHasher domain.PasswordHasher,
+	encrypter domain.Encrypter,
+	throttleStore domain.ThrottleStore,
+) domain.TwoFactorService {
+	return &service{
+		cfg:            cfg,
+		userRepo:       userRepo,
+		passwordHasher: passwordHasher,
+		encrypter:      encrypter,
+		throttleStore:  throttleStore,
+	}
+}
+
+func (s *service) Enable(ctx context.Context, userID int64) (*domain.TwoFactorSetup, error) {
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.HasTwoFactorEnabled() {
+		return nil, errdefs.ErrConflict(i18n.T(ctx, "two_factor.already_enabled"))
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return nil, err
+	}
+	encrypted, err := s.encrypter.Encrypt(secret)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.userRepo.Update(ctx, user.ID, &domain.UserUpdate{
+		TwoFactorSecret:        omitnull.From(encrypted),
+		TwoFactorRecoveryCodes: omitnull.FromPtr[[]string](nil),
+		TwoFactorConfirmedAt:   omitnull.FromPtr[time.Time](nil),
+	}); err != nil {
+		return nil, err
+	}
+
+	return &domain.TwoFactorSetup{
+		Secret: secret,
+		URI:    totp.KeyURI(s.cfg.App.Name, user.Email, secret),
+	}, nil
+}
+
+func (s *service) Confirm(ctx context.Context, userID int64, code string) ([]string, error) {
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.HasTwoFactorEnabled() {
+		return nil, errdefs.ErrConflict(i18n.T(ctx, "two_factor.already_enabled"))
+	}
+	if user.TwoFactorSecret == nil {
+		return nil, errdefs.ErrBadRequest(i18n.T(ctx, "two_factor.not_enabled"))
+	}
+
+	valid, err := s.Verify(ctx, user, code)
+	if err != nil {
+		return nil, err
+	}
+	if !valid {
+		return nil, validator.NewError("code", i18n.T(ctx, "two_factor.invalid_code"))
+	}
+
+	codes, hashed, err := generateRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+	if err := s.userRepo.Update(ctx, user.ID, &domain.UserUpdate{
+		TwoFactorRecoveryCodes: omitnull.From(hashed),
+		TwoFactorConfirmedAt:   omitnull.From(time.Now()),
+	}); err != nil {
+		return nil, err
+	}
+
+	return codes, nil
+}
+
+func (s *service) Disable(ctx context.Context, userID int64, password string) error {
+	user, err := s.findWithPassword(ctx, userID, password)
+	if err != nil {
+		return err
+	}
+	if user.TwoFactorSecret == nil {
+		return nil
+	}
+
+	return s.userRepo.Update(ctx, user.ID, &domain.UserUpdate{
+		TwoFactorSecret:        omitnull.FromPtr[string](nil),
+		TwoFactorRecoveryCodes: omitnull.FromPtr[[]string](nil),
+		TwoFactorConfirmedAt:   omitnull.FromPtr[time.Time](nil),
+	})
+}
+
+func (s *service) RegenerateRecoveryCodes(ctx context.Context, userID int64, password string) ([]string, error) {
+	user, err := s.findWithPassword(ctx, userID, password)
+	if err != nil {
+		return nil, err
+	}
+	if !user.HasTwoFactorEnabled() {
+		return nil, errdefs.ErrBadRequest(i18n.T(ctx, "two_factor.not_enabled"))
+	}
+
+	codes, hashed, err := generateRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+	if err := s.userRepo.Update(ctx, user.ID, &domain.UserUpdate{
+		TwoFactorRecoveryCodes: omitnull.From(hashed),
+	}); err != nil {
+		return nil, err
+	}
+
+	return codes, nil
+}
+
+func (s *service) Verify(ctx context.Context, user *domain.User, code string) (bool, error) {
+	if user.TwoFactorSecret == nil {
+		return false, nil
+	}
+	secret, err := s.encrypter.Decrypt(*user.TwoFactorSecret)
+	if err != nil {
+		return false, err
+	}
+
+	step, ok := totp.Match(secret, strings.TrimSpace(code), time.Now(), codeSkew)
+	if !ok {
+		return false, nil
+	}
+	return s.userRepo.UseTwoFactorStep(ctx, user.ID, int64(step))
+}
+
+// UseRecoveryCode consumes the code in a single conditional update, so two
+// concurrent sign-ins cannot both spend it.
+func (s *service) UseRecoveryCode(ctx context.Context, user *domain.User, code string) (bool, error) {
+	hashed := hashRecoveryCode(code)
+	used, err := s.userRepo.UseTwoFactorRecoveryCode(ctx, user.ID, hashed)
+	if err != nil || !used {
+		return false, err
+	}
+	user.TwoFactorRecoveryCodes = slices.DeleteFunc(slices.Clone(user.TwoFactorRecoveryCodes), func(stored string) bool {
+		return stored == hashed
+	})
+	return true, nil
+}
+
+// twoFactorChallenge is encrypted with the application key so the client can
+// neither read nor forge it.
+type twoFactorChallenge struct {
+	UserID    int64 `json:"uid"`
+	ExpiresAt int64 `json:"exp"`
+}
+
+func (s *service) CreateChallenge(ctx context.Context, user *domain.User) (string, error) {
+	payload, err := json.Marshal(twoFactorChallenge{
+		UserID:    user.ID,
+		ExpiresAt: time.Now().Add(s.cfg.Auth.TwoFactorChallengeExpiration).Unix(),
+	})
+	if err != nil {
+		return "", err
+	}
+	return s.encrypter.Encrypt(string(payload))
+}
+
+func (s *service) ParseChallenge(ctx context.Context, token string) (int64, error) {
+	payload, err := s.encrypter.Decrypt(token)
+	if err != nil {
+		return 0, errdefs.ErrTokenInvalid(i18n.T(ctx, "two_factor.challenge"))
+	}
+	var challenge twoFactorChallenge
+	if err := json.Unmarshal([]byte(payload), &challenge); err != nil {
+		return 0, errdefs.ErrTokenInvalid(i18n.T(ctx, "two_factor.challenge"))
+	}
+	if time.Now().Unix() > challenge.ExpiresAt {
+		return 0, errdefs.ErrTokenInvalid(i18n.T(ctx, "two_factor.challenge"))
+	}
+	counter, err := s.throttleStore.Get(ctx, challengeKey(token))
+	if err != nil && !errors.Is(err, domain.ErrResourceNotFound) {
+		return 0, err
+	}
+	if counter != nil && counter.BlockedFor(time.Now()) > 0 {
+		return 0, errdefs.ErrTokenInvalid(i18n.T(ctx, "two_factor.challenge"))
+	}
+	return challenge.UserID, nil
+}
+
+func (s *service) FailChallenge(ctx context.Context, token string) error {
+	counter, err := s.throttleStore.Hit(ctx, challengeKey(token), s.cfg.Auth.TwoFactorChallengeExpiration)
+	if err != nil {
+		return err
+	}
+	if counter.Hits < maxChallengeFailures {
+		return nil
+	}
+	return s.ConsumeChallenge(ctx, token)
+}
+
+func (s *service) ConsumeChallenge(ctx context.Context, token string) error {
+	return s.throttleStore.Block(ctx, challengeKey(token), time.Now().Add(s.cfg.Auth.TwoFactorChallengeExpiration))
+}
+
+// challengeKey identifies a challenge in the throttle store. Every challenge
+// token is unique, as encrypting uses a random nonce.
+func challengeKey(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return "two_factor:challenge:" + hex.EncodeToString(sum[:])
+}
+
+func (s *service) findWithPassword(ctx context.Context, userID int64, password string) (*domain.User, error) {
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	match, err := s.passwordHasher.Verify(password, user.Password)
+	if err != nil {
+		return nil, err
+	}
+	if !match {
+		return nil, validator.NewError("password", i18n.T(ctx, "auth.password"))
+	}
+
+	return user, nil
+}
+
+// generateRecoveryCodes returns the plain codes shown once to the user and the hashes to store.
+func generateRecoveryCodes() ([]string, []string, error) {
+	codes := make([]string, recoveryCodeCount)
+	hashed := make([]string, recoveryCodeCount)
+	for i := range codes {
+		part1, err := randomString(5)
+		if err != nil {
+			return nil, nil, err
+		}
+		part2, err := randomString(5)
+		if err != nil {
+			return nil, nil, err
+		}
+		codes[i] = part1 + "-" + part2
+		hashed[i] = hashRecoveryCode(codes[i])
+	}
+
+	return codes, hashed, nil
+}
+
+func hashRecoveryCode(code string) string {
+	sum := sha256.Sum256([]byte(strings.ToLower(strings.TrimSpace(code))))
+	return hex.EncodeToString(sum[:])
+}
+
+func randomString(length int) (string, error) {
+	const charset = "abcdefghijklmnopqrstuvwxyz0123456789"
+	var max = big.NewInt(int64(len(charset)))
+
+	b := make([]byte, length)
+	for i := range b {
+		randomIndex, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			return "", err
+		}
+		b[i] = charset[randomIndex.Int64()]
+	}
+	return string(b), nil
+}
@@ -0,0 +1,18 @@
+package twofactor_test
+
+import (
+	"testing"
+
+	"github.com/akfaiz/go-vue-starter-kit/internal/lang"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestTwoFactorService(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Two-Factor Service Suite")
+}
+
+var _ = BeforeSuite(func() {
+	lang.Init()
+})
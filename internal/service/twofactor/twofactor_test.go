@@ -0,0 +1,273 @@
+package twofactor_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/akfaiz/go-vue-starter-kit/internal/config"
+	"github.com/akfaiz/go-vue-starter-kit/internal/domain"
+	"github.com/akfaiz/go-vue-starter-kit/internal/errdefs"
+	"github.com/akfaiz/go-vue-starter-kit/internal/hash/aesgcm"
+	"github.com/akfaiz/go-vue-starter-kit/internal/mocks"
+	"github.com/akfaiz/go-vue-starter-kit/internal/repository/throttle"
+	"github.com/akfaiz/go-vue-starter-kit/internal/service/twofactor"
+	"github.com/akfaiz/go-vue-starter-kit/internal/validator"
+	"github.com/akfaiz/go-vue-starter-kit/pkg/totp"
+	"github.com/invopop/ctxi18n"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+)
+
+func sha256Hex(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:])
+}
+
+var _ = Describe("Two-Factor Service", Label("unit", "usecase"), func() {
+	var (
+		userRepoMock *mocks.MockUserRepository
+		hasherMock   *mocks.MockPasswordHasher
+		encrypter    domain.Encrypter
+		svc          domain.TwoFactorService
+
+		ctx  context.Context
+		user *domain.User
+	)
+	BeforeEach(func() {
+		ctrl := gomock.NewController(GinkgoT())
+		userRepoMock = mocks.NewMockUserRepository(ctrl)
+		hasherMock = mocks.NewMockPasswordHasher(ctrl)
+
+		var err error
+		encrypter, err = aesgcm.NewEncrypter(config.App{Key: "test-app-key"})
+		Expect(err).NotTo(HaveOccurred())
+		cfg := config.Config{}
+		cfg.App.Name = "Starter Kit"
+		cfg.Auth.TwoFactorChallengeExpiration = 5 * time.Minute
+		svc = twofactor.NewService(cfg, userRepoMock, hasherMock, encrypter, throttle.NewMemoryStore())
+
+		ctx = context.Background()
+		ctx, _ = ctxi18n.WithLocale(ctx, "en")
+		user = &domain.User{ID: 1, Email: "john.doe@example.com", Password: "hashed"}
+
+		DeferCleanup(func() {
+			ctrl.Finish()
+		})
+	})
+
+	withSecret := func(secret string, confirmed bool) {
+		encrypted, err := encrypter.Encrypt(secret)
+		Expect(err).NotTo(HaveOccurred())
+		user.TwoFactorSecret = &encrypted
+		if confirmed {
+			now := time.Now()
+			user.TwoFactorConfirmedAt = &now
+		}
+	}
+
+	Describe("Enable", func() {
+		It("should store an encrypted secret and return the otpauth URI", func() {
+			var update *domain.UserUpdate
+			userRepoMock.EXPECT().FindByID(gomock.Any(), int64(1)).Return(user, nil)
+			userRepoMock.EXPECT().Update(gomock.Any(), int64(1), gomock.Any()).
+				DoAndReturn(func(_ context.Context, _ int64, u *domain.UserUpdate) error {
+					update = u
+					return nil
+				})
+
+			setup, err := svc.Enable(ctx, 1)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(setup.URI).To(HavePrefix("otpauth://totp/"))
+			Expect(setup.URI).To(ContainSubstring("secret=" + setup.Secret))
+
+			stored := update.TwoFactorSecret.MustGet()
+			Expect(stored).NotTo(Equal(setup.Secret))
+			decrypted, err := encrypter.Decrypt(stored)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(decrypted).To(Equal(setup.Secret))
+			Expect(update.TwoFactorConfirmedAt.IsNull()).To(BeTrue())
+		})
+		It("should return conflict when already enabled", func() {
+			withSecret("JBSWY3DPEHPK3PXP", true)
+			userRepoMock.EXPECT().FindByID(gomock.Any(), int64(1)).Return(user, nil)
+
+			_, err := svc.Enable(ctx, 1)
+			var appErr *errdefs.AppError
+			Expect(errors.As(err, &appErr)).To(BeTrue())
+			Expect(appErr.Status).To(Equal(409))
+		})
+	})
+
+	Describe("Confirm", func() {
+		It("should activate two-factor and return hashed recovery codes", func() {
+			withSecret("JBSWY3DPEHPK3PXP", false)
+			code, err := totp.GenerateCode("JBSWY3DPEHPK3PXP", time.Now())
+			Expect(err).NotTo(HaveOccurred())
+
+			var update *domain.UserUpdate
+			userRepoMock.EXPECT().FindByID(gomock.Any(), int64(1)).Return(user, nil)
+			userRepoMock.EXPECT().UseTwoFactorStep(gomock.Any(), int64(1), gomock.Any()).Return(true, nil)
+			userRepoMock.EXPECT().Update(gomock.Any(), int64(1), gomock.Any()).
+				DoAndReturn(func(_ context.Context, _ int64, u *domain.UserUpdate) error {
+					update = u
+					return nil
+				})
+
+			codes, err := svc.Confirm(ctx, 1, code)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(codes).To(HaveLen(8))
+			Expect(codes[0]).To(MatchRegexp(`^[a-z0-9]{5}-[a-z0-9]{5}$`))
+			Expect(update.TwoFactorConfirmedAt.IsValue()).To(BeTrue())
+			hashed := update.TwoFactorRecoveryCodes.MustGet()
+			Expect(hashed).To(HaveLen(8))
+			Expect(hashed[0]).To(Equal(sha256Hex(codes[0])))
+		})
+		It("should return validation error when the code is wrong", func() {
+			withSecret("JBSWY3DPEHPK3PXP", false)
+			userRepoMock.EXPECT().FindByID(gomock.Any(), int64(1)).Return(user, nil)
+
+			_, err := svc.Confirm(ctx, 1, "000000")
+			var vErr *validator.ValidationError
+			Expect(errors.As(err, &vErr)).To(BeTrue())
+			Expect(vErr.First().Field).To(Equal("code"))
+		})
+		It("should return bad request when enrollment has not started", func() {
+			userRepoMock.EXPECT().FindByID(gomock.Any(), int64(1)).Return(user, nil)
+
+			_, err := svc.Confirm(ctx, 1, "123456")
+			var appErr *errdefs.AppError
+			Expect(errors.As(err, &appErr)).To(BeTrue())
+			Expect(appErr.Status).To(Equal(400))
+		})
+	})
+
+	Describe("Disable", func() {
+		It("should clear the secret after verifying the password", func() {
+			withSecret("JBSWY3DPEHPK3PXP", true)
+			userRepoMock.EXPECT().FindByID(gomock.Any(), int64(1)).Return(user, nil)
+			hasherMock.EXPECT().Verify("password123", "hashed").Return(true, nil)
+			userRepoMock.EXPECT().Update(gomock.Any(), int64(1), gomock.Any()).
+				DoAndReturn(func(_ context.Context, _ int64, u *domain.UserUpdate) error {
+					Expect(u.TwoFactorSecret.IsNull()).To(BeTrue())
+					Expect(u.TwoFactorRecoveryCodes.IsNull()).To(BeTrue())
+					Expect(u.TwoFactorConfirmedAt.IsNull()).To(BeTrue())
+					return nil
+				})
+
+			Expect(svc.Disable(ctx, 1, "password123")).To(Succeed())
+		})
+		It("should return validation error when the password is wrong", func() {
+			userRepoMock.EXPECT().FindByID(gomock.Any(), int64(1)).Return(user, nil)
+			hasherMock.EXPECT().Verify("wrong", "hashed").Return(false, nil)
+
+			err := svc.Disable(ctx, 1, "wrong")
+			var vErr *validator.ValidationError
+			Expect(errors.As(err, &vErr)).To(BeTrue())
+			Expect(vErr.First().Field).To(Equal("password"))
+		})
+	})
+
+	Describe("UseRecoveryCode", func() {
+		It("should consume a matching code only once", func() {
+			user.TwoFactorRecoveryCodes = []string{sha256Hex("aaaaa-11111"), sha256Hex("bbbbb-22222")}
+			// The repository removes the code only while it is still stored.
+			gomock.InOrder(
+				userRepoMock.EXPECT().UseTwoFactorRecoveryCode(gomock.Any(), int64(1), sha256Hex("aaaaa-11111")).Return(true, nil),
+				userRepoMock.EXPECT().UseTwoFactorRecoveryCode(gomock.Any(), int64(1), sha256Hex("aaaaa-11111")).Return(false, nil),
+			)
+
+			valid, err := svc.UseRecoveryCode(ctx, user, strings.ToUpper("aaaaa-11111"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(valid).To(BeTrue())
+			Expect(user.TwoFactorRecoveryCodes).To(Equal([]string{sha256Hex("bbbbb-22222")}))
+
+			valid, err = svc.UseRecoveryCode(ctx, user, "aaaaa-11111")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(valid).To(BeFalse())
+		})
+	})
+
+	Describe("Verify", func() {
+		It("should accept the current code and reject others", func() {
+			withSecret("JBSWY3DPEHPK3PXP", true)
+			code, err := totp.GenerateCode("JBSWY3DPEHPK3PXP", time.Now())
+			Expect(err).NotTo(HaveOccurred())
+
+			step := time.Now().Unix() / int64(totp.Period.Seconds())
+			userRepoMock.EXPECT().UseTwoFactorStep(gomock.Any(), int64(1), step).Return(true, nil)
+
+			Expect(svc.Verify(ctx, user, code)).To(BeTrue())
+			Expect(svc.Verify(ctx, user, "abcdef")).To(BeFalse())
+		})
+		It("should reject a code whose time step was already used", func() {
+			withSecret("JBSWY3DPEHPK3PXP", true)
+			code, err := totp.GenerateCode("JBSWY3DPEHPK3PXP", time.Now())
+			Expect(err).NotTo(HaveOccurred())
+			userRepoMock.EXPECT().UseTwoFactorStep(gomock.Any(), int64(1), gomock.Any()).Return(false, nil)
+
+			Expect(svc.Verify(ctx, user, code)).To(BeFalse())
+		})
+	})
+
+	Describe("Challenge", func() {
+		It("should round-trip the user ID", func() {
+			token, err := svc.CreateChallenge(ctx, user)
+			Expect(err).NotTo(HaveOccurred())
+
+			userID, err := svc.ParseChallenge(ctx, token)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(userID).To(Equal(user.ID))
+		})
+		It("should reject a tampered token", func() {
+			token, err := svc.CreateChallenge(ctx, user)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = svc.ParseChallenge(ctx, token+"x")
+			var appErr *errdefs.AppError
+			Expect(errors.As(err, &appErr)).To(BeTrue())
+			Expect(appErr.Status).To(Equal(401))
+		})
+		It("should reject a token that already signed the user in", func() {
+			token, err := svc.CreateChallenge(ctx, user)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(svc.ConsumeChallenge(ctx, token)).To(Succeed())
+
+			_, err = svc.ParseChallenge(ctx, token)
+			var appErr *errdefs.AppError
+			Expect(errors.As(err, &appErr)).To(BeTrue())
+			Expect(appErr.Status).To(Equal(401))
+		})
+		It("should reject a token after too many wrong codes", func() {
+			token, err := svc.CreateChallenge(ctx, user)
+			Expect(err).NotTo(HaveOccurred())
+			for range 4 {
+				Expect(svc.FailChallenge(ctx, token)).To(Succeed())
+			}
+			_, err = svc.ParseChallenge(ctx, token)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(svc.FailChallenge(ctx, token)).To(Succeed())
+			_, err = svc.ParseChallenge(ctx, token)
+			var appErr *errdefs.AppError
+			Expect(errors.As(err, &appErr)).To(BeTrue())
+			Expect(appErr.Status).To(Equal(401))
+		})
+		It("should reject an expired token", func() {
+			payload, err := json.Marshal(map[string]int64{"uid": 1, "exp": time.Now().Add(-time.Minute).Unix()})
+			Expect(err).NotTo(HaveOccurred())
+			expired, err := encrypter.Encrypt(string(payload))
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = svc.ParseChallenge(ctx, expired)
+			var appErr *errdefs.AppError
+			Expect(errors.As(err, &appErr)).To(BeTrue())
+			Expect(appErr.Status).To(Equal(401))
+		})
+	})
+})
@@ -2,27 +2,56 @@ package user
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/base64"
 	"errors"
+	"net/url"
+	"strconv"
 	"time"
 
 	"github.com/aarondl/opt/omit"
 	"github.com/aarondl/opt/omitnull"
+	"github.com/akfaiz/go-mailgen"
+	"github.com/akfaiz/go-vue-starter-kit/internal/config"
 	"github.com/akfaiz/go-vue-starter-kit/internal/domain"
+	"github.com/akfaiz/go-vue-starter-kit/internal/errdefs"
 	"github.com/akfaiz/go-vue-starter-kit/internal/validator"
+	"github.com/invopop/ctxi18n/i18n"
 )
 
 type service struct {
-	userRepo       domain.UserRepository
-	passwordHasher domain.PasswordHasher
+	cfg             config.Config
+	userRepo        domain.UserRepository
+	userTokenRepo   domain.UserTokenRepository
+	passwordHasher  domain.PasswordHasher
+	passwordChanger domain.PasswordChanger
+	sessionService  domain.SessionService
+	loginThrottler  domain.LoginThrottler
+	mailer          domain.Mailer
+	auditLogger     domain.AuditLogger
 }
 
 func NewService(
+	cfg config.Config,
 	userRepo domain.UserRepository,
+	userTokenRepo domain.UserTokenRepository,
 	passwordHasher domain.PasswordHasher,
+	passwordChanger domain.PasswordChanger,
+	sessionService domain.SessionService,
+	loginThrottler domain.LoginThrottler,
+	mailer domain.Mailer,
+	auditLogger domain.AuditLogger,
 ) domain.UserService {
 	return &service{
-		userRepo:       userRepo,
-		passwordHasher: passwordHasher,
+		cfg:             cfg,
+		userRepo:        userRepo,
+		userTokenRepo:   userTokenRepo,
+		passwordHasher:  passwordHasher,
+		passwordChanger: passwordChanger,
+		sessionService:  sessionService,
+		loginThrottler:  loginThrottler,
+		mailer:          mailer,
+		auditLogger:     auditLogger,
 	}
 }
 
@@ -36,49 +65,122 @@ func (s *service) UpdateProfile(ctx context.Context, id int64, user *domain.User
 		return err
 	}
 
-	update := &domain.UserUpdate{}
-	if oldUser.Name != user.Name {
-		update.Name = omit.From(user.Name)
+	if oldUser.Name == user.Name {
+		return nil
 	}
-	if oldUser.Email != user.Email {
-		var t *time.Time
-		update.EmailVerifiedAt = omitnull.FromPtr(t)
-		update.Email = omit.From(user.Email)
+	return s.userRepo.Update(ctx, id, &domain.UserUpdate{
+		Name: omit.From(user.Name),
+	})
+}
+
+func (s *service) ChangeEmail(ctx context.Context, id int64, newEmail string) error {
+	user, err := s.userRepo.FindByID(ctx, id)
+	if err != nil {
+		return err
 	}
-	if update.IsEmpty() {
+	if user.Email == newEmail {
 		return nil
 	}
+	return s.requestEmailChange(ctx, user, newEmail)
+}
 
-	if err := s.userRepo.Update(ctx, id, update); err != nil {
-		if errors.Is(err, domain.ErrEmailAlreadyExists) {
-			return validator.NewError("email", "Email already exists")
+func (s *service) PendingEmail(ctx context.Context, id int64) (string, error) {
+	token, err := s.userTokenRepo.FindOne(ctx, id, domain.TokenTypeEmailChange)
+	if err != nil {
+		if errors.Is(err, domain.ErrResourceNotFound) {
+			return "", nil
 		}
+		return "", err
+	}
+	if time.Now().After(token.ExpiresAt) {
+		return "", nil
+	}
+	return token.Email, nil
+}
+
+func (s *service) ConfirmEmailChange(ctx context.Context, id int64, token string) error {
+	userToken, err := s.findToken(ctx, id, domain.TokenTypeEmailChange, token)
+	if err != nil {
+		return err
+	}
+	user, err := s.userRepo.FindByID(ctx, id)
+	if err != nil {
 		return err
 	}
 
+	// Following the link proves the user owns the new inbox.
+	if err := s.userRepo.Update(ctx, id, &domain.UserUpdate{
+		Email:           omit.From(userToken.Email),
+		EmailVerifiedAt: omitnull.From(time.Now()),
+	}); err != nil {
+		if errors.Is(err, domain.ErrEmailAlreadyExists) {
+			return errdefs.ErrConflict(i18n.T(ctx, "email_change.taken"))
+		}
+		return err
+	}
+	// The revert token is kept so the previous address can still undo the change.
+	_ = s.userTokenRepo.Delete(ctx, id, domain.TokenTypeEmailChange)
+	s.auditLogger.Log(ctx, domain.AuditEmailChanged, id, map[string]any{
+		"old_email": user.Email,
+		"new_email": userToken.Email,
+	})
 	return nil
 }
 
-func (s *service) ChangePassword(ctx context.Context, id int64, currentPassword, newPassword string) error {
-	user, err := s.userRepo.FindByID(ctx, id)
+func (s *service) RevertEmailChange(ctx context.Context, id int64, token string) error {
+	userToken, err := s.findToken(ctx, id, domain.TokenTypeEmailRevert, token)
 	if err != nil {
 		return err
 	}
-	match, err := s.passwordHasher.Verify(currentPassword, user.Password)
+	user, err := s.userRepo.FindByID(ctx, id)
 	if err != nil {
 		return err
 	}
-	if !match {
-		return validator.NewError("current_password", "Current password is incorrect")
+
+	if user.Email != userToken.Email {
+		if err := s.userRepo.Update(ctx, id, &domain.UserUpdate{
+			Email:           omit.From(userToken.Email),
+			EmailVerifiedAt: omitnull.From(time.Now()),
+		}); err != nil {
+			if errors.Is(err, domain.ErrEmailAlreadyExists) {
+				return errdefs.ErrConflict(i18n.T(ctx, "email_change.taken"))
+			}
+			return err
+		}
+	}
+	_ = s.userTokenRepo.Delete(ctx, id, domain.TokenTypeEmailChange)
+	_ = s.userTokenRepo.Delete(ctx, id, domain.TokenTypeEmailRevert)
+
+	// Whoever requested the change may still be signed in.
+	if err := s.sessionService.RevokeAll(ctx, id, ""); err != nil {
+		return err
 	}
-	hashedPassword, err := s.passwordHasher.Hash(newPassword)
+	s.auditLogger.Log(ctx, domain.AuditEmailChangeReverted, id, map[string]any{
+		"old_email": user.Email,
+		"new_email": userToken.Email,
+	})
+	return nil
+}
+
+func (s *service) ChangePassword(ctx context.Context, id int64, currentPassword, newPassword string) error {
+	user, err := s.userRepo.FindByID(ctx, id)
 	if err != nil {
 		return err
 	}
-	update := &domain.UserUpdate{
-		Password: omit.From(hashedPassword),
+	if err := s.verifyPassword(ctx, user, currentPassword, "current_password", "Current password is incorrect"); err != nil {
+		return err
+	}
+	if err := s.passwordChanger.Change(ctx, "new_password", user, newPassword); err != nil {
+		return err
+	}
+	s.auditLogger.Log(ctx, domain.AuditPasswordChanged, id, nil)
+
+	// Keep the session that changed the password, sign out everywhere else.
+	var currentSessionID string
+	if claims := domain.ClaimsFromContext(ctx); claims != nil {
+		currentSessionID = claims.SessionID
 	}
-	return s.userRepo.Update(ctx, id, update)
+	return s.sessionService.RevokeAll(ctx, id, currentSessionID)
 }
 
 func (s *service) Delete(ctx context.Context, id int64, password string) error {
@@ -86,12 +188,159 @@ func (s *service) Delete(ctx context.Context, id int64, password string) error {
 	if err != nil {
 		return err
 	}
+	if err := s.verifyPassword(ctx, user, password, "password", "Password is incorrect"); err != nil {
+		return err
+	}
+	// Revoke first so the access tokens still in circulation stop working too.
+	if err := s.sessionService.RevokeAll(ctx, id, ""); err != nil {
+		return err
+	}
+	if err := s.userRepo.Delete(ctx, id); err != nil {
+		return err
+	}
+	s.auditLogger.Log(ctx, domain.AuditAccountDeleted, id, map[string]any{"email": user.Email})
+	return nil
+}
+
+// verifyPassword checks the password of a signed-in user. It shares the login
+// lockout, so a hijacked session cannot be used to guess the password.
+func (s *service) verifyPassword(ctx context.Context, user *domain.User, password, field, message string) error {
+	if err := s.loginThrottler.Check(ctx, user.Email); err != nil {
+		return err
+	}
 	match, err := s.passwordHasher.Verify(password, user.Password)
 	if err != nil {
 		return err
 	}
 	if !match {
-		return validator.NewError("password", "Password is incorrect")
+		if err := s.loginThrottler.Fail(ctx, user.Email); err != nil {
+			return err
+		}
+		return validator.NewError(field, message)
+	}
+	return s.loginThrottler.Succeed(ctx, user.Email)
+}
+
+// requestEmailChange leaves the current address in place until the new one is
+// confirmed, and gives the current address a way to undo the change.
+func (s *service) requestEmailChange(ctx context.Context, user *domain.User, newEmail string) error {
+	_, err := s.userRepo.FindByEmail(ctx, newEmail)
+	if err == nil {
+		return validator.NewError("email", "Email already exists")
+	}
+	if !errors.Is(err, domain.ErrResourceNotFound) {
+		return err
+	}
+
+	confirmToken, err := s.createToken(ctx, user.ID, domain.TokenTypeEmailChange, newEmail, s.cfg.Auth.EmailChangeExpiration)
+	if err != nil {
+		return err
+	}
+	revertToken, err := s.revertToken(ctx, user)
+	if err != nil {
+		return err
+	}
+
+	if err := s.mailer.Send(ctx, s.buildEmailConfirmChange(user, newEmail, confirmToken)); err != nil {
+		return err
+	}
+	if err := s.mailer.Send(ctx, s.buildEmailChangeRequested(user, newEmail, revertToken)); err != nil {
+		return err
+	}
+	s.auditLogger.Log(ctx, domain.AuditEmailChangeRequested, user.ID, map[string]any{
+		"old_email": user.Email,
+		"new_email": newEmail,
+	})
+	return nil
+}
+
+// revertToken issues the token that undoes email changes, bound to the current
+// address. While one is unexpired it is kept and "" returned instead: after a
+// confirmed change, replacing it would bind it to the new address and take the
+// undo link away from the owner of the original one.
+func (s *service) revertToken(ctx context.Context, user *domain.User) (string, error) {
+	existing, err := s.userTokenRepo.FindOne(ctx, user.ID, domain.TokenTypeEmailRevert)
+	if err == nil && time.Now().Before(existing.ExpiresAt) {
+		return "", nil
+	}
+	if err != nil && !errors.Is(err, domain.ErrResourceNotFound) {
+		return "", err
+	}
+	return s.createToken(ctx, user.ID, domain.TokenTypeEmailRevert, user.Email, s.cfg.Auth.EmailRevertExpiration)
+}
+
+// createToken stores a hashed token carrying email and returns the plain token.
+func (s *service) createToken(ctx context.Context, userID int64, tokenType domain.TokenType, email string, ttl time.Duration) (string, error) {
+	token := randomToken()
+	hashedToken, err := s.passwordHasher.Hash(token)
+	if err != nil {
+		return "", err
+	}
+	if err := s.userTokenRepo.Create(ctx, &domain.UserToken{
+		UserID:    userID,
+		Token:     hashedToken,
+		TokenType: tokenType,
+		Email:     email,
+		ExpiresAt: time.Now().Add(ttl),
+	}); err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+func (s *service) findToken(ctx context.Context, userID int64, tokenType domain.TokenType, token string) (*domain.UserToken, error) {
+	userToken, err := s.userTokenRepo.FindOne(ctx, userID, tokenType)
+	if err != nil {
+		if errors.Is(err, domain.ErrResourceNotFound) {
+			return nil, errdefs.ErrBadRequest(i18n.T(ctx, "email_change.token"))
+		}
+		return nil, err
+	}
+	if time.Now().After(userToken.ExpiresAt) {
+		return nil, errdefs.ErrBadRequest(i18n.T(ctx, "email_change.token"))
+	}
+	match, err := s.passwordHasher.Verify(token, userToken.Token)
+	if err != nil {
+		return nil, err
+	}
+	if !match {
+		return nil, errdefs.ErrBadRequest(i18n.T(ctx, "email_change.token"))
+	}
+	return userToken, nil
+}
+
+func (s *service) buildEmailConfirmChange(user *domain.User, newEmail, token string) *mailgen.Builder {
+	link := s.cfg.App.FrontendBaseURL + "/confirm-email?token=" + url.QueryEscape(token) + "&user_id=" + strconv.FormatInt(user.ID, 10)
+	return mailgen.New().
+		To(newEmail).
+		Subject("Confirm Your New Email Address").
+		Name(user.Name).
+		Line("You asked to use this address for your account. Please click the button below to confirm it.").
+		Action("Confirm Email Address", link).
+		Linef("This link will expire in %d minutes.", int(s.cfg.Auth.EmailChangeExpiration.Minutes())).
+		Line("If you did not request this change, no further action is required.")
+}
+
+// buildEmailChangeRequested links to the revert page when a revert token was
+// issued. Otherwise the link from the earlier notice is still the one to use.
+func (s *service) buildEmailChangeRequested(user *domain.User, newEmail, token string) *mailgen.Builder {
+	msg := mailgen.New().
+		To(user.Email).
+		Subject("Email Address Change Requested").
+		Name(user.Name).
+		Linef("A request was made to change the email address of your account to %s. The change takes effect once the new address is confirmed.", newEmail)
+	if token == "" {
+		return msg.Line("If you did not make this request, use the revert link from the earlier email about changing your address, then reset your password.")
 	}
-	return s.userRepo.Delete(ctx, id)
+	link := s.cfg.App.FrontendBaseURL + "/revert-email?token=" + url.QueryEscape(token) + "&user_id=" + strconv.FormatInt(user.ID, 10)
+	return msg.
+		Line("If you did not make this request, click the button below to keep this address and sign out all devices, then reset your password.").
+		Action("Revert Email Change", link).
+		Linef("This link will expire in %d days.", int(s.cfg.Auth.EmailRevertExpiration.Hours()/24))
+}
+
+func randomToken() string {
+	b := make([]byte, 32)
+	_, _ = rand.Read(b)
+	return base64.RawURLEncoding.EncodeToString(b)
 }
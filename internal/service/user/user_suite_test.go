@@ -3,6 +3,7 @@ package user_test
 import (
 	"testing"
 
+	"github.com/akfaiz/go-vue-starter-kit/internal/lang"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 )
@@ -11,3 +12,7 @@ func TestUserService(t *testing.T) {
 	RegisterFailHandler(Fail)
 	RunSpecs(t, "User Service Suite")
 }
+
+var _ = BeforeSuite(func() {
+	lang.Init()
+})
@@ -6,11 +6,14 @@ import (
 	"time"
 
 	"github.com/aarondl/opt/omit"
-	"github.com/aarondl/opt/omitnull"
+	"github.com/akfaiz/go-vue-starter-kit/internal/config"
 	"github.com/akfaiz/go-vue-starter-kit/internal/domain"
+	"github.com/akfaiz/go-vue-starter-kit/internal/errdefs"
 	"github.com/akfaiz/go-vue-starter-kit/internal/mocks"
+	"github.com/akfaiz/go-vue-starter-kit/internal/service/password"
 	"github.com/akfaiz/go-vue-starter-kit/internal/service/user"
 	"github.com/akfaiz/go-vue-starter-kit/internal/validator"
+	"github.com/invopop/ctxi18n"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"go.uber.org/mock/gomock"
@@ -19,7 +22,14 @@ import (
 var _ = Describe("User Service", Label("unit", "usecase"), func() {
 	var (
 		userRepoMock       *mocks.MockUserRepository
+		userTokenRepoMock  *mocks.MockUserTokenRepository
 		passwordHasherMock *mocks.MockPasswordHasher
+		passwordPolicyMock *mocks.MockPasswordPolicy
+		historyMock        *mocks.MockPasswordHistory
+		sessionSvcMock     *mocks.MockSessionService
+		throttlerMock      *mocks.MockLoginThrottler
+		mailerMock         *mocks.MockMailer
+		auditLoggerMock    *mocks.MockAuditLogger
 		svc                domain.UserService
 
 		ctx    context.Context
@@ -28,10 +38,20 @@ var _ = Describe("User Service", Label("unit", "usecase"), func() {
 	BeforeEach(func() {
 		ctrl := gomock.NewController(GinkgoT())
 		userRepoMock = mocks.NewMockUserRepository(ctrl)
+		userTokenRepoMock = mocks.NewMockUserTokenRepository(ctrl)
 		passwordHasherMock = mocks.NewMockPasswordHasher(ctrl)
-		svc = user.NewService(userRepoMock, passwordHasherMock)
+		passwordPolicyMock = mocks.NewMockPasswordPolicy(ctrl)
+		historyMock = mocks.NewMockPasswordHistory(ctrl)
+		sessionSvcMock = mocks.NewMockSessionService(ctrl)
+		throttlerMock = mocks.NewMockLoginThrottler(ctrl)
+		mailerMock = mocks.NewMockMailer(ctrl)
+		auditLoggerMock = mocks.NewMockAuditLogger(ctrl)
+		auditLoggerMock.EXPECT().Log(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+		cfg := config.Config{Auth: config.Auth{EmailChangeExpiration: time.Hour, EmailRevertExpiration: 7 * 24 * time.Hour}}
+		svc = user.NewService(cfg, userRepoMock, userTokenRepoMock, passwordHasherMock, password.NewChanger(userRepoMock, passwordHasherMock, passwordPolicyMock, historyMock), sessionSvcMock, throttlerMock, mailerMock, auditLoggerMock)
 
 		ctx = context.Background()
+		ctx, _ = ctxi18n.WithLocale(ctx, "en")
 
 		DeferCleanup(func() {
 			ctrl.Finish()
@@ -99,44 +119,120 @@ var _ = Describe("User Service", Label("unit", "usecase"), func() {
 				Expect(actErr).To(Equal(domain.ErrResourceNotFound))
 			})
 		})
-		When("name and email change successfully", func() {
+		When("the name changes", func() {
 			BeforeEach(func() {
 				userRepoMock.EXPECT().FindByID(ctx, int64(1)).Return(&domain.User{
 					ID:    1,
 					Name:  "John Doe",
 					Email: "john.doe@example.com",
 				}, nil)
-				var t *time.Time
 				userRepoMock.EXPECT().Update(ctx, int64(1), &domain.UserUpdate{
-					Name:            omit.From("Jane Doe"),
-					Email:           omit.From("jane.doe@example.com"),
-					EmailVerifiedAt: omitnull.FromPtr(t),
+					Name: omit.From("Jane Doe"),
 				}).Return(nil)
 			})
-			It("should update the profile successfully", func() {
+			It("should update the name without starting an email change", func() {
+				Expect(actErr).To(BeNil())
+			})
+		})
+		When("the name did not change", func() {
+			BeforeEach(func() {
+				userRepoMock.EXPECT().FindByID(ctx, int64(1)).Return(&domain.User{
+					ID:    1,
+					Name:  "Jane Doe",
+					Email: "jane.doe@example.com",
+				}, nil)
+			})
+			It("should do nothing and return nil", func() {
 				Expect(actErr).To(BeNil())
 			})
 		})
-		When("name changed but email remains the same", func() {
+		When("there is an error during update", func() {
 			BeforeEach(func() {
 				userRepoMock.EXPECT().FindByID(ctx, int64(1)).Return(&domain.User{
 					ID:    1,
 					Name:  "John Doe",
-					Email: inputUser.Email,
+					Email: "john.doe@example.com",
 				}, nil)
 				userRepoMock.EXPECT().Update(ctx, int64(1), &domain.UserUpdate{
 					Name: omit.From("Jane Doe"),
-				}).Return(nil)
+				}).Return(errors.New("db down"))
+			})
+			It("bubbles the error", func() {
+				Expect(actErr).To(HaveOccurred())
+			})
+		})
+	})
+
+	Describe("ChangeEmail", func() {
+		JustBeforeEach(func() {
+			actErr = svc.ChangeEmail(ctx, 1, "jane.doe@example.com")
+		})
+		When("user is not found", func() {
+			BeforeEach(func() {
+				userRepoMock.EXPECT().FindByID(ctx, int64(1)).Return(nil, domain.ErrResourceNotFound)
 			})
-			It("should update the name only", func() {
+			It("should return an error", func() {
+				Expect(actErr).To(Equal(domain.ErrResourceNotFound))
+			})
+		})
+		When("the email changes", func() {
+			BeforeEach(func() {
+				userRepoMock.EXPECT().FindByID(ctx, int64(1)).Return(&domain.User{
+					ID:    1,
+					Name:  "John Doe",
+					Email: "john.doe@example.com",
+				}, nil)
+				userRepoMock.EXPECT().FindByEmail(ctx, "jane.doe@example.com").Return(nil, domain.ErrResourceNotFound)
+				userTokenRepoMock.EXPECT().FindOne(ctx, int64(1), domain.TokenTypeEmailRevert).Return(nil, domain.ErrResourceNotFound)
+				passwordHasherMock.EXPECT().Hash(gomock.Any()).Return("hashed", nil).Times(2)
+				userTokenRepoMock.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, token *domain.UserToken) error {
+					Expect(token.TokenType).To(Equal(domain.TokenTypeEmailChange))
+					Expect(token.Email).To(Equal("jane.doe@example.com"))
+					Expect(token.ExpiresAt).To(BeTemporally("~", time.Now().Add(time.Hour), time.Second))
+					return nil
+				})
+				userTokenRepoMock.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, token *domain.UserToken) error {
+					Expect(token.TokenType).To(Equal(domain.TokenTypeEmailRevert))
+					Expect(token.Email).To(Equal("john.doe@example.com"))
+					return nil
+				})
+				mailerMock.EXPECT().Send(ctx, gomock.Any()).Return(nil).Times(2)
+			})
+			It("should keep the email pending and mail both addresses", func() {
 				Expect(actErr).To(BeNil())
 			})
 		})
-		When("neither name nor email changed", func() {
+		When("the email changes again after a confirmed change", func() {
+			BeforeEach(func() {
+				// john.doe@example.com was confirmed from original@example.com,
+				// whose owner still holds the revert link
+				userRepoMock.EXPECT().FindByID(ctx, int64(1)).Return(&domain.User{
+					ID:    1,
+					Name:  "John Doe",
+					Email: "john.doe@example.com",
+				}, nil)
+				userRepoMock.EXPECT().FindByEmail(ctx, "jane.doe@example.com").Return(nil, domain.ErrResourceNotFound)
+				userTokenRepoMock.EXPECT().FindOne(ctx, int64(1), domain.TokenTypeEmailRevert).Return(&domain.UserToken{
+					UserID:    1,
+					TokenType: domain.TokenTypeEmailRevert,
+					Email:     "original@example.com",
+					ExpiresAt: time.Now().Add(24 * time.Hour),
+				}, nil)
+				passwordHasherMock.EXPECT().Hash(gomock.Any()).Return("hashed", nil)
+				userTokenRepoMock.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, token *domain.UserToken) error {
+					Expect(token.TokenType).To(Equal(domain.TokenTypeEmailChange))
+					return nil
+				})
+				mailerMock.EXPECT().Send(ctx, gomock.Any()).Return(nil).Times(2)
+			})
+			It("should keep the revert token of the original address", func() {
+				Expect(actErr).To(BeNil())
+			})
+		})
+		When("the email did not change", func() {
 			BeforeEach(func() {
 				userRepoMock.EXPECT().FindByID(ctx, int64(1)).Return(&domain.User{
 					ID:    1,
-					Name:  "Jane Doe",
 					Email: "jane.doe@example.com",
 				}, nil)
 			})
@@ -151,12 +247,7 @@ var _ = Describe("User Service", Label("unit", "usecase"), func() {
 					Name:  "John Doe",
 					Email: "john.doe@example.com",
 				}, nil)
-				var t *time.Time
-				userRepoMock.EXPECT().Update(ctx, int64(1), &domain.UserUpdate{
-					Name:            omit.From("Jane Doe"),
-					Email:           omit.From("jane.doe@example.com"),
-					EmailVerifiedAt: omitnull.FromPtr(t),
-				}).Return(domain.ErrEmailAlreadyExists)
+				userRepoMock.EXPECT().FindByEmail(ctx, "jane.doe@example.com").Return(&domain.User{ID: 2}, nil)
 			})
 			It("should return a validation error", func() {
 				var vErr *validator.ValidationError
@@ -165,22 +256,115 @@ var _ = Describe("User Service", Label("unit", "usecase"), func() {
 				Expect(vErr.First().Message).To(Equal("Email already exists"))
 			})
 		})
-		When("there is an error during update", func() {
+	})
+
+	Describe("ConfirmEmailChange", func() {
+		var changeToken *domain.UserToken
+		BeforeEach(func() {
+			changeToken = &domain.UserToken{
+				UserID:    1,
+				Token:     "hashed",
+				TokenType: domain.TokenTypeEmailChange,
+				Email:     "jane.doe@example.com",
+				ExpiresAt: time.Now().Add(time.Hour),
+			}
+		})
+		JustBeforeEach(func() {
+			actErr = svc.ConfirmEmailChange(ctx, 1, "token")
+		})
+		When("the token is valid", func() {
 			BeforeEach(func() {
-				userRepoMock.EXPECT().FindByID(ctx, int64(1)).Return(&domain.User{
-					ID:    1,
-					Name:  "John Doe",
-					Email: "john.doe@example.com",
-				}, nil)
-				var t *time.Time
-				userRepoMock.EXPECT().Update(ctx, int64(1), &domain.UserUpdate{
-					Name:            omit.From("Jane Doe"),
-					Email:           omit.From("jane.doe@example.com"),
-					EmailVerifiedAt: omitnull.FromPtr(t),
-				}).Return(errors.New("db down"))
+				userTokenRepoMock.EXPECT().FindOne(ctx, int64(1), domain.TokenTypeEmailChange).Return(changeToken, nil)
+				passwordHasherMock.EXPECT().Verify("token", "hashed").Return(true, nil)
+				userRepoMock.EXPECT().FindByID(ctx, int64(1)).Return(&domain.User{ID: 1, Email: "john.doe@example.com"}, nil)
+				userRepoMock.EXPECT().Update(ctx, int64(1), gomock.Any()).DoAndReturn(func(_ context.Context, _ int64, update *domain.UserUpdate) error {
+					Expect(update.Email.MustGet()).To(Equal("jane.doe@example.com"))
+					Expect(update.EmailVerifiedAt.MustGet()).To(BeTemporally("~", time.Now(), time.Second))
+					return nil
+				})
+				userTokenRepoMock.EXPECT().Delete(ctx, int64(1), domain.TokenTypeEmailChange).Return(nil)
+			})
+			It("should switch to the new address", func() {
+				Expect(actErr).To(BeNil())
 			})
-			It("bubbles the error", func() {
-				Expect(actErr).To(HaveOccurred())
+		})
+		When("the token has expired", func() {
+			BeforeEach(func() {
+				changeToken.ExpiresAt = time.Now().Add(-time.Minute)
+				userTokenRepoMock.EXPECT().FindOne(ctx, int64(1), domain.TokenTypeEmailChange).Return(changeToken, nil)
+			})
+			It("should reject the token", func() {
+				var appErr *errdefs.AppError
+				Expect(errors.As(actErr, &appErr)).To(BeTrue())
+				Expect(appErr.Status).To(Equal(400))
+			})
+		})
+		When("the token does not match", func() {
+			BeforeEach(func() {
+				userTokenRepoMock.EXPECT().FindOne(ctx, int64(1), domain.TokenTypeEmailChange).Return(changeToken, nil)
+				passwordHasherMock.EXPECT().Verify("token", "hashed").Return(false, nil)
+			})
+			It("should reject the token", func() {
+				var appErr *errdefs.AppError
+				Expect(errors.As(actErr, &appErr)).To(BeTrue())
+				Expect(appErr.Status).To(Equal(400))
+			})
+		})
+		When("the address was taken in the meantime", func() {
+			BeforeEach(func() {
+				userTokenRepoMock.EXPECT().FindOne(ctx, int64(1), domain.TokenTypeEmailChange).Return(changeToken, nil)
+				passwordHasherMock.EXPECT().Verify("token", "hashed").Return(true, nil)
+				userRepoMock.EXPECT().FindByID(ctx, int64(1)).Return(&domain.User{ID: 1, Email: "john.doe@example.com"}, nil)
+				userRepoMock.EXPECT().Update(ctx, int64(1), gomock.Any()).Return(domain.ErrEmailAlreadyExists)
+			})
+			It("should return a conflict", func() {
+				var appErr *errdefs.AppError
+				Expect(errors.As(actErr, &appErr)).To(BeTrue())
+				Expect(appErr.Status).To(Equal(409))
+			})
+		})
+	})
+
+	Describe("RevertEmailChange", func() {
+		var revertToken *domain.UserToken
+		BeforeEach(func() {
+			revertToken = &domain.UserToken{
+				UserID:    1,
+				Token:     "hashed",
+				TokenType: domain.TokenTypeEmailRevert,
+				Email:     "john.doe@example.com",
+				ExpiresAt: time.Now().Add(time.Hour),
+			}
+			userTokenRepoMock.EXPECT().FindOne(ctx, int64(1), domain.TokenTypeEmailRevert).Return(revertToken, nil)
+			passwordHasherMock.EXPECT().Verify("token", "hashed").Return(true, nil)
+		})
+		JustBeforeEach(func() {
+			actErr = svc.RevertEmailChange(ctx, 1, "token")
+		})
+		When("the change was already confirmed", func() {
+			BeforeEach(func() {
+				userRepoMock.EXPECT().FindByID(ctx, int64(1)).Return(&domain.User{ID: 1, Email: "jane.doe@example.com"}, nil)
+				userRepoMock.EXPECT().Update(ctx, int64(1), gomock.Any()).DoAndReturn(func(_ context.Context, _ int64, update *domain.UserUpdate) error {
+					Expect(update.Email.MustGet()).To(Equal("john.doe@example.com"))
+					return nil
+				})
+				userTokenRepoMock.EXPECT().Delete(ctx, int64(1), domain.TokenTypeEmailChange).Return(nil)
+				userTokenRepoMock.EXPECT().Delete(ctx, int64(1), domain.TokenTypeEmailRevert).Return(nil)
+				sessionSvcMock.EXPECT().RevokeAll(ctx, int64(1), "").Return(nil)
+			})
+			It("should restore the previous address and sign out everywhere", func() {
+				Expect(actErr).To(BeNil())
+			})
+		})
+		When("the change is still pending", func() {
+			BeforeEach(func() {
+				userRepoMock.EXPECT().FindByID(ctx, int64(1)).Return(&domain.User{ID: 1, Email: "john.doe@example.com"}, nil)
+				userTokenRepoMock.EXPECT().Delete(ctx, int64(1), domain.TokenTypeEmailChange).Return(nil)
+				userTokenRepoMock.EXPECT().Delete(ctx, int64(1), domain.TokenTypeEmailRevert).Return(nil)
+				sessionSvcMock.EXPECT().RevokeAll(ctx, int64(1), "").Return(nil)
+			})
+			It("should cancel the change and sign out everywhere", func() {
+				Expect(actErr).To(BeNil())
 			})
 		})
 	})
@@ -190,13 +374,17 @@ var _ = Describe("User Service", Label("unit", "usecase"), func() {
 			uid             int64
 			currentPassword string
 			newPassword     string
+			throttleErr     error
 		)
 		BeforeEach(func() {
 			uid = 1
 			currentPassword = "oldpassword"
 			newPassword = "newpassword"
+			throttleErr = nil
 		})
 		JustBeforeEach(func() {
+			throttlerMock.EXPECT().Check(ctx, gomock.Any()).Return(throttleErr).AnyTimes()
+			throttlerMock.EXPECT().Succeed(ctx, gomock.Any()).Return(nil).AnyTimes()
 			actErr = svc.ChangePassword(ctx, uid, currentPassword, newPassword)
 		})
 		When("user is not found", func() {
@@ -214,14 +402,62 @@ var _ = Describe("User Service", Label("unit", "usecase"), func() {
 					Password: "hashedpassword",
 				}, nil)
 				passwordHasherMock.EXPECT().Verify(currentPassword, "hashedpassword").Return(false, nil)
+				throttlerMock.EXPECT().Fail(ctx, gomock.Any()).Return(nil)
 			})
-			It("should return a validation error", func() {
+			It("should count the failure and return a validation error", func() {
 				var vErr *validator.ValidationError
 				Expect(errors.As(actErr, &vErr)).To(BeTrue())
 				Expect(vErr.First().Field).To(Equal("current_password"))
 				Expect(vErr.First().Message).To(Equal("Current password is incorrect"))
 			})
 		})
+		When("the account is locked after failed attempts", func() {
+			BeforeEach(func() {
+				throttleErr = errdefs.ErrLocked().WithRetryAfter(time.Minute)
+				userRepoMock.EXPECT().FindByID(ctx, int64(1)).Return(&domain.User{
+					ID:       1,
+					Password: "hashedpassword",
+				}, nil)
+			})
+			It("should not check the password", func() {
+				var appErr *errdefs.AppError
+				Expect(errors.As(actErr, &appErr)).To(BeTrue())
+				Expect(appErr.Status).To(Equal(423))
+			})
+		})
+		When("the new password breaks the password policy", func() {
+			BeforeEach(func() {
+				userRepoMock.EXPECT().FindByID(ctx, int64(1)).Return(&domain.User{
+					ID:       1,
+					Password: "hashedpassword",
+				}, nil)
+				passwordHasherMock.EXPECT().Verify(currentPassword, "hashedpassword").Return(true, nil)
+				passwordPolicyMock.EXPECT().Validate(ctx, "new_password", newPassword, gomock.Any()).
+					Return(validator.NewError("new_password", "This password is too common. Please choose a different one."))
+			})
+			It("should return the policy error without changing the password", func() {
+				var vErr *validator.ValidationError
+				Expect(errors.As(actErr, &vErr)).To(BeTrue())
+				Expect(vErr.First().Field).To(Equal("new_password"))
+			})
+		})
+		When("the new password was used recently", func() {
+			BeforeEach(func() {
+				userRepoMock.EXPECT().FindByID(ctx, int64(1)).Return(&domain.User{
+					ID:       1,
+					Password: "hashedpassword",
+				}, nil)
+				passwordHasherMock.EXPECT().Verify(currentPassword, "hashedpassword").Return(true, nil)
+				passwordPolicyMock.EXPECT().Validate(ctx, "new_password", newPassword, gomock.Any()).Return(nil)
+				historyMock.EXPECT().Check(ctx, "new_password", newPassword, gomock.Any()).
+					Return(validator.NewError("new_password", "You have used this password recently. Please choose a different one."))
+			})
+			It("should reject it without changing the password", func() {
+				var vErr *validator.ValidationError
+				Expect(errors.As(actErr, &vErr)).To(BeTrue())
+				Expect(vErr.First().Field).To(Equal("new_password"))
+			})
+		})
 		When("there is an error during password verification", func() {
 			BeforeEach(func() {
 				userRepoMock.EXPECT().FindByID(ctx, int64(1)).Return(&domain.User{
@@ -241,15 +477,40 @@ var _ = Describe("User Service", Label("unit", "usecase"), func() {
 					Password: "hashedpassword",
 				}, nil)
 				passwordHasherMock.EXPECT().Verify(currentPassword, "hashedpassword").Return(true, nil)
+				passwordPolicyMock.EXPECT().Validate(ctx, "new_password", newPassword, gomock.Any()).Return(nil)
+				historyMock.EXPECT().Check(ctx, "new_password", newPassword, gomock.Any()).Return(nil)
 				passwordHasherMock.EXPECT().Hash(newPassword).Return("newhashedpassword", nil)
 				userRepoMock.EXPECT().Update(ctx, int64(1), &domain.UserUpdate{
 					Password: omit.From("newhashedpassword"),
 				}).Return(nil)
+				historyMock.EXPECT().Record(ctx, int64(1), "hashedpassword").Return(nil)
+				sessionSvcMock.EXPECT().RevokeAll(ctx, int64(1), "").Return(nil)
 			})
 			It("should change the password successfully", func() {
 				Expect(actErr).To(BeNil())
 			})
 		})
+		When("password is changed from an authenticated session", func() {
+			BeforeEach(func() {
+				ctx = domain.ContextWithClaims(ctx, &domain.JWTClaims{ID: 1, SessionID: "session-1"})
+				userRepoMock.EXPECT().FindByID(ctx, int64(1)).Return(&domain.User{
+					ID:       1,
+					Password: "hashedpassword",
+				}, nil)
+				passwordHasherMock.EXPECT().Verify(currentPassword, "hashedpassword").Return(true, nil)
+				passwordPolicyMock.EXPECT().Validate(ctx, "new_password", newPassword, gomock.Any()).Return(nil)
+				historyMock.EXPECT().Check(ctx, "new_password", newPassword, gomock.Any()).Return(nil)
+				passwordHasherMock.EXPECT().Hash(newPassword).Return("newhashedpassword", nil)
+				userRepoMock.EXPECT().Update(ctx, int64(1), &domain.UserUpdate{
+					Password: omit.From("newhashedpassword"),
+				}).Return(nil)
+				historyMock.EXPECT().Record(ctx, int64(1), "hashedpassword").Return(nil)
+				sessionSvcMock.EXPECT().RevokeAll(ctx, int64(1), "session-1").Return(nil)
+			})
+			It("should revoke every other session", func() {
+				Expect(actErr).To(BeNil())
+			})
+		})
 		When("there is an error during password hashing", func() {
 			BeforeEach(func() {
 				userRepoMock.EXPECT().FindByID(ctx, int64(1)).Return(&domain.User{
@@ -257,6 +518,8 @@ var _ = Describe("User Service", Label("unit", "usecase"), func() {
 					Password: "hashedpassword",
 				}, nil)
 				passwordHasherMock.EXPECT().Verify(currentPassword, "hashedpassword").Return(true, nil)
+				passwordPolicyMock.EXPECT().Validate(ctx, "new_password", newPassword, gomock.Any()).Return(nil)
+				historyMock.EXPECT().Check(ctx, "new_password", newPassword, gomock.Any()).Return(nil)
 				passwordHasherMock.EXPECT().Hash(newPassword).Return("", errors.New("bcrypt error"))
 			})
 			It("bubbles the error", func() {
@@ -270,6 +533,8 @@ var _ = Describe("User Service", Label("unit", "usecase"), func() {
 					Password: "hashedpassword",
 				}, nil)
 				passwordHasherMock.EXPECT().Verify(currentPassword, "hashedpassword").Return(true, nil)
+				passwordPolicyMock.EXPECT().Validate(ctx, "new_password", newPassword, gomock.Any()).Return(nil)
+				historyMock.EXPECT().Check(ctx, "new_password", newPassword, gomock.Any()).Return(nil)
 				passwordHasherMock.EXPECT().Hash(newPassword).Return("newhashedpassword", nil)
 				userRepoMock.EXPECT().Update(ctx, int64(1), &domain.UserUpdate{
 					Password: omit.From("newhashedpassword"),
@@ -291,6 +556,8 @@ var _ = Describe("User Service", Label("unit", "usecase"), func() {
 			password = "userpassword"
 		})
 		JustBeforeEach(func() {
+			throttlerMock.EXPECT().Check(ctx, gomock.Any()).Return(nil).AnyTimes()
+			throttlerMock.EXPECT().Succeed(ctx, gomock.Any()).Return(nil).AnyTimes()
 			actErr = svc.Delete(ctx, uid, password)
 		})
 		When("user is not found", func() {
@@ -308,8 +575,9 @@ var _ = Describe("User Service", Label("unit", "usecase"), func() {
 					Password: "hashedpassword",
 				}, nil)
 				passwordHasherMock.EXPECT().Verify(password, "hashedpassword").Return(false, nil)
+				throttlerMock.EXPECT().Fail(ctx, gomock.Any()).Return(nil)
 			})
-			It("should return a validation error", func() {
+			It("should count the failure and return a validation error", func() {
 				var vErr *validator.ValidationError
 				Expect(errors.As(actErr, &vErr)).To(BeTrue())
 				Expect(vErr.First().Field).To(Equal("password"))
@@ -335,9 +603,10 @@ var _ = Describe("User Service", Label("unit", "usecase"), func() {
 					Password: "hashedpassword",
 				}, nil)
 				passwordHasherMock.EXPECT().Verify(password, "hashedpassword").Return(true, nil)
+				sessionSvcMock.EXPECT().RevokeAll(ctx, int64(1), "").Return(nil)
 				userRepoMock.EXPECT().Delete(ctx, int64(1)).Return(nil)
 			})
-			It("should delete the user successfully", func() {
+			It("should revoke every session and delete the user", func() {
 				Expect(actErr).To(BeNil())
 			})
 		})
@@ -348,6 +617,7 @@ var _ = Describe("User Service", Label("unit", "usecase"), func() {
 					Password: "hashedpassword",
 				}, nil)
 				passwordHasherMock.EXPECT().Verify(password, "hashedpassword").Return(true, nil)
+				sessionSvcMock.EXPECT().RevokeAll(ctx, int64(1), "").Return(nil)
 				userRepoMock.EXPECT().Delete(ctx, int64(1)).Return(errors.New("db down"))
 			})
 			It("bubbles the error", func() {
@@ -0,0 +1,85 @@
+package totp_test
+
+import (
+	"encoding/base32"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/akfaiz/go-vue-starter-kit/pkg/totp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// rfcSecret is the SHA1 seed from RFC 6238 Appendix B.
+var rfcSecret = base32.StdEncoding.EncodeToString([]byte("12345678901234567890"))
+
+func TestGenerateCode(t *testing.T) {
+	// RFC 6238 test vectors truncated to 6 digits.
+	vectors := map[int64]string{
+		59:          "287082",
+		1111111109:  "081804",
+		1111111111:  "050471",
+		1234567890:  "005924",
+		2000000000:  "279037",
+		20000000000: "353130",
+	}
+	for unix, want := range vectors {
+		code, err := totp.GenerateCode(rfcSecret, time.Unix(unix, 0))
+
+		require.NoError(t, err)
+		assert.Equal(t, want, code, "time %d", unix)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	now := time.Unix(1234567890, 0)
+
+	t.Run("should accept the current code", func(t *testing.T) {
+		assert.True(t, totp.Validate(rfcSecret, "005924", now, 1))
+	})
+
+	t.Run("should accept a code from the previous step within skew", func(t *testing.T) {
+		code, err := totp.GenerateCode(rfcSecret, now.Add(-totp.Period))
+		require.NoError(t, err)
+
+		assert.True(t, totp.Validate(rfcSecret, code, now, 1))
+		assert.False(t, totp.Validate(rfcSecret, code, now, 0))
+	})
+
+	t.Run("should reject codes outside the skew window", func(t *testing.T) {
+		code, err := totp.GenerateCode(rfcSecret, now.Add(-3*totp.Period))
+		require.NoError(t, err)
+
+		assert.False(t, totp.Validate(rfcSecret, code, now, 1))
+	})
+
+	t.Run("should reject malformed input", func(t *testing.T) {
+		assert.False(t, totp.Validate(rfcSecret, "", now, 1))
+		assert.False(t, totp.Validate(rfcSecret, "12345", now, 1))
+		assert.False(t, totp.Validate("not base32!", "005924", now, 1))
+	})
+}
+
+func TestGenerateSecret(t *testing.T) {
+	secret1, err := totp.GenerateSecret()
+	require.NoError(t, err)
+	secret2, err := totp.GenerateSecret()
+	require.NoError(t, err)
+
+	assert.Len(t, secret1, 32)
+	assert.NotEqual(t, secret1, secret2)
+
+	code, err := totp.GenerateCode(secret1, time.Now())
+	require.NoError(t, err)
+	assert.True(t, totp.Validate(secret1, code, time.Now(), 1))
+}
+
+func TestKeyURI(t *testing.T) {
+	uri := totp.KeyURI("Go Vue", "john@example.com", "JBSWY3DPEHPK3PXP")
+
+	assert.True(t, strings.HasPrefix(uri, "otpauth://totp/Go%20Vue:john@example.com?"))
+	assert.Contains(t, uri, "secret=JBSWY3DPEHPK3PXP")
+	assert.Contains(t, uri, "issuer=Go+Vue")
+	assert.Contains(t, uri, "digits=6")
+}
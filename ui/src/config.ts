@@ -6,6 +6,8 @@ export const appConfig = (() => {
     return {
       env: import.meta.env.MODE,
       apiBaseUrl: import.meta.env.VITE_API_BASE_URL || '/api',
+      magicLinkEnabled: import.meta.env.VITE_MAGIC_LINK_ENABLED === 'true',
+      authMode: import.meta.env.VITE_AUTH_MODE === 'cookie' ? 'cookie' as const : 'bearer' as const,
     }
   }
 
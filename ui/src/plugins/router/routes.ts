@@ -26,6 +26,17 @@ export const routes = [
         meta: { guestOnly: true },
         component: () => import('@/pages/auth/login.vue'),
       },
+      {
+        path: 'magic-link',
+        name: 'magic-link',
+        meta: { guestOnly: true },
+        component: () => import('@/pages/auth/magic-link.vue'),
+      },
+      {
+        path: 'oauth/callback',
+        name: 'oauth-callback',
+        component: () => import('@/pages/oauth/callback.vue'),
+      },
       {
         path: 'register',
         name: 'register',
@@ -44,12 +55,28 @@ export const routes = [
         meta: { guestOnly: true },
         component: () => import('@/pages/auth/reset-password.vue'),
       },
+      {
+        path: 'password-expired',
+        name: 'password-expired',
+        meta: { guestOnly: true },
+        component: () => import('@/pages/auth/password-expired.vue'),
+      },
       {
         path: 'verify-email',
         name: 'verify-email',
         meta: { requiresAuth: true },
         component: () => import('@/pages/auth/verify-email.vue'),
       },
+      {
+        path: 'confirm-email',
+        name: 'confirm-email',
+        component: () => import('@/pages/auth/confirm-email.vue'),
+      },
+      {
+        path: 'revert-email',
+        name: 'revert-email',
+        component: () => import('@/pages/auth/revert-email.vue'),
+      },
       {
         path: '/:pathMatch(.*)*',
         component: () => import('@/pages/[...error].vue'),
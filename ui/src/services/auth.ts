@@ -1,7 +1,8 @@
 // src/services/auth.ts
 /* eslint-disable camelcase */
 
-import { clearAuthTokens, setAuthTokens } from '@/utils/token'
+import { appConfig } from '@/config'
+import { clearAuthTokens, cookieAuth, setAuthTokens } from '@/utils/token'
 import type { ApiEnvelope, ApiMessage } from './response'
 
 /* ------------------------------- Types ----------------------------------- */
@@ -11,6 +12,13 @@ export interface User {
   name: string
   email: string
   email_verified_at?: string | null
+  // New address awaiting confirmation through the link sent to it
+  pending_email?: string | null
+  two_factor_enabled?: boolean
+  roles?: string[]
+  permissions?: string[]
+  // Set while an administrator is signed in as this user
+  impersonator?: { id: number, name: string, email: string } | null
   created_at?: string
   updated_at?: string
 }
@@ -34,37 +42,146 @@ export interface ResetPasswordRequest {
   password_confirmation: string
 }
 
+export interface ChangeExpiredPasswordRequest {
+  email: string
+  current_password: string
+  password: string
+  password_confirmation: string
+}
+
+// Empty in cookie auth mode, where the API sets the tokens as HttpOnly cookies
 export interface TokenResponse {
-  access_token: string
-  refresh_token: string | null
+  access_token?: string
+  refresh_token?: string | null
+}
+
+export interface LoginResponse {
+  access_token?: string
+  refresh_token?: string
+  two_factor_required: boolean
+  challenge_token?: string
+}
+
+export interface VerifyTwoFactorRequest {
+  challenge_token: string
+  code?: string
+  recovery_code?: string
+}
+
+export interface VerifyMagicLinkRequest {
+  token: string
+  email: string
 }
 
 /* ----------------------------- Services ---------------------------------- */
 
-/** /login -> { status, message, data: { access_token, refresh_token } } */
-export async function login(payload: LoginRequest): Promise<TokenResponse> {
-  const res = await $api.post<ApiEnvelope<TokenResponse>>('/v1/auth/login', payload)
+/**
+ * /login -> { status, message, data: { access_token, refresh_token } }
+ * or, with two-factor enabled, { data: { two_factor_required: true, challenge_token } }
+ */
+export async function login(payload: LoginRequest): Promise<LoginResponse> {
+  const res = await $api.post<ApiEnvelope<LoginResponse>>('/v1/auth/login', payload)
 
-  const { access_token, refresh_token } = res.data.data ?? {}
+  const data = res.data.data
+  if (data?.two_factor_required)
+    return data
 
-  if (!access_token)
+  if (!data || (!cookieAuth && !data.access_token))
     throw new Error('Login failed: access_token missing in response')
 
-  setAuthTokens(access_token, refresh_token ?? '')
+  setAuthTokens(data.access_token ?? '', data.refresh_token ?? '')
+
+  return data
+}
+
+/** Problem type of a login whose password is past its maximum age; see changeExpiredPassword */
+export const PASSWORD_EXPIRED = '/problems/password-expired'
+
+/** /password/expired -> same as /login, after replacing the expired password */
+export async function changeExpiredPassword(payload: ChangeExpiredPasswordRequest): Promise<LoginResponse> {
+  const res = await $api.post<ApiEnvelope<LoginResponse>>('/v1/auth/password/expired', payload)
+
+  const data = res.data.data
+  if (data?.two_factor_required)
+    return data
+
+  if (!data || (!cookieAuth && !data.access_token))
+    throw new Error('Password change failed: access_token missing in response')
+
+  setAuthTokens(data.access_token ?? '', data.refresh_token ?? '')
+
+  return data
+}
+
+/** /two-factor/verify -> { status, message, data: { access_token, refresh_token } } */
+export async function verifyTwoFactor(payload: VerifyTwoFactorRequest): Promise<TokenResponse> {
+  const res = await $api.post<ApiEnvelope<TokenResponse>>('/v1/auth/two-factor/verify', payload)
+
+  const { access_token, refresh_token } = res.data.data ?? {}
+
+  if (!cookieAuth && !access_token)
+    throw new Error('Two-factor verification failed: access_token missing in response')
+
+  setAuthTokens(access_token ?? '', refresh_token ?? '')
 
   return { access_token, refresh_token }
 }
 
+/** /oauth/providers -> { status, data: { providers } } */
+export async function oauthProviders(): Promise<string[]> {
+  const res = await $api.get<ApiEnvelope<{ providers: string[] }>>('/v1/auth/oauth/providers')
+
+  return res.data.data?.providers ?? []
+}
+
+/** Full-page URL that starts a social login; the API redirects to the provider. */
+export function oauthRedirectUrl(provider: string): string {
+  return `${appConfig.apiBaseUrl}/v1/auth/oauth/${encodeURIComponent(provider)}/redirect`
+}
+
+/** Tokens handed over by the OAuth callback in the URL fragment (none in cookie auth mode). */
+export function completeOAuthLogin(accessToken: string, refreshToken: string): void {
+  setAuthTokens(accessToken, refreshToken)
+}
+
+export async function sendMagicLink(email: string): Promise<ApiMessage> {
+  const { data } = await $api.post<ApiMessage>('/v1/auth/magic-link', { email })
+
+  return data
+}
+
+/** /magic-link/verify -> same shape as /login */
+export async function verifyMagicLink(payload: VerifyMagicLinkRequest): Promise<LoginResponse> {
+  const res = await $api.post<ApiEnvelope<LoginResponse>>('/v1/auth/magic-link/verify', payload)
+
+  const data = res.data.data
+  if (data?.two_factor_required)
+    return data
+
+  if (!data || (!cookieAuth && !data.access_token))
+    throw new Error('Sign-in failed: access_token missing in response')
+
+  setAuthTokens(data.access_token ?? '', data.refresh_token ?? '')
+
+  return data
+}
+
 /** /register -> { status, message } (no tokens) */
-export async function register(payload: RegisterRequest): Promise<TokenResponse> {
-  const { data } = await $api.post<ApiEnvelope<TokenResponse>>('/v1/auth/register', payload)
+/**
+ * /register -> 201 { data: { access_token, refresh_token } }
+ * or, in stealth mode, 202 { message } and the user signs in after checking their email (returns null)
+ */
+export async function register(payload: RegisterRequest): Promise<TokenResponse | null> {
+  const { status, data } = await $api.post<ApiEnvelope<TokenResponse>>('/v1/auth/register', payload)
+  if (status === 202)
+    return null
 
   const { access_token, refresh_token } = data.data ?? {}
 
-  if (!access_token)
+  if (!cookieAuth && !access_token)
     throw new Error('Registration failed: access_token missing in response')
 
-  setAuthTokens(access_token, refresh_token ?? '')
+  setAuthTokens(access_token ?? '', refresh_token ?? '')
 
   return { access_token, refresh_token }
 }
@@ -80,10 +197,19 @@ export async function me(): Promise<User> {
 }
 
 export async function logout(): Promise<void> {
-  // Optional: await $api.post("/v1/auth/logout").catch(() => {});
+  await $api.post('/v1/auth/logout').catch(() => {})
   clearAuthTokens()
 }
 
+/** Problem type of actions that need confirmPassword first; retry them afterwards */
+export const PASSWORD_CONFIRMATION_REQUIRED = '/problems/password-confirmation-required'
+
+export async function confirmPassword(password: string): Promise<ApiMessage> {
+  const { data } = await $api.post<ApiMessage>('/v1/auth/confirm-password', { password })
+
+  return data
+}
+
 export async function sendPasswordResetEmail(email: string): Promise<ApiMessage> {
   const { data } = await $api.post<ApiMessage>('/v1/auth/forgot-password', { email })
 
@@ -9,7 +9,6 @@ export interface ChangePasswordRequest {
 
 export interface UpdateProfileRequest {
   name: string
-  email: string
 }
 
 export async function updateProfile(payload: UpdateProfileRequest): Promise<ApiMessage> {
@@ -18,6 +17,28 @@ export async function updateProfile(payload: UpdateProfileRequest): Promise<ApiM
   return data
 }
 
+/** Needs a recent sign-in; otherwise fails with PASSWORD_CONFIRMATION_REQUIRED */
+export async function changeEmail(email: string): Promise<ApiMessage> {
+  const { data } = await $api.put<ApiMessage>('/v1/profile/email', { email })
+
+  return data
+}
+
+export async function confirmEmailChange(token: string, userId: number): Promise<ApiMessage> {
+  const { data } = await $api.post<ApiMessage>('/v1/auth/email/change/confirm', { token, user_id: userId })
+
+  return data
+}
+
+export async function revertEmailChange(token: string, userId: number): Promise<ApiMessage> {
+  const { data } = await $api.post<ApiMessage>('/v1/auth/email/change/revert', { token, user_id: userId })
+
+  // Every session is revoked, including this browser's
+  clearAuthTokens()
+
+  return data
+}
+
 export async function changePassword(payload: ChangePasswordRequest): Promise<ApiMessage> {
   const { data } = await $api.put<ApiMessage>('/v1/profile/password', payload)
 
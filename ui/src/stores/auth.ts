@@ -1,10 +1,14 @@
 import { defineStore } from 'pinia'
-import type { LoginRequest, RegisterRequest, User } from '@/services/auth'
+import type { ChangeExpiredPasswordRequest, LoginRequest, LoginResponse, RegisterRequest, TokenResponse, User, VerifyMagicLinkRequest, VerifyTwoFactorRequest } from '@/services/auth'
 import {
+  changeExpiredPassword as changeExpiredPasswordSvc,
+  completeOAuthLogin as completeOAuthLoginSvc,
   login as loginSvc,
   logout as logoutSvc,
   me as meSvc,
   register as registerSvc,
+  verifyMagicLink as verifyMagicLinkSvc,
+  verifyTwoFactor as verifyTwoFactorSvc,
 } from '@/services/auth'
 
 const ME_TTL_MS = 5 * 60 * 1000 // cache /me for 5 minutes
@@ -69,18 +73,58 @@ export const useAuthStore = defineStore('auth', {
       return this.fetchMe(false)
     },
 
-    async login(payload: LoginRequest): Promise<User | null> {
-      await loginSvc(payload) // tokens set by service
+    async login(payload: LoginRequest): Promise<LoginResponse> {
+      const res = await loginSvc(payload) // tokens set by service
+      if (res.two_factor_required)
+        return res // caller must complete the two-factor challenge
+
+      await this.fetchMe(true) // refresh current user
+
+      return res
+    },
+
+    async changeExpiredPassword(payload: ChangeExpiredPasswordRequest): Promise<LoginResponse> {
+      const res = await changeExpiredPasswordSvc(payload) // tokens set by service
+      if (res.two_factor_required)
+        return res // caller must complete the two-factor challenge
+
+      await this.fetchMe(true) // refresh current user
+
+      return res
+    },
+
+    async verifyTwoFactor(payload: VerifyTwoFactorRequest): Promise<User | null> {
+      await verifyTwoFactorSvc(payload) // tokens set by service
 
       return this.fetchMe(true) // refresh current user
     },
 
-    async register(payload: RegisterRequest): Promise<User | null> {
-      await registerSvc(payload) // tokens set by service
+    async verifyMagicLink(payload: VerifyMagicLinkRequest): Promise<LoginResponse> {
+      const res = await verifyMagicLinkSvc(payload) // tokens set by service
+      if (res.two_factor_required)
+        return res // caller must complete the two-factor challenge
+
+      await this.fetchMe(true) // refresh current user
+
+      return res
+    },
+
+    async completeOAuthLogin(accessToken: string, refreshToken: string): Promise<User | null> {
+      completeOAuthLoginSvc(accessToken, refreshToken)
 
       return this.fetchMe(true) // refresh current user
     },
 
+    async register(payload: RegisterRequest): Promise<TokenResponse | null> {
+      const res = await registerSvc(payload) // tokens set by service
+      if (!res)
+        return res // stealth mode: the user signs in after checking their email
+
+      await this.fetchMe(true) // refresh current user
+
+      return res
+    },
+
     async logout(): Promise<void> {
       await logoutSvc() // clears tokens
       this.user = null
@@ -6,6 +6,8 @@ declare global {
     __APP_CONFIG__?: {
       env: string;
       apiBaseUrl: string;
+      magicLinkEnabled?: boolean;
+      authMode?: 'bearer' | 'cookie';
     };
   }
 }
@@ -9,7 +9,7 @@ import axios, {
 } from 'axios'
 import { toAppError } from './errors'
 import type { Tokens } from './token'
-import { clearAuthTokens, readTokens, setAuthTokens } from './token'
+import { CSRF_HEADER, clearAuthTokens, cookieAuth, isAccessTokenSet, readCsrfToken, readTokens, setAuthTokens } from './token'
 import { appConfig } from '@/config'
 
 /* ------------------------------ Config knobs ----------------------------- */
@@ -46,7 +46,8 @@ const refreshClient = axios.create({
 })
 
 /**
- * Queue for requests while a refresh is in-flight.
+ * Queue for requests while a refresh is in-flight. In cookie auth mode the
+ * refreshed token is a cookie, so the queued requests get an empty string.
  */
 let isRefreshing = false
 let pendingQueue: Array<{
@@ -56,7 +57,7 @@ let pendingQueue: Array<{
 
 function processQueue(error: unknown, token: string | null) {
   pendingQueue.forEach(({ resolve, reject }) => {
-    if (error || !token)
+    if (error || token === null)
       reject(error ?? new Error('No token'))
     else resolve(token)
   })
@@ -64,6 +65,16 @@ function processQueue(error: unknown, token: string | null) {
 }
 
 async function refreshTokenOrThrow(): Promise<string> {
+  if (cookieAuth) {
+    // The refresh token cookie is only sent to this endpoint
+    await refreshClient.post('/v1/auth/refresh-token', {}, {
+      withCredentials: true,
+      headers: { [CSRF_HEADER]: readCsrfToken() ?? '' },
+    })
+
+    return ''
+  }
+
   const tokens: Tokens = readTokens()
   if (!tokens.refreshToken)
     throw new Error('No refresh token')
@@ -85,14 +96,23 @@ export function createApi(options: ApiOptions = {}): AxiosInstance {
   const api = axios.create({
     baseURL: options.baseURL ?? API_BASE,
     timeout: options.timeout ?? 15_000,
+    withCredentials: cookieAuth,
     headers: {
       'Accept': 'application/json',
       'Content-Type': 'application/json',
     },
   })
 
-  // Attach Authorization header
+  // Attach Authorization header, or the CSRF token in cookie auth mode
   api.interceptors.request.use((config: InternalAxiosRequestConfig) => {
+    if (cookieAuth) {
+      const csrfToken = readCsrfToken()
+      if (csrfToken)
+        config.headers.set(CSRF_HEADER, csrfToken)
+
+      return config
+    }
+
     const tokens: Tokens = readTokens()
 
     if (options.withAuth && tokens.accessToken)
@@ -127,7 +147,7 @@ export function createApi(options: ApiOptions = {}): AxiosInstance {
         }
         const tokens: Tokens = readTokens()
 
-        if (!tokens.refreshToken) {
+        if (cookieAuth ? !isAccessTokenSet() : !tokens.refreshToken) {
           clearAuthTokens()
 
           return Promise.reject(error)
@@ -142,7 +162,8 @@ export function createApi(options: ApiOptions = {}): AxiosInstance {
               resolve: newToken => {
                 if (!original.headers)
                   original.headers = new AxiosHeaders()
-                original.headers.set('Authorization', `Bearer ${newToken}`)
+                if (newToken)
+                  original.headers.set('Authorization', `Bearer ${newToken}`)
                 resolve(api(original))
               },
               reject: err => reject(err),
@@ -160,7 +181,8 @@ export function createApi(options: ApiOptions = {}): AxiosInstance {
           // Retry original with fresh token
           if (!original.headers)
             original.headers = new AxiosHeaders()
-          original.headers.set('Authorization', `Bearer ${newAccessToken}`)
+          if (newAccessToken)
+            original.headers.set('Authorization', `Bearer ${newAccessToken}`)
 
           return api(original)
         }
@@ -1,3 +1,5 @@
+import { appConfig } from '@/config'
+
 export interface Tokens {
   accessToken: string | null
   refreshToken: string | null
@@ -5,6 +7,24 @@ export interface Tokens {
 
 const STORAGE_KEY = 'auth.tokens'
 
+/**
+ * In cookie auth mode the API keeps the tokens in HttpOnly cookies the app cannot
+ * read. The readable CSRF cookie set next to them marks a signed-in browser and is
+ * echoed in the X-CSRF-Token header of state-changing requests.
+ */
+export const cookieAuth = appConfig.authMode === 'cookie'
+
+const CSRF_COOKIE = 'csrf_token'
+
+export const CSRF_HEADER = 'X-CSRF-Token'
+
+export function readCsrfToken(): string | null {
+  const prefix = `${CSRF_COOKIE}=`
+  const cookie = document.cookie.split('; ').find(c => c.startsWith(prefix))
+
+  return cookie ? decodeURIComponent(cookie.slice(prefix.length)) : null
+}
+
 // let tokens: Tokens = readTokens()
 
 export function readTokens(): Tokens {
@@ -31,15 +51,26 @@ function persistTokens(next: Tokens) {
 
 /** Call this after login or refresh */
 export function setAuthTokens(accessToken: string, refreshToken: string) {
+  if (cookieAuth)
+    return
   persistTokens({ accessToken, refreshToken })
 }
 
 /** Call this on logout */
 export function clearAuthTokens() {
+  if (cookieAuth) {
+    // The API expires the cookies on logout; this covers sessions that died without one
+    document.cookie = `${CSRF_COOKIE}=; Max-Age=0; Path=/`
+
+    return
+  }
   persistTokens({ accessToken: null, refreshToken: null })
 }
 
 export function isAccessTokenSet(): boolean {
+  if (cookieAuth)
+    return !!readCsrfToken()
+
   const tokens = readTokens()
 
   return !!tokens.accessToken